@@ -19,9 +19,9 @@ func main() {
 		_ = godotenv.Load(".env")
 	}
 
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		log.Fatal("JWT_SECRET environment variable is required")
+	keyring, err := service.NewJWTKeyringFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to load JWT signing keys: %v", err)
 	}
 
 	// Initialize database connection
@@ -31,8 +31,11 @@ func main() {
 	}
 	defer dbPool.Close()
 
-	// Ensure rate limiter cleanup goroutine is stopped on shutdown
-	defer middleware.AuthRateLimiter.Stop()
+	// Rate limiters (in-memory by default, shared through Postgres when configured)
+	if err := middleware.ConfigureRateLimiters(repository.NewRateLimitRepository(dbPool)); err != nil {
+		log.Fatalf("Failed to configure rate limiters: %v", err)
+	}
+	defer middleware.StopRateLimiters()
 
 	// Get port from environment
 	port := os.Getenv("PORT")
@@ -46,39 +49,118 @@ func main() {
 	landingRepo := repository.NewLandingRepository(dbPool)
 	ruleRepo := repository.NewRuleRepository(dbPool)
 	contactRepo := repository.NewContactRepository(dbPool)
+	sequenceRepo := repository.NewSequenceRepository(dbPool)
+	campaignRepo := repository.NewCampaignRepository(dbPool)
+	deviceRepo := repository.NewDeviceRepository(dbPool)
+	tokenRepo := repository.NewTokenRepository(dbPool)
+	otpRepo := repository.NewOTPRepository(dbPool)
+	loginAttemptRepo := repository.NewLoginAttemptRepository(dbPool)
+	accountRepo := repository.NewAccountRepository(dbPool)
+	organizationRepo := repository.NewOrganizationRepository(dbPool)
+	apiKeyRepo := repository.NewAPIKeyRepository(dbPool)
+	webhookRepo := repository.NewWebhookRepository(dbPool)
+	leadRepo := repository.NewLeadRepository(dbPool)
+	mediaRepo := repository.NewMediaRepository(dbPool)
+	shortLinkRepo := repository.NewShortLinkRepository(dbPool)
 
 	// Services
-	authService := service.NewAuthService(userRepo, jwtSecret)
+	authService := service.NewAuthService(userRepo, deviceRepo, tokenRepo, loginAttemptRepo, keyring)
 	userService := service.NewUserService(userRepo)
-	uploadThingStore, uploadThingErr := service.NewUploadThingImageStoreFromEnv()
-	if uploadThingErr != nil {
-		log.Printf("UploadThing not configured: %v", uploadThingErr)
+	otpSender, err := service.NewOTPSenderFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to configure OTP sender: %v", err)
+	}
+	otpSecret := os.Getenv("OTP_SECRET")
+	if otpSecret == "" {
+		otpSecret = os.Getenv("JWT_SECRET")
+	}
+	if otpSecret == "" {
+		log.Fatal("OTP_SECRET is required when JWT_SECRET is not set")
+	}
+	otpService := service.NewOTPService(otpRepo, userRepo, otpSender, otpSecret)
+	imageStore, err := service.NewImageStoreFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to configure image store: %v", err)
 	}
-	templateService := service.NewTemplateService(templateRepo, uploadThingStore)
-	landingService := service.NewLandingService(landingRepo, uploadThingStore)
+	if imageStore == nil {
+		log.Printf("Image uploads disabled: set IMAGE_STORE or UPLOADTHING_TOKEN")
+	}
+	mediaService := service.NewMediaService(imageStore, mediaRepo)
+	shortLinkService := service.NewShortLinkService(shortLinkRepo)
+	landingService := service.NewLandingService(landingRepo, userRepo, mediaService)
+	templateService := service.NewTemplateService(templateRepo, userRepo, mediaService, landingService, shortLinkService)
 	ruleService := service.NewRuleService(ruleRepo)
-	contactService := service.NewContactService(contactRepo)
+	webhookService := service.NewWebhookService(webhookRepo)
+	contactService := service.NewContactService(contactRepo, webhookService)
+	sequenceService := service.NewSequenceService(sequenceRepo, templateRepo, webhookService)
+	campaignService := service.NewCampaignService(campaignRepo, templateRepo, deviceRepo, webhookService)
+	deviceService := service.NewDeviceService(deviceRepo, templateRepo, landingService, shortLinkService, webhookService, sequenceService, campaignService)
+	leadService := service.NewLeadService(leadRepo, userRepo, contactService, ruleService, deviceService)
+	organizationService := service.NewOrganizationService(organizationRepo, userRepo)
+	apiKeyService := service.NewAPIKeyService(apiKeyRepo, userRepo)
+	accountService := service.NewAccountService(accountRepo, userRepo, tokenRepo, templateRepo, ruleRepo, contactRepo, landingRepo, sequenceRepo, campaignRepo, mediaRepo, webhookRepo, apiKeyRepo)
+
+	// Background workers
+	deviceMonitor := service.NewDeviceMonitor(deviceRepo)
+	deviceMonitor.Start()
+	defer deviceMonitor.Stop()
+
+	accountPurger := service.NewAccountPurger(accountRepo, mediaService)
+	accountPurger.Start()
+	defer accountPurger.Stop()
+
+	webhookDispatcher := service.NewWebhookDispatcher(webhookRepo)
+	webhookDispatcher.Start()
+	defer webhookDispatcher.Stop()
+
+	if imageStore != nil {
+		mediaReconciler := service.NewMediaReconciler(mediaRepo, imageStore)
+		mediaReconciler.Start()
+		defer mediaReconciler.Stop()
+	}
 
 	// Handlers
-	authHandler := handler.NewAuthHandler(authService)
-	userHandler := handler.NewUserHandler(userService)
+	authHandler := handler.NewAuthHandler(authService, otpService)
+	otpHandler := handler.NewOTPHandler(otpService)
+	userHandler := handler.NewUserHandler(userService, accountService)
 	templateHandler := handler.NewTemplateHandler(templateService)
-	landingHandler := handler.NewLandingHandler(landingService, userService)
+	landingHandler := handler.NewLandingHandler(landingService, userService, leadService)
 	ruleHandler := handler.NewRuleHandler(ruleService)
-	syncHandler := handler.NewSyncHandler(userService, templateService, ruleService)
-	contactHandler := handler.NewContactHandler(contactService)
-	adminHandler := handler.NewAdminHandler(userService)
+	syncHandler := handler.NewSyncHandler(userService, templateService, ruleService, deviceService, landingService)
+	contactHandler := handler.NewContactHandler(contactService, sequenceService)
+	sequenceHandler := handler.NewSequenceHandler(sequenceService)
+	campaignHandler := handler.NewCampaignHandler(campaignService)
+	deviceHandler := handler.NewDeviceHandler(deviceService)
+	organizationHandler := handler.NewOrganizationHandler(organizationService)
+	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyService)
+	webhookHandler := handler.NewWebhookHandler(webhookService)
+	leadHandler := handler.NewLeadHandler(leadService)
+	mediaHandler := handler.NewMediaHandler(mediaService)
+	shortLinkHandler := handler.NewShortLinkHandler(shortLinkService)
+	adminHandler := handler.NewAdminHandler(userService, deviceService, authService)
 
 	// Setup router
 	router := api.SetupRouter(
 		authService,
+		apiKeyService,
+		organizationService,
 		authHandler,
+		otpHandler,
 		userHandler,
 		templateHandler,
 		landingHandler,
 		ruleHandler,
 		syncHandler,
 		contactHandler,
+		sequenceHandler,
+		campaignHandler,
+		deviceHandler,
+		organizationHandler,
+		apiKeyHandler,
+		webhookHandler,
+		leadHandler,
+		mediaHandler,
+		shortLinkHandler,
 		adminHandler,
 	)
 
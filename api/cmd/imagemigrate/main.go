@@ -0,0 +1,72 @@
+// Command imagemigrate moves the stored template and landing images, with their
+// variants, from one image store backend to another and updates their
+// image_url and image_key.
+// Both backends are configured from the same environment variables as the API.
+//
+//	go run ./cmd/imagemigrate -from uploadthing -to s3 -dry-run
+//	go run ./cmd/imagemigrate -from uploadthing -to s3 -delete-source
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"callflow/config"
+	"callflow/internal/repository"
+	"callflow/internal/service"
+
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	from := flag.String("from", "", "source backend: uploadthing, local or s3")
+	to := flag.String("to", "", "target backend: uploadthing, local or s3")
+	dryRun := flag.Bool("dry-run", false, "only report what would be moved")
+	deleteSource := flag.Bool("delete-source", false, "delete each source image once its row points at the copy")
+	flag.Parse()
+
+	if *from == "" || *to == "" || *from == *to {
+		log.Fatal("-from and -to must name two different backends")
+	}
+
+	if _, err := os.Stat(".env"); err == nil {
+		_ = godotenv.Load(".env")
+	}
+
+	source, err := service.NewImageStore(*from)
+	if err != nil {
+		log.Fatalf("Failed to configure source store: %v", err)
+	}
+	target, err := service.NewImageStore(*to)
+	if err != nil {
+		log.Fatalf("Failed to configure target store: %v", err)
+	}
+
+	dbPool, err := config.InitDBPool()
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer dbPool.Close()
+
+	migrator := service.NewImageMigrator(
+		repository.NewTemplateRepository(dbPool),
+		repository.NewLandingRepository(dbPool),
+		repository.NewMediaRepository(dbPool),
+		source,
+		target,
+		*dryRun,
+		*deleteSource,
+	)
+	result, err := migrator.Run(context.Background())
+	if err != nil {
+		log.Fatalf("Migration failed: %v", err)
+	}
+
+	fmt.Printf("moved %d, skipped %d, failed %d\n", result.Moved, result.Skipped, result.Failed)
+	if result.Failed > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,113 @@
+// Command jwtkey generates and retires JWT signing keys for JWT_KEYS_DIR.
+//
+//	go run ./cmd/jwtkey -dir keys                 # new EdDSA key, prints its kid
+//	go run ./cmd/jwtkey -dir keys -alg RS256      # new RSA key
+//	go run ./cmd/jwtkey -dir keys -retire <kid>   # keep only the public half
+package main
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+func main() {
+	dir := flag.String("dir", "keys", "directory holding the signing keys")
+	alg := flag.String("alg", "EdDSA", "signing algorithm: EdDSA or RS256")
+	kid := flag.String("kid", "", "key ID (default: date and random suffix)")
+	retire := flag.String("retire", "", "replace the private key of this kid with its public key")
+	flag.Parse()
+
+	if err := os.MkdirAll(*dir, 0o700); err != nil {
+		log.Fatalf("Failed to create key directory: %v", err)
+	}
+
+	if *retire != "" {
+		if err := retireKey(*dir, *retire); err != nil {
+			log.Fatalf("Failed to retire key: %v", err)
+		}
+		fmt.Printf("retired %s\n", *retire)
+		return
+	}
+
+	if *kid == "" {
+		suffix := make([]byte, 3)
+		if _, err := rand.Read(suffix); err != nil {
+			log.Fatalf("Failed to generate key ID: %v", err)
+		}
+		*kid = time.Now().UTC().Format("20060102") + "-" + hex.EncodeToString(suffix)
+	}
+
+	if err := generateKey(*dir, *kid, *alg); err != nil {
+		log.Fatalf("Failed to generate key: %v", err)
+	}
+	fmt.Println(*kid)
+}
+
+func generateKey(dir, kid, alg string) error {
+	path := filepath.Join(dir, kid+".pem")
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists", path)
+	}
+
+	var key crypto.Signer
+	var err error
+	switch alg {
+	case "EdDSA":
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	case "RS256":
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+	default:
+		return fmt.Errorf("unsupported algorithm %q", alg)
+	}
+	if err != nil {
+		return err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600)
+}
+
+func retireKey(dir, kid string) error {
+	path := filepath.Join(dir, kid+".pem")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PRIVATE KEY" {
+		return errors.New("not a PKCS#8 private key")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return err
+	}
+	signer, ok := parsed.(crypto.Signer)
+	if !ok {
+		return errors.New("unsupported private key")
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return err
+	}
+	pub := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	if err := os.WriteFile(filepath.Join(dir, kid+".pub.pem"), pub, 0o644); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
@@ -1,9 +1,12 @@
 package handler
 
 import (
+	"errors"
 	"strconv"
 
 	"callflow/internal/api/response"
+	"callflow/internal/domain/auth"
+	"callflow/internal/domain/device"
 	"callflow/internal/domain/user"
 
 	"github.com/gin-gonic/gin"
@@ -11,12 +14,18 @@ import (
 
 // AdminHandler handles admin HTTP requests
 type AdminHandler struct {
-	userService user.Service
+	userService   user.Service
+	deviceService device.Service
+	authService   auth.Service
 }
 
 // NewAdminHandler creates a new admin handler instance
-func NewAdminHandler(userService user.Service) *AdminHandler {
-	return &AdminHandler{userService: userService}
+func NewAdminHandler(userService user.Service, deviceService device.Service, authService auth.Service) *AdminHandler {
+	return &AdminHandler{
+		userService:   userService,
+		deviceService: deviceService,
+		authService:   authService,
+	}
 }
 
 // RegisterRoutes registers the admin routes
@@ -26,6 +35,10 @@ func (h *AdminHandler) RegisterRoutes(rg *gin.RouterGroup) {
 		admin.GET("/users", h.ListUsers)
 		admin.PUT("/users/:id/plan", h.UpdatePlan)
 		admin.PUT("/users/:id/status", h.UpdateStatus)
+		admin.POST("/users/:id/unlock", h.UnlockUser)
+		admin.GET("/locked-accounts", h.ListLockedAccounts)
+		admin.GET("/devices/unhealthy", h.ListUnhealthyDevices)
+		admin.GET("/devices/alerts", h.ListDeviceAlerts)
 	}
 }
 
@@ -56,6 +69,10 @@ func (h *AdminHandler) UpdatePlan(c *gin.Context) {
 	}
 
 	if err := h.userService.UpdatePlan(c.Request.Context(), id, req.Plan); err != nil {
+		if errors.Is(err, user.ErrPhoneNotVerified) {
+			response.Forbidden(c, response.ErrPhoneNotVerified, "User's phone number is not verified", "")
+			return
+		}
 		internalError(c, response.ErrUpdateFailed, "Failed to update plan", err)
 		return
 	}
@@ -86,3 +103,53 @@ func (h *AdminHandler) UpdateStatus(c *gin.Context) {
 
 	response.Success(c, gin.H{"message": "Status updated successfully"})
 }
+
+// ListLockedAccounts returns the phone numbers locked after repeated failed logins
+func (h *AdminHandler) ListLockedAccounts(c *gin.Context) {
+	accounts, err := h.authService.GetLockedAccounts(c.Request.Context())
+	if err != nil {
+		internalError(c, response.ErrListFailed, "Failed to list locked accounts", err)
+		return
+	}
+	response.Success(c, accounts)
+}
+
+// UnlockUser clears the failed-login lockout of a user
+func (h *AdminHandler) UnlockUser(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, response.ErrInvalidID, "Invalid user ID", err.Error())
+		return
+	}
+
+	if err := h.authService.Unlock(c.Request.Context(), id); err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			response.NotFound(c, response.ErrNotFound, "User not found", "")
+			return
+		}
+		internalError(c, response.ErrUpdateFailed, "Failed to unlock user", err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "User unlocked successfully"})
+}
+
+// ListUnhealthyDevices returns devices that reported problems or stopped sending heartbeats
+func (h *AdminHandler) ListUnhealthyDevices(c *gin.Context) {
+	devices, err := h.deviceService.GetUnhealthy(c.Request.Context())
+	if err != nil {
+		internalError(c, response.ErrListFailed, "Failed to list devices", err)
+		return
+	}
+	response.Success(c, devices)
+}
+
+// ListDeviceAlerts returns the open device alerts
+func (h *AdminHandler) ListDeviceAlerts(c *gin.Context) {
+	alerts, err := h.deviceService.GetOpenAlerts(c.Request.Context())
+	if err != nil {
+		internalError(c, response.ErrListFailed, "Failed to list device alerts", err)
+		return
+	}
+	response.Success(c, alerts)
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"callflow/internal/api/response"
+	"callflow/internal/domain/apikey"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// APIKeyHandler handles HTTP requests related to API keys
+type APIKeyHandler struct {
+	apiKeyService apikey.Service
+	validate      *validator.Validate
+}
+
+// NewAPIKeyHandler creates a new API key handler instance
+func NewAPIKeyHandler(apiKeyService apikey.Service) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+		validate:      validator.New(),
+	}
+}
+
+// RegisterRoutes registers the API key routes
+func (h *APIKeyHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	keys := rg.Group("/api-keys")
+	{
+		keys.GET("", h.GetKeys)
+		keys.POST("", h.CreateKey)
+		keys.DELETE("/:id", h.RevokeKey)
+	}
+}
+
+// GetKeys returns the API keys of the authenticated user
+func (h *APIKeyHandler) GetKeys(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	keys, err := h.apiKeyService.GetByUserID(c.Request.Context(), userID)
+	if err != nil {
+		internalError(c, response.ErrListFailed, "Failed to list API keys", err)
+		return
+	}
+
+	response.Success(c, keys)
+}
+
+// CreateKey creates an API key. The plaintext key is only returned here.
+func (h *APIKeyHandler) CreateKey(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req apikey.KeyCreate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, response.ErrInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		response.BadRequest(c, response.ErrValidationFailed, "Validation failed", err.Error())
+		return
+	}
+
+	key, err := h.apiKeyService.Create(c.Request.Context(), userID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, apikey.ErrInvalidScope):
+			response.BadRequest(c, response.ErrInvalidScope, err.Error(), "")
+		case errors.Is(err, apikey.ErrInvalidExpiry):
+			response.BadRequest(c, response.ErrValidationFailed, err.Error(), "")
+		case errors.Is(err, apikey.ErrTooManyKeys):
+			response.Conflict(c, response.ErrConflict, err.Error(), "")
+		default:
+			internalError(c, response.ErrCreateFailed, "Failed to create API key", err)
+		}
+		return
+	}
+
+	response.Created(c, key)
+}
+
+// RevokeKey revokes an API key of the authenticated user
+func (h *APIKeyHandler) RevokeKey(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, response.ErrInvalidID, "Invalid API key ID", err.Error())
+		return
+	}
+
+	if err := h.apiKeyService.Revoke(c.Request.Context(), id, userID); err != nil {
+		if errors.Is(err, apikey.ErrKeyNotFound) {
+			response.NotFound(c, response.ErrAPIKeyNotFound, "API key not found", "")
+			return
+		}
+		internalError(c, response.ErrDeleteFailed, "Failed to revoke API key", err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "API key revoked successfully"})
+}
@@ -2,10 +2,12 @@ package handler
 
 import (
 	"errors"
+	"net/http"
 
 	"callflow/internal/api/middleware"
 	"callflow/internal/api/response"
 	"callflow/internal/domain/auth"
+	"callflow/internal/domain/otp"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -14,13 +16,15 @@ import (
 // AuthHandler handles HTTP requests related to authentication
 type AuthHandler struct {
 	authService auth.Service
+	otpService  otp.Service
 	validate    *validator.Validate
 }
 
 // NewAuthHandler creates a new authentication handler instance
-func NewAuthHandler(authService auth.Service) *AuthHandler {
+func NewAuthHandler(authService auth.Service, otpService otp.Service) *AuthHandler {
 	return &AuthHandler{
 		authService: authService,
+		otpService:  otpService,
 		validate:    validator.New(),
 	}
 }
@@ -31,9 +35,21 @@ func (h *AuthHandler) RegisterRoutes(rg *gin.RouterGroup) {
 	{
 		authGroup.POST("/register", middleware.RateLimitAuth(), h.Register)
 		authGroup.POST("/login", middleware.RateLimitAuth(), h.Login)
+		authGroup.POST("/password/forgot", middleware.RateLimitAuth(), h.ForgotPassword)
+		authGroup.POST("/password/reset", middleware.RateLimitAuth(), h.ResetPassword)
 	}
 }
 
+// RegisterProtectedRoutes registers the authentication routes that require a token
+func (h *AuthHandler) RegisterProtectedRoutes(rg *gin.RouterGroup) {
+	rg.PUT("/user/password", middleware.RateLimitAuth(), h.ChangePassword)
+}
+
+// RegisterWellKnownRoutes registers the discovery routes served outside the API prefix
+func (h *AuthHandler) RegisterWellKnownRoutes(rg *gin.RouterGroup) {
+	rg.GET("/.well-known/jwks.json", h.JWKS)
+}
+
 // Register handles user registration with phone and password
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req auth.RegisterRequest
@@ -74,11 +90,18 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
+	req.ClientIP = c.ClientIP()
+	if !middleware.CheckRateLimit(c, middleware.LoginRateLimiter, req.ClientIP+"|"+req.Phone) {
+		return
+	}
+
 	tokenResponse, err := h.authService.Login(c.Request.Context(), req)
 	if err != nil {
 		switch {
 		case errors.Is(err, auth.ErrInvalidCredentials):
 			response.Unauthorized(c, response.ErrInvalidCredentials, "Invalid phone or password", "")
+		case errors.Is(err, auth.ErrAccountLocked):
+			response.TooManyRequests(c, response.ErrAccountLocked, "Too many failed attempts. Account is temporarily locked", "")
 		case errors.Is(err, auth.ErrUserInactive):
 			response.Forbidden(c, response.ErrUserInactive, "User account is inactive", "")
 		default:
@@ -89,3 +112,101 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 	response.Success(c, tokenResponse)
 }
+
+// ForgotPassword sends a password reset code to the account's phone
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req auth.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, response.ErrInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		response.BadRequest(c, response.ErrValidationFailed, "Validation failed", err.Error())
+		return
+	}
+
+	if err := h.otpService.Request(c.Request.Context(), otp.Request{
+		Phone:   req.Phone,
+		Purpose: otp.PurposePasswordReset,
+	}); err != nil {
+		otpError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "If the number is registered, a reset code has been sent"})
+}
+
+// ResetPassword sets a new password using a reset code and signs out every session
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req auth.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, response.ErrInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		response.BadRequest(c, response.ErrValidationFailed, "Validation failed", err.Error())
+		return
+	}
+
+	if err := h.otpService.Verify(c.Request.Context(), otp.Verify{
+		Phone:   req.Phone,
+		Code:    req.Code,
+		Purpose: otp.PurposePasswordReset,
+	}); err != nil {
+		otpError(c, err)
+		return
+	}
+
+	if err := h.authService.ResetPassword(c.Request.Context(), req.Phone, req.NewPassword); err != nil {
+		internalError(c, response.ErrUpdateFailed, "Failed to reset password", err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Password reset successfully"})
+}
+
+// ChangePassword changes the password of the authenticated user and signs out other sessions
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req auth.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, response.ErrInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		response.BadRequest(c, response.ErrValidationFailed, "Validation failed", err.Error())
+		return
+	}
+
+	if err := h.authService.ChangePassword(c.Request.Context(), userID, getTokenID(c), req); err != nil {
+		if errors.Is(err, auth.ErrInvalidCredentials) {
+			response.BadRequest(c, response.ErrInvalidCredentials, "Current password is incorrect", "")
+			return
+		}
+		internalError(c, response.ErrUpdateFailed, "Failed to change password", err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Password changed successfully"})
+}
+
+// JWKS returns the public keys other services use to verify our tokens
+func (h *AuthHandler) JWKS(c *gin.Context) {
+	keys, err := h.authService.GetJWKS(c.Request.Context())
+	if err != nil {
+		internalError(c, response.ErrGetFailed, "Failed to get signing keys", err)
+		return
+	}
+
+	// Served as a bare key set rather than the API envelope so standard JWT
+	// libraries can consume it.
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, keys)
+}
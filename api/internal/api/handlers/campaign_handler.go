@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"callflow/internal/api/response"
+	"callflow/internal/domain/campaign"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// CampaignHandler handles HTTP requests related to broadcast campaigns
+type CampaignHandler struct {
+	campaignService campaign.Service
+	validate        *validator.Validate
+}
+
+// NewCampaignHandler creates a new campaign handler instance
+func NewCampaignHandler(campaignService campaign.Service) *CampaignHandler {
+	return &CampaignHandler{
+		campaignService: campaignService,
+		validate:        validator.New(),
+	}
+}
+
+// RegisterRoutes registers the campaign routes
+func (h *CampaignHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	campaigns := rg.Group("/campaigns")
+	{
+		campaigns.GET("", h.Get)
+		campaigns.POST("", h.Create)
+		campaigns.GET("/:id", h.GetByID)
+		campaigns.GET("/:id/recipients", h.GetRecipients)
+		campaigns.POST("/:id/pause", h.Pause)
+		campaigns.POST("/:id/resume", h.Resume)
+		campaigns.POST("/:id/cancel", h.Cancel)
+	}
+}
+
+// Get returns the campaigns for the authenticated user
+func (h *CampaignHandler) Get(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	campaigns, err := h.campaignService.Get(c.Request.Context(), userID)
+	if err != nil {
+		internalError(c, response.ErrGetFailed, "Failed to get campaigns", err)
+		return
+	}
+
+	response.Success(c, campaigns)
+}
+
+// GetByID returns a campaign with its recipient statistics
+func (h *CampaignHandler) GetByID(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	id, ok := parseCampaignID(c)
+	if !ok {
+		return
+	}
+
+	cp, err := h.campaignService.GetByID(c.Request.Context(), id, userID)
+	if err != nil {
+		if errors.Is(err, campaign.ErrCampaignNotFound) {
+			response.NotFound(c, response.ErrCampaignNotFound, "Campaign not found", "")
+			return
+		}
+		internalError(c, response.ErrGetFailed, "Failed to get campaign", err)
+		return
+	}
+
+	response.Success(c, cp)
+}
+
+// Create schedules a new campaign for the authenticated user
+func (h *CampaignHandler) Create(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req campaign.CampaignCreate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, response.ErrInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		response.BadRequest(c, response.ErrValidationFailed, "Validation failed", err.Error())
+		return
+	}
+
+	cp, err := h.campaignService.Create(c.Request.Context(), userID, req)
+	if err != nil {
+		if errors.Is(err, campaign.ErrInvalidTemplate) {
+			response.BadRequest(c, response.ErrValidationFailed, err.Error(), "")
+			return
+		}
+		internalError(c, response.ErrCreateFailed, "Failed to create campaign", err)
+		return
+	}
+
+	response.Created(c, cp)
+}
+
+// GetRecipients returns the recipients of a campaign and their delivery status
+func (h *CampaignHandler) GetRecipients(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	id, ok := parseCampaignID(c)
+	if !ok {
+		return
+	}
+
+	recipients, err := h.campaignService.GetRecipients(c.Request.Context(), id, userID)
+	if err != nil {
+		if errors.Is(err, campaign.ErrCampaignNotFound) {
+			response.NotFound(c, response.ErrCampaignNotFound, "Campaign not found", "")
+			return
+		}
+		internalError(c, response.ErrListFailed, "Failed to get recipients", err)
+		return
+	}
+
+	response.Success(c, recipients)
+}
+
+// Pause stops handing out messages for a scheduled or running campaign
+func (h *CampaignHandler) Pause(c *gin.Context) {
+	h.transition(c, h.campaignService.Pause)
+}
+
+// Resume continues a paused campaign
+func (h *CampaignHandler) Resume(c *gin.Context) {
+	h.transition(c, h.campaignService.Resume)
+}
+
+// Cancel stops a campaign for good and skips its unsent recipients
+func (h *CampaignHandler) Cancel(c *gin.Context) {
+	h.transition(c, h.campaignService.Cancel)
+}
+
+func (h *CampaignHandler) transition(c *gin.Context, change func(ctx context.Context, id int64, userID int64) (*campaign.Campaign, error)) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	id, ok := parseCampaignID(c)
+	if !ok {
+		return
+	}
+
+	cp, err := change(c.Request.Context(), id, userID)
+	if err != nil {
+		if errors.Is(err, campaign.ErrCampaignNotFound) {
+			response.NotFound(c, response.ErrCampaignNotFound, "Campaign not found", "")
+			return
+		}
+		if errors.Is(err, campaign.ErrInvalidTransition) {
+			response.Conflict(c, response.ErrCampaignTransition, err.Error(), "")
+			return
+		}
+		internalError(c, response.ErrUpdateFailed, "Failed to update campaign", err)
+		return
+	}
+
+	response.Success(c, cp)
+}
+
+func parseCampaignID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, response.ErrInvalidID, "Invalid campaign ID", err.Error())
+		return 0, false
+	}
+	return id, true
+}
@@ -1,8 +1,12 @@
 package handler
 
 import (
+	"errors"
+	"strconv"
+
 	"callflow/internal/api/response"
 	"callflow/internal/domain/contact"
+	"callflow/internal/domain/sequence"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -10,15 +14,17 @@ import (
 
 // ContactHandler handles HTTP requests related to contacts
 type ContactHandler struct {
-	contactService contact.Service
-	validate       *validator.Validate
+	contactService  contact.Service
+	sequenceService sequence.Service
+	validate        *validator.Validate
 }
 
 // NewContactHandler creates a new contact handler instance
-func NewContactHandler(contactService contact.Service) *ContactHandler {
+func NewContactHandler(contactService contact.Service, sequenceService sequence.Service) *ContactHandler {
 	return &ContactHandler{
-		contactService: contactService,
-		validate:       validator.New(),
+		contactService:  contactService,
+		sequenceService: sequenceService,
+		validate:        validator.New(),
 	}
 }
 
@@ -28,6 +34,9 @@ func (h *ContactHandler) RegisterRoutes(rg *gin.RouterGroup) {
 	{
 		contacts.GET("", h.Get)
 		contacts.POST("/batch", h.BatchUpsert)
+		contacts.POST("/calls", h.RecordCall)
+		contacts.POST("/opt-out", h.OptOut)
+		contacts.PUT("/:id/tags", h.SetTags)
 	}
 }
 
@@ -75,3 +84,108 @@ func (h *ContactHandler) BatchUpsert(c *gin.Context) {
 		"count":   len(req.Contacts),
 	})
 }
+
+// RecordCall stores a call reported by the device and starts matching follow-up sequences
+func (h *ContactHandler) RecordCall(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req contact.CallEvent
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, response.ErrInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		response.BadRequest(c, response.ErrValidationFailed, "Validation failed", err.Error())
+		return
+	}
+
+	ct, err := h.contactService.RecordCall(c.Request.Context(), userID, req)
+	if err != nil {
+		internalError(c, response.ErrCreateFailed, "Failed to record call", err)
+		return
+	}
+
+	enrollments, err := h.sequenceService.HandleCall(c.Request.Context(), userID, ct, req.Direction)
+	if err != nil {
+		internalError(c, response.ErrCreateFailed, "Failed to start follow-up sequences", err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"contact":     ct,
+		"enrollments": enrollments,
+	})
+}
+
+// OptOut marks a phone number as opted out and stops its follow-up sequences
+func (h *ContactHandler) OptOut(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req contact.OptOutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, response.ErrInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		response.BadRequest(c, response.ErrValidationFailed, "Validation failed", err.Error())
+		return
+	}
+
+	ct, err := h.contactService.OptOut(c.Request.Context(), userID, req.Phone)
+	if err != nil {
+		internalError(c, response.ErrUpdateFailed, "Failed to opt out contact", err)
+		return
+	}
+
+	if err := h.sequenceService.HandleOptOut(c.Request.Context(), userID, req.Phone); err != nil {
+		internalError(c, response.ErrUpdateFailed, "Failed to stop follow-up sequences", err)
+		return
+	}
+
+	response.Success(c, ct)
+}
+
+// SetTags replaces the tags of a contact, used to build campaign segments
+func (h *ContactHandler) SetTags(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, response.ErrInvalidID, "Invalid contact ID", err.Error())
+		return
+	}
+
+	var req contact.TagsUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, response.ErrInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		response.BadRequest(c, response.ErrValidationFailed, "Validation failed", err.Error())
+		return
+	}
+
+	ct, err := h.contactService.SetTags(c.Request.Context(), id, userID, req.Tags)
+	if err != nil {
+		if errors.Is(err, contact.ErrContactNotFound) {
+			response.NotFound(c, response.ErrContactNotFound, "Contact not found", "")
+			return
+		}
+		internalError(c, response.ErrUpdateFailed, "Failed to update contact tags", err)
+		return
+	}
+
+	response.Success(c, ct)
+}
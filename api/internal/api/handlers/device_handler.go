@@ -0,0 +1,301 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"callflow/internal/api/middleware"
+	"callflow/internal/api/response"
+	"callflow/internal/domain/device"
+	"callflow/internal/domain/organization"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// DeviceHandler handles HTTP requests made by the user's phone
+type DeviceHandler struct {
+	deviceService device.Service
+	validate      *validator.Validate
+}
+
+// NewDeviceHandler creates a new device handler instance
+func NewDeviceHandler(deviceService device.Service) *DeviceHandler {
+	return &DeviceHandler{
+		deviceService: deviceService,
+		validate:      validator.New(),
+	}
+}
+
+// RegisterRoutes registers the device routes.
+// /devices manages the account's devices; /device is used by the calling device itself.
+func (h *DeviceHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	devices := rg.Group("/devices")
+	{
+		devices.GET("", h.Get)
+		devices.PUT("/:id/sms-line", middleware.RequireRole(organization.RoleOwner), h.SetSMSLine)
+		devices.DELETE("/:id", middleware.RequireRole(organization.RoleOwner), h.Revoke)
+	}
+
+	d := rg.Group("/device")
+	{
+		d.POST("/heartbeat", h.Heartbeat)
+		d.GET("/jobs", h.GetJobs)
+		d.POST("/jobs", h.Enqueue)
+		d.POST("/jobs/lease", h.Lease)
+		d.POST("/jobs/ack", h.Ack)
+		d.POST("/jobs/fail", h.Fail)
+	}
+}
+
+// Get returns the devices registered to the authenticated user
+func (h *DeviceHandler) Get(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	devices, err := h.deviceService.Get(c.Request.Context(), userID)
+	if err != nil {
+		internalError(c, response.ErrGetFailed, "Failed to get devices", err)
+		return
+	}
+
+	response.Success(c, devices)
+}
+
+// SetSMSLine designates a device as the only SMS sender for a line
+func (h *DeviceHandler) SetSMSLine(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, response.ErrInvalidID, "Invalid device ID", err.Error())
+		return
+	}
+
+	var req device.SMSLineUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, response.ErrInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		response.BadRequest(c, response.ErrValidationFailed, "Validation failed", err.Error())
+		return
+	}
+
+	d, err := h.deviceService.SetSMSLine(c.Request.Context(), id, userID, req.Line)
+	if err != nil {
+		switch {
+		case errors.Is(err, device.ErrDeviceNotFound):
+			response.NotFound(c, response.ErrDeviceNotFound, "Device not found", "")
+		case errors.Is(err, device.ErrLineAssigned):
+			response.Conflict(c, response.ErrConflict, err.Error(), "")
+		default:
+			internalError(c, response.ErrUpdateFailed, "Failed to assign SMS line", err)
+		}
+		return
+	}
+
+	response.Success(c, d)
+}
+
+// Revoke signs a device out and removes its SMS line assignment
+func (h *DeviceHandler) Revoke(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, response.ErrInvalidID, "Invalid device ID", err.Error())
+		return
+	}
+
+	if err := h.deviceService.Revoke(c.Request.Context(), id, userID); err != nil {
+		if errors.Is(err, device.ErrDeviceNotFound) {
+			response.NotFound(c, response.ErrDeviceNotFound, "Device not found", "")
+			return
+		}
+		internalError(c, response.ErrDeleteFailed, "Failed to revoke device", err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Device revoked successfully"})
+}
+
+// Heartbeat records the status report of the calling device
+func (h *DeviceHandler) Heartbeat(c *gin.Context) {
+	if _, ok := getUserID(c); !ok {
+		return
+	}
+
+	var req device.Heartbeat
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, response.ErrInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		response.BadRequest(c, response.ErrValidationFailed, "Validation failed", err.Error())
+		return
+	}
+
+	d, err := h.deviceService.Heartbeat(c.Request.Context(), getActorID(c), getDeviceID(c), req)
+	if err != nil {
+		if errors.Is(err, device.ErrDeviceNotFound) {
+			response.NotFound(c, response.ErrDeviceNotFound, "Device not registered; log in again with device details", "")
+			return
+		}
+		internalError(c, response.ErrUpdateFailed, "Failed to record heartbeat", err)
+		return
+	}
+
+	response.Success(c, d)
+}
+
+// GetJobs returns the most recent jobs of the authenticated user
+func (h *DeviceHandler) GetJobs(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	jobs, err := h.deviceService.GetJobs(c.Request.Context(), userID)
+	if err != nil {
+		internalError(c, response.ErrGetFailed, "Failed to get jobs", err)
+		return
+	}
+
+	response.Success(c, jobs)
+}
+
+// Enqueue adds a job for the user's phone
+func (h *DeviceHandler) Enqueue(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req device.JobCreate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, response.ErrInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		response.BadRequest(c, response.ErrValidationFailed, "Validation failed", err.Error())
+		return
+	}
+
+	job, err := h.deviceService.Enqueue(c.Request.Context(), userID, req)
+	if err != nil {
+		if errors.Is(err, device.ErrInvalidPayload) || errors.Is(err, device.ErrInvalidTemplate) {
+			response.BadRequest(c, response.ErrValidationFailed, err.Error(), "")
+			return
+		}
+		internalError(c, response.ErrCreateFailed, "Failed to enqueue job", err)
+		return
+	}
+
+	response.Created(c, job)
+}
+
+// Lease hands the calling device a batch of ready jobs. The device is the one
+// the token was issued to, never one named by the client.
+func (h *DeviceHandler) Lease(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req device.LeaseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, response.ErrInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		response.BadRequest(c, response.ErrValidationFailed, "Validation failed", err.Error())
+		return
+	}
+
+	lease, err := h.deviceService.Lease(c.Request.Context(), userID, getActorID(c), getDeviceID(c), req)
+	if err != nil {
+		if errors.Is(err, device.ErrDeviceNotFound) {
+			response.NotFound(c, response.ErrDeviceNotFound, "Device not registered; log in again with device details", "")
+			return
+		}
+		internalError(c, response.ErrGetFailed, "Failed to lease jobs", err)
+		return
+	}
+
+	response.Success(c, lease)
+}
+
+// Ack marks a leased job as done
+func (h *DeviceHandler) Ack(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req device.AckRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, response.ErrInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		response.BadRequest(c, response.ErrValidationFailed, "Validation failed", err.Error())
+		return
+	}
+
+	job, err := h.deviceService.Ack(c.Request.Context(), userID, req)
+	if err != nil {
+		if errors.Is(err, device.ErrJobNotFound) {
+			response.Conflict(c, response.ErrJobLeaseLost, err.Error(), "")
+			return
+		}
+		internalError(c, response.ErrUpdateFailed, "Failed to acknowledge job", err)
+		return
+	}
+
+	response.Success(c, job)
+}
+
+// Fail reports that a leased job could not be completed
+func (h *DeviceHandler) Fail(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req device.FailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, response.ErrInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		response.BadRequest(c, response.ErrValidationFailed, "Validation failed", err.Error())
+		return
+	}
+
+	job, err := h.deviceService.Fail(c.Request.Context(), userID, req)
+	if err != nil {
+		if errors.Is(err, device.ErrJobNotFound) {
+			response.Conflict(c, response.ErrJobLeaseLost, err.Error(), "")
+			return
+		}
+		internalError(c, response.ErrUpdateFailed, "Failed to record job failure", err)
+		return
+	}
+
+	response.Success(c, job)
+}
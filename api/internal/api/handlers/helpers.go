@@ -30,3 +30,29 @@ func internalError(c *gin.Context, code, message string, err error) {
 	log.Printf("Internal error [%s]: %v", code, err)
 	response.InternalServerError(c, code, message, "")
 }
+
+// getTokenID returns the jti of the request's token, or "" for legacy tokens.
+func getTokenID(c *gin.Context) string {
+	return c.GetString("tokenID")
+}
+
+// getActorID returns the user the request's credentials belong to. Under
+// X-Organization-ID, userID is the organization owner while devices stay
+// registered to the member who signed in on them.
+func getActorID(c *gin.Context) int64 {
+	if id := c.GetInt64("actorID"); id != 0 {
+		return id
+	}
+	return c.GetInt64("userID")
+}
+
+// getDeviceID returns the ID of the device the request's token was issued to,
+// or 0 when the token is not bound to a device.
+func getDeviceID(c *gin.Context) int64 {
+	val, exists := c.Get("deviceID")
+	if !exists {
+		return 0
+	}
+	id, _ := val.(int64)
+	return id
+}
@@ -6,18 +6,25 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
+	"callflow/internal/api/middleware"
 	"callflow/internal/api/response"
 	"callflow/internal/domain/landing"
+	"callflow/internal/domain/lead"
+	"callflow/internal/domain/media"
 	"callflow/internal/domain/user"
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
 )
 
 // LandingHandler handles HTTP requests related to landing pages.
 type LandingHandler struct {
 	landingService landing.Service
 	userService    user.Service
+	leadService    lead.Service
+	validate       *validator.Validate
 }
 
 const maxLandingImageBytes = 5 * 1024 * 1024
@@ -29,10 +36,12 @@ var allowedLandingImageContentTypes = map[string]struct{}{
 }
 
 // NewLandingHandler creates a new landing handler instance.
-func NewLandingHandler(landingService landing.Service, userService user.Service) *LandingHandler {
+func NewLandingHandler(landingService landing.Service, userService user.Service, leadService lead.Service) *LandingHandler {
 	return &LandingHandler{
 		landingService: landingService,
 		userService:    userService,
+		leadService:    leadService,
+		validate:       validator.New(),
 	}
 }
 
@@ -42,7 +51,19 @@ func (h *LandingHandler) RegisterRoutes(rg *gin.RouterGroup) {
 	{
 		landingGroup.GET("", h.Get)
 		landingGroup.PUT("", h.Upsert)
-		landingGroup.POST("/upload-image", h.UploadImage)
+		landingGroup.GET("/draft", h.GetDraft)
+		landingGroup.PUT("/draft", h.SaveDraft)
+		landingGroup.DELETE("/draft", h.DiscardDraft)
+		landingGroup.POST("/draft/preview-token", h.RotatePreviewToken)
+		landingGroup.POST("/publish", h.Publish)
+		landingGroup.GET("/revisions", h.GetRevisions)
+		landingGroup.GET("/revisions/:id", h.GetRevision)
+		landingGroup.POST("/revisions/:id/restore", h.RestoreRevision)
+		landingGroup.GET("/slug", h.GetSlug)
+		landingGroup.PUT("/slug", h.UpdateSlug)
+		landingGroup.GET("/analytics", h.GetAnalytics)
+		landingGroup.POST("/links", h.CreateLink)
+		landingGroup.POST("/upload-image", middleware.RateLimitUploads(), h.UploadImage)
 	}
 }
 
@@ -50,7 +71,10 @@ func (h *LandingHandler) RegisterRoutes(rg *gin.RouterGroup) {
 func (h *LandingHandler) RegisterPublicRoutes(rg *gin.RouterGroup) {
 	public := rg.Group("/public")
 	{
-		public.GET("/landing/:id", h.GetPublic)
+		public.GET("/landing/:slug", middleware.RateLimitPublic(), h.GetPublic)
+		public.GET("/landing/:slug/preview/:token", middleware.RateLimitPublic(), h.GetPreview)
+		public.POST("/landing/:slug/events", middleware.RateLimitPublic(), h.TrackEvent)
+		public.POST("/landing/:slug/enquiry", middleware.RateLimitEnquiry(), h.Enquiry)
 	}
 }
 
@@ -77,9 +101,17 @@ func (h *LandingHandler) Get(c *gin.Context) {
 		}
 	}
 
+	slug, err := h.landingService.GetSlug(c.Request.Context(), userID)
+	if err != nil {
+		internalError(c, response.ErrGetFailed, "Failed to get landing slug", err)
+		return
+	}
+
 	response.Success(c, gin.H{
 		"landing":      l,
 		"location_url": u.LocationURL,
+		"slug":         slug.Slug,
+		"landing_url":  h.landingService.URL(slug.Slug),
 	})
 }
 
@@ -88,7 +120,9 @@ type landingUpsertRequest struct {
 	LocationURL *string `json:"location_url,omitempty"`
 }
 
-// Upsert creates or updates the authenticated user's landing page.
+// Upsert saves the landing page fields into the draft, like SaveDraft, and
+// updates the user's location URL. Customers see the changes once the draft
+// is published.
 func (h *LandingHandler) Upsert(c *gin.Context) {
 	userID, ok := getUserID(c)
 	if !ok {
@@ -101,13 +135,14 @@ func (h *LandingHandler) Upsert(c *gin.Context) {
 		return
 	}
 
-	l, err := h.landingService.UpsertByUserID(c.Request.Context(), userID, req.LandingUpsert)
+	if err := h.validate.Struct(req.LandingUpsert); err != nil {
+		response.BadRequest(c, response.ErrValidationFailed, "Validation failed", err.Error())
+		return
+	}
+
+	d, err := h.landingService.SaveDraft(c.Request.Context(), userID, req.LandingUpsert)
 	if err != nil {
-		if errors.Is(err, landing.ErrInvalidImageURL) || errors.Is(err, landing.ErrMissingImageKey) {
-			response.BadRequest(c, response.ErrValidationFailed, "Invalid image data", err.Error())
-			return
-		}
-		internalError(c, response.ErrUpdateFailed, "Failed to update landing page", err)
+		landingError(c, err, response.ErrUpdateFailed, "Failed to save landing draft")
 		return
 	}
 
@@ -130,11 +165,161 @@ func (h *LandingHandler) Upsert(c *gin.Context) {
 	}
 
 	response.Success(c, gin.H{
-		"landing":      l,
+		"landing":      d,
 		"location_url": loc,
 	})
 }
 
+// GetDraft returns the authenticated user's unpublished landing page
+func (h *LandingHandler) GetDraft(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	d, err := h.landingService.GetDraft(c.Request.Context(), userID)
+	if err != nil {
+		landingError(c, err, response.ErrGetFailed, "Failed to get landing draft")
+		return
+	}
+
+	response.Success(c, d)
+}
+
+// SaveDraft updates the draft, starting it from the live page when there is
+// none. The live page is unchanged until the draft is published.
+func (h *LandingHandler) SaveDraft(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req landing.LandingUpsert
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, response.ErrInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		response.BadRequest(c, response.ErrValidationFailed, "Validation failed", err.Error())
+		return
+	}
+
+	d, err := h.landingService.SaveDraft(c.Request.Context(), userID, req)
+	if err != nil {
+		landingError(c, err, response.ErrUpdateFailed, "Failed to save landing draft")
+		return
+	}
+
+	response.Success(c, d)
+}
+
+// DiscardDraft deletes the draft, leaving the live page as it is
+func (h *LandingHandler) DiscardDraft(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.landingService.DiscardDraft(c.Request.Context(), userID); err != nil {
+		landingError(c, err, response.ErrDeleteFailed, "Failed to discard landing draft")
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Landing draft discarded successfully"})
+}
+
+// RotatePreviewToken replaces the draft's preview URL; the old one stops working
+func (h *LandingHandler) RotatePreviewToken(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	d, err := h.landingService.RotatePreviewToken(c.Request.Context(), userID)
+	if err != nil {
+		landingError(c, err, response.ErrUpdateFailed, "Failed to rotate preview link")
+		return
+	}
+
+	response.Success(c, d)
+}
+
+// Publish makes the draft the live landing page and records a revision
+func (h *LandingHandler) Publish(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	l, err := h.landingService.Publish(c.Request.Context(), userID)
+	if err != nil {
+		landingError(c, err, response.ErrUpdateFailed, "Failed to publish landing page")
+		return
+	}
+
+	response.Success(c, gin.H{"landing": l})
+}
+
+// GetRevisions lists the published versions of the landing page, newest first
+func (h *LandingHandler) GetRevisions(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	revisions, err := h.landingService.GetRevisions(c.Request.Context(), userID)
+	if err != nil {
+		internalError(c, response.ErrListFailed, "Failed to list landing revisions", err)
+		return
+	}
+
+	response.Success(c, revisions)
+}
+
+// GetRevision returns a published version of the landing page
+func (h *LandingHandler) GetRevision(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	id, ok := parseRevisionID(c)
+	if !ok {
+		return
+	}
+
+	rev, err := h.landingService.GetRevision(c.Request.Context(), id, userID)
+	if err != nil {
+		landingError(c, err, response.ErrGetFailed, "Failed to get landing revision")
+		return
+	}
+
+	response.Success(c, rev)
+}
+
+// RestoreRevision copies a revision into the draft so it can be previewed and
+// published
+func (h *LandingHandler) RestoreRevision(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	id, ok := parseRevisionID(c)
+	if !ok {
+		return
+	}
+
+	d, err := h.landingService.RestoreRevision(c.Request.Context(), id, userID)
+	if err != nil {
+		landingError(c, err, response.ErrUpdateFailed, "Failed to restore landing revision")
+		return
+	}
+
+	response.Success(c, d)
+}
+
 // UploadImage uploads a landing image and returns a public URL and storage key.
 func (h *LandingHandler) UploadImage(c *gin.Context) {
 	userID, ok := getUserID(c)
@@ -187,6 +372,10 @@ func (h *LandingHandler) UploadImage(c *gin.Context) {
 		content,
 	)
 	if err != nil {
+		if errors.Is(err, media.ErrInvalidImage) || errors.Is(err, media.ErrImageTooLarge) {
+			response.BadRequest(c, response.ErrValidationFailed, err.Error(), "")
+			return
+		}
 		if errors.Is(err, landing.ErrUploadDisabled) {
 			internalError(c, response.ErrCreateFailed, "Image upload is not configured", err)
 			return
@@ -198,11 +387,69 @@ func (h *LandingHandler) UploadImage(c *gin.Context) {
 	response.Success(c, uploaded)
 }
 
-// GetPublic returns the public landing page for a user.
+// GetSlug returns the authenticated user's landing slug and public URL.
+func (h *LandingHandler) GetSlug(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	slug, err := h.landingService.GetSlug(c.Request.Context(), userID)
+	if err != nil {
+		internalError(c, response.ErrGetFailed, "Failed to get landing slug", err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"slug":        slug.Slug,
+		"landing_url": h.landingService.URL(slug.Slug),
+	})
+}
+
+// UpdateSlug changes the authenticated user's landing slug. The previous slug
+// keeps redirecting to the new one.
+func (h *LandingHandler) UpdateSlug(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req landing.SlugUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, response.ErrInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	slug, err := h.landingService.UpdateSlug(c.Request.Context(), userID, req.Slug)
+	if err != nil {
+		switch {
+		case errors.Is(err, landing.ErrInvalidSlug) || errors.Is(err, landing.ErrSlugReserved):
+			response.BadRequest(c, response.ErrValidationFailed, err.Error(), "")
+		case errors.Is(err, landing.ErrSlugTaken):
+			response.Conflict(c, response.ErrSlugTaken, "Slug is already taken", "")
+		default:
+			internalError(c, response.ErrUpdateFailed, "Failed to update landing slug", err)
+		}
+		return
+	}
+
+	response.Success(c, gin.H{
+		"slug":        slug.Slug,
+		"landing_url": h.landingService.URL(slug.Slug),
+	})
+}
+
+// GetPublic returns the public landing page for a slug. Former slugs and
+// legacy numeric user IDs redirect to the current slug.
 func (h *LandingHandler) GetPublic(c *gin.Context) {
-	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	ref := c.Param("slug")
+	id, current, err := h.landingService.ResolveSlug(c.Request.Context(), ref)
 	if err != nil {
-		response.BadRequest(c, response.ErrInvalidID, "Invalid user ID", err.Error())
+		if errors.Is(err, landing.ErrSlugNotFound) {
+			response.NotFound(c, response.ErrNotFound, "Landing page not found", "")
+			return
+		}
+		internalError(c, response.ErrGetFailed, "Failed to get landing page", err)
 		return
 	}
 
@@ -212,11 +459,18 @@ func (h *LandingHandler) GetPublic(c *gin.Context) {
 		return
 	}
 
+	// Pages that are not live are not redirected either, so the current slug
+	// of an inactive account is not revealed.
 	if u.Status != user.StatusActive || u.Plan != user.PlanSMS {
 		response.NotFound(c, response.ErrNotFound, "Landing page not found", "")
 		return
 	}
 
+	if current != ref {
+		c.Redirect(http.StatusMovedPermanently, strings.TrimSuffix(c.Request.URL.Path, ref)+current)
+		return
+	}
+
 	l, err := h.landingService.GetByUserID(c.Request.Context(), id)
 	if err != nil {
 		if errors.Is(err, landing.ErrLandingNotFound) {
@@ -227,9 +481,50 @@ func (h *LandingHandler) GetPublic(c *gin.Context) {
 		}
 	}
 
-	response.Success(c, gin.H{
+	response.Success(c, publicLanding(current, u, l))
+}
+
+// GetPreview returns the draft of a landing page to holders of its preview
+// token, in the same shape as the public page.
+func (h *LandingHandler) GetPreview(c *gin.Context) {
+	id, current, err := h.landingService.ResolveSlug(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		if errors.Is(err, landing.ErrSlugNotFound) {
+			response.NotFound(c, response.ErrNotFound, "Landing page not found", "")
+			return
+		}
+		internalError(c, response.ErrGetFailed, "Failed to get landing preview", err)
+		return
+	}
+
+	u, err := h.userService.GetUser(c.Request.Context(), id)
+	if err != nil || u.Status != user.StatusActive || u.Plan != user.PlanSMS {
+		response.NotFound(c, response.ErrNotFound, "Landing page not found", "")
+		return
+	}
+
+	l, err := h.landingService.GetPreview(c.Request.Context(), id, c.Param("token"))
+	if err != nil {
+		// A wrong token looks the same as a missing draft.
+		if errors.Is(err, landing.ErrDraftNotFound) {
+			response.NotFound(c, response.ErrNotFound, "Landing page not found", "")
+			return
+		}
+		internalError(c, response.ErrGetFailed, "Failed to get landing preview", err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("X-Robots-Tag", "noindex")
+	response.Success(c, publicLanding(current, u, l))
+}
+
+// publicLanding is the public view of a landing page. Row and user IDs stay
+// private; the page is addressed by its slug.
+func publicLanding(slug string, u *user.User, l *landing.Landing) gin.H {
+	return gin.H{
+		"slug": slug,
 		"user": gin.H{
-			"id":            u.ID,
 			"name":          u.Name,
 			"business_name": u.BusinessName,
 			"phone":         u.Phone,
@@ -237,8 +532,205 @@ func (h *LandingHandler) GetPublic(c *gin.Context) {
 			"city":          u.City,
 			"location_url":  u.LocationURL,
 		},
-		"landing": l,
-	})
+		"landing": gin.H{
+			"headline":      l.Headline,
+			"description":   l.Description,
+			"image_url":     l.ImageURL,
+			"whatsapp_url":  l.WhatsappURL,
+			"facebook_url":  l.FacebookURL,
+			"instagram_url": l.InstagramURL,
+			"youtube_url":   l.YoutubeURL,
+			"email":         l.Email,
+			"website_url":   l.WebsiteURL,
+		},
+		"sections": publicLandingSections(l.Sections, time.Now()),
+	}
+}
+
+// publicLandingSections leaves out hidden sections, expired offers and
+// galleries whose images are all gone.
+func publicLandingSections(sections []*landing.Section, now time.Time) []*landing.Section {
+	visible := make([]*landing.Section, 0, len(sections))
+	for _, sec := range sections {
+		switch {
+		case sec.Hidden:
+		case sec.Type == landing.SectionOffer && sec.Offer.ExpiresAt != nil && !sec.Offer.ExpiresAt.After(now):
+		case sec.Type == landing.SectionGallery && len(sec.Images) == 0:
+		default:
+			visible = append(visible, sec)
+		}
+	}
+	return visible
+}
+
+// TrackEvent records a view or button click on a public landing page.
+func (h *LandingHandler) TrackEvent(c *gin.Context) {
+	var req landing.EventCreate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, response.ErrInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		response.BadRequest(c, response.ErrValidationFailed, "Validation failed", err.Error())
+		return
+	}
+
+	if err := h.landingService.TrackEvent(c.Request.Context(), c.Param("slug"), req); err != nil {
+		switch {
+		case errors.Is(err, landing.ErrSlugNotFound):
+			response.NotFound(c, response.ErrNotFound, "Landing page not found", "")
+		case errors.Is(err, landing.ErrInvalidEvent):
+			response.BadRequest(c, response.ErrValidationFailed, err.Error(), "")
+		default:
+			internalError(c, response.ErrCreateFailed, "Failed to record event", err)
+		}
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Event recorded"})
+}
+
+// Enquiry stores the contact form of a public landing page as a lead of its
+// owner. Submissions that fill the honeypot get the same reply but are dropped.
+func (h *LandingHandler) Enquiry(c *gin.Context) {
+	var req lead.Enquiry
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, response.ErrInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		response.BadRequest(c, response.ErrValidationFailed, "Validation failed", err.Error())
+		return
+	}
+
+	if req.Website != "" {
+		response.Created(c, gin.H{"message": "Enquiry received"})
+		return
+	}
+
+	id, _, err := h.landingService.ResolveSlug(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		if errors.Is(err, landing.ErrSlugNotFound) {
+			response.NotFound(c, response.ErrNotFound, "Landing page not found", "")
+			return
+		}
+		internalError(c, response.ErrCreateFailed, "Failed to send enquiry", err)
+		return
+	}
+
+	u, err := h.userService.GetUser(c.Request.Context(), id)
+	if err != nil || u.Status != user.StatusActive || u.Plan != user.PlanSMS {
+		response.NotFound(c, response.ErrNotFound, "Landing page not found", "")
+		return
+	}
+
+	if _, err := h.leadService.ReceiveEnquiry(c.Request.Context(), id, req); err != nil {
+		if errors.Is(err, lead.ErrInvalidPhone) {
+			response.BadRequest(c, response.ErrValidationFailed, err.Error(), "")
+			return
+		}
+		internalError(c, response.ErrCreateFailed, "Failed to send enquiry", err)
+		return
+	}
+
+	// The lead stays private; the visitor only learns it was received.
+	response.Created(c, gin.H{"message": "Enquiry received"})
+}
+
+// CreateLink issues a landing URL with an attribution token for a message the
+// device sends on its own, such as a missed-call reply.
+func (h *LandingHandler) CreateLink(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req landing.LinkCreate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, response.ErrInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		response.BadRequest(c, response.ErrValidationFailed, "Validation failed", err.Error())
+		return
+	}
+
+	link, err := h.landingService.CreateLink(c.Request.Context(), userID, req)
+	if err != nil {
+		internalError(c, response.ErrCreateFailed, "Failed to create landing link", err)
+		return
+	}
+
+	response.Created(c, link)
+}
+
+// GetAnalytics returns the landing page's daily views and clicks between the
+// from and to dates (YYYY-MM-DD, UTC). It defaults to the last 30 days.
+func (h *LandingHandler) GetAnalytics(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	to := time.Now().UTC()
+	if v := c.Query("to"); v != "" {
+		t, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			response.BadRequest(c, response.ErrValidationFailed, landing.ErrInvalidRange.Error(), "")
+			return
+		}
+		to = t
+	}
+	from := to.AddDate(0, 0, 1-landing.DefaultAnalyticsDays)
+	if v := c.Query("from"); v != "" {
+		t, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			response.BadRequest(c, response.ErrValidationFailed, landing.ErrInvalidRange.Error(), "")
+			return
+		}
+		from = t
+	}
+
+	analytics, err := h.landingService.GetAnalytics(c.Request.Context(), userID, from, to)
+	if err != nil {
+		if errors.Is(err, landing.ErrInvalidRange) {
+			response.BadRequest(c, response.ErrValidationFailed, err.Error(), "")
+			return
+		}
+		internalError(c, response.ErrGetFailed, "Failed to get landing analytics", err)
+		return
+	}
+
+	response.Success(c, analytics)
+}
+
+func parseRevisionID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, response.ErrInvalidID, "Invalid revision ID", err.Error())
+		return 0, false
+	}
+	return id, true
+}
+
+func landingError(c *gin.Context, err error, code, message string) {
+	switch {
+	case errors.Is(err, landing.ErrInvalidImageURL) || errors.Is(err, landing.ErrMissingImageKey):
+		response.BadRequest(c, response.ErrValidationFailed, "Invalid image data", err.Error())
+	case errors.Is(err, media.ErrMediaNotFound):
+		response.NotFound(c, response.ErrMediaNotFound, "Media not found", "")
+	case errors.Is(err, landing.ErrInvalidSection):
+		response.BadRequest(c, response.ErrValidationFailed, err.Error(), "")
+	case errors.Is(err, landing.ErrDraftNotFound):
+		response.NotFound(c, response.ErrDraftNotFound, "Landing draft not found", "")
+	case errors.Is(err, landing.ErrRevisionNotFound):
+		response.NotFound(c, response.ErrRevisionNotFound, "Landing revision not found", "")
+	default:
+		internalError(c, code, message, err)
+	}
 }
 
 func detectLandingImageContentType(headerValue string, file []byte) string {
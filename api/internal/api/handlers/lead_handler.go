@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"callflow/internal/api/middleware"
+	"callflow/internal/api/response"
+	"callflow/internal/domain/lead"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// LeadHandler handles HTTP requests related to inbound leads
+type LeadHandler struct {
+	leadService lead.Service
+	validate    *validator.Validate
+}
+
+// NewLeadHandler creates a new lead handler instance
+func NewLeadHandler(leadService lead.Service) *LeadHandler {
+	return &LeadHandler{
+		leadService: leadService,
+		validate:    validator.New(),
+	}
+}
+
+// RegisterRoutes registers the lead routes
+func (h *LeadHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	leads := rg.Group("/leads")
+	{
+		leads.GET("", h.Get)
+		leads.GET("/endpoint", h.GetEndpoint)
+		leads.POST("/endpoint", h.RotateEndpoint)
+		leads.DELETE("/endpoint", h.DeleteEndpoint)
+	}
+}
+
+// RegisterPublicRoutes registers the signed inbound lead endpoint
+func (h *LeadHandler) RegisterPublicRoutes(rg *gin.RouterGroup) {
+	public := rg.Group("/public")
+	{
+		public.POST("/leads/:token", middleware.RateLimitPublic(), h.Receive)
+	}
+}
+
+// Get returns the most recent leads of the authenticated user
+func (h *LeadHandler) Get(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	leads, err := h.leadService.Get(c.Request.Context(), userID)
+	if err != nil {
+		internalError(c, response.ErrListFailed, "Failed to list leads", err)
+		return
+	}
+
+	response.Success(c, leads)
+}
+
+// GetEndpoint returns the authenticated user's inbound lead endpoint
+func (h *LeadHandler) GetEndpoint(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	e, err := h.leadService.GetEndpoint(c.Request.Context(), userID)
+	if err != nil {
+		leadError(c, err, response.ErrGetFailed, "Failed to get lead endpoint")
+		return
+	}
+
+	response.Success(c, e)
+}
+
+// RotateEndpoint creates the inbound lead endpoint, or replaces its token and
+// secret. The secret is only returned here.
+func (h *LeadHandler) RotateEndpoint(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	e, err := h.leadService.RotateEndpoint(c.Request.Context(), userID)
+	if err != nil {
+		internalError(c, response.ErrCreateFailed, "Failed to create lead endpoint", err)
+		return
+	}
+
+	response.Created(c, e)
+}
+
+// DeleteEndpoint disables the inbound lead endpoint
+func (h *LeadHandler) DeleteEndpoint(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.leadService.DeleteEndpoint(c.Request.Context(), userID); err != nil {
+		leadError(c, err, response.ErrDeleteFailed, "Failed to delete lead endpoint")
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Lead endpoint deleted successfully"})
+}
+
+// Receive accepts a lead signed with the endpoint's secret
+func (h *LeadHandler) Receive(c *gin.Context) {
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, lead.MaxBodyBytes))
+	if err != nil {
+		response.BadRequest(c, response.ErrInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	userID, err := h.leadService.Authenticate(c.Request.Context(), c.Param("token"), c.GetHeader(lead.SignatureHeader), body)
+	if err != nil {
+		leadError(c, err, response.ErrCreateFailed, "Failed to receive lead")
+		return
+	}
+
+	var req lead.LeadCreate
+	if err := json.Unmarshal(body, &req); err != nil {
+		response.BadRequest(c, response.ErrInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		response.BadRequest(c, response.ErrValidationFailed, "Validation failed", err.Error())
+		return
+	}
+
+	l, err := h.leadService.Receive(c.Request.Context(), userID, req)
+	if err != nil {
+		internalError(c, response.ErrCreateFailed, "Failed to receive lead", err)
+		return
+	}
+
+	response.Created(c, l)
+}
+
+func leadError(c *gin.Context, err error, code, message string) {
+	switch {
+	case errors.Is(err, lead.ErrEndpointNotFound):
+		response.NotFound(c, response.ErrLeadEndpointNotFound, "Lead endpoint not found", "")
+	case errors.Is(err, lead.ErrInvalidSignature):
+		response.Unauthorized(c, response.ErrInvalidSignature, "Invalid or expired signature", "")
+	default:
+		internalError(c, code, message, err)
+	}
+}
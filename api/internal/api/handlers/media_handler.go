@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"callflow/internal/api/response"
+	"callflow/internal/domain/media"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MediaHandler handles HTTP requests related to the media library
+type MediaHandler struct {
+	mediaService media.Service
+}
+
+// NewMediaHandler creates a new media handler instance
+func NewMediaHandler(mediaService media.Service) *MediaHandler {
+	return &MediaHandler{
+		mediaService: mediaService,
+	}
+}
+
+// RegisterRoutes registers the media routes
+func (h *MediaHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	mediaGroup := rg.Group("/media")
+	{
+		mediaGroup.GET("", h.List)
+		mediaGroup.GET("/:id", h.Get)
+		mediaGroup.DELETE("/:id", h.Delete)
+	}
+}
+
+// List returns the images in the authenticated user's media library
+func (h *MediaHandler) List(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	items, err := h.mediaService.List(c.Request.Context(), userID)
+	if err != nil {
+		internalError(c, response.ErrListFailed, "Failed to list media", err)
+		return
+	}
+
+	response.Success(c, items)
+}
+
+// Get returns a single image of the media library
+func (h *MediaHandler) Get(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, response.ErrInvalidID, "Invalid media ID", err.Error())
+		return
+	}
+
+	item, err := h.mediaService.Get(c.Request.Context(), id, userID)
+	if err != nil {
+		mediaError(c, err, response.ErrGetFailed, "Failed to get media")
+		return
+	}
+
+	response.Success(c, item)
+}
+
+// Delete removes an image from the media library unless it is still in use
+func (h *MediaHandler) Delete(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, response.ErrInvalidID, "Invalid media ID", err.Error())
+		return
+	}
+
+	if err := h.mediaService.Delete(c.Request.Context(), id, userID); err != nil {
+		mediaError(c, err, response.ErrDeleteFailed, "Failed to delete media")
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Media deleted successfully"})
+}
+
+func mediaError(c *gin.Context, err error, code, message string) {
+	switch {
+	case errors.Is(err, media.ErrMediaNotFound):
+		response.NotFound(c, response.ErrMediaNotFound, "Media not found", "")
+	case errors.Is(err, media.ErrMediaInUse):
+		response.Conflict(c, response.ErrMediaInUse, "Media is used by a template or landing page", "")
+	default:
+		internalError(c, code, message, err)
+	}
+}
@@ -0,0 +1,339 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"callflow/internal/api/response"
+	"callflow/internal/domain/organization"
+	"callflow/internal/domain/user"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// OrganizationHandler handles HTTP requests related to organizations and their members
+type OrganizationHandler struct {
+	organizationService organization.Service
+	validate            *validator.Validate
+}
+
+// NewOrganizationHandler creates a new organization handler instance
+func NewOrganizationHandler(organizationService organization.Service) *OrganizationHandler {
+	return &OrganizationHandler{
+		organizationService: organizationService,
+		validate:            validator.New(),
+	}
+}
+
+// RegisterRoutes registers the organization routes. "own" routes manage the
+// organization owned by the authenticated user.
+func (h *OrganizationHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	orgs := rg.Group("/organizations")
+	{
+		orgs.GET("", h.GetMemberships)
+		orgs.GET("/own", h.GetOwn)
+		orgs.PUT("/own", h.UpdateOwn)
+		orgs.GET("/own/members", h.GetMembers)
+		orgs.PUT("/own/members/:user_id", h.UpdateMemberRole)
+		orgs.DELETE("/own/members/:user_id", h.RemoveMember)
+		orgs.GET("/own/invitations", h.GetInvitations)
+		orgs.POST("/own/invitations", h.Invite)
+		orgs.DELETE("/own/invitations/:id", h.RevokeInvitation)
+		orgs.GET("/invitations", h.GetReceivedInvitations)
+		orgs.POST("/invitations/:id/accept", h.AcceptInvitation)
+		orgs.POST("/invitations/:id/decline", h.DeclineInvitation)
+		orgs.DELETE("/:id/membership", h.Leave)
+	}
+}
+
+// GetMemberships returns the organizations the authenticated user belongs to
+func (h *OrganizationHandler) GetMemberships(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	memberships, err := h.organizationService.GetMemberships(c.Request.Context(), userID)
+	if err != nil {
+		internalError(c, response.ErrListFailed, "Failed to list organizations", err)
+		return
+	}
+
+	response.Success(c, memberships)
+}
+
+// GetOwn returns the organization owned by the authenticated user
+func (h *OrganizationHandler) GetOwn(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	org, err := h.organizationService.GetOwn(c.Request.Context(), userID)
+	if err != nil {
+		internalError(c, response.ErrGetFailed, "Failed to get organization", err)
+		return
+	}
+
+	response.Success(c, org)
+}
+
+// UpdateOwn renames the organization owned by the authenticated user
+func (h *OrganizationHandler) UpdateOwn(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req organization.OrganizationUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, response.ErrInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		response.BadRequest(c, response.ErrValidationFailed, "Validation failed", err.Error())
+		return
+	}
+
+	org, err := h.organizationService.UpdateOwn(c.Request.Context(), userID, req)
+	if err != nil {
+		internalError(c, response.ErrUpdateFailed, "Failed to update organization", err)
+		return
+	}
+
+	response.Success(c, org)
+}
+
+// GetMembers returns the members of the authenticated user's organization
+func (h *OrganizationHandler) GetMembers(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	members, err := h.organizationService.GetMembers(c.Request.Context(), userID)
+	if err != nil {
+		internalError(c, response.ErrListFailed, "Failed to list members", err)
+		return
+	}
+
+	response.Success(c, members)
+}
+
+// UpdateMemberRole changes the role of a member of the authenticated user's organization
+func (h *OrganizationHandler) UpdateMemberRole(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	memberID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, response.ErrInvalidID, "Invalid user ID", err.Error())
+		return
+	}
+
+	var req organization.RoleUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, response.ErrInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		response.BadRequest(c, response.ErrValidationFailed, "Validation failed", err.Error())
+		return
+	}
+
+	if err := h.organizationService.UpdateMemberRole(c.Request.Context(), userID, memberID, req); err != nil {
+		organizationError(c, err, response.ErrUpdateFailed, "Failed to update member")
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Member role updated successfully"})
+}
+
+// RemoveMember removes a member from the authenticated user's organization
+func (h *OrganizationHandler) RemoveMember(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	memberID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, response.ErrInvalidID, "Invalid user ID", err.Error())
+		return
+	}
+
+	if err := h.organizationService.RemoveMember(c.Request.Context(), userID, memberID); err != nil {
+		organizationError(c, err, response.ErrDeleteFailed, "Failed to remove member")
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Member removed successfully"})
+}
+
+// GetInvitations returns the pending invitations of the authenticated user's organization
+func (h *OrganizationHandler) GetInvitations(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	invitations, err := h.organizationService.GetInvitations(c.Request.Context(), userID)
+	if err != nil {
+		internalError(c, response.ErrListFailed, "Failed to list invitations", err)
+		return
+	}
+
+	response.Success(c, invitations)
+}
+
+// Invite invites a phone number to the authenticated user's organization
+func (h *OrganizationHandler) Invite(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req organization.InvitationCreate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, response.ErrInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		response.BadRequest(c, response.ErrValidationFailed, "Validation failed", err.Error())
+		return
+	}
+
+	inv, err := h.organizationService.Invite(c.Request.Context(), userID, req)
+	if err != nil {
+		organizationError(c, err, response.ErrCreateFailed, "Failed to create invitation")
+		return
+	}
+
+	response.Created(c, inv)
+}
+
+// RevokeInvitation revokes a pending invitation of the authenticated user's organization
+func (h *OrganizationHandler) RevokeInvitation(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, response.ErrInvalidID, "Invalid invitation ID", err.Error())
+		return
+	}
+
+	if err := h.organizationService.RevokeInvitation(c.Request.Context(), userID, id); err != nil {
+		organizationError(c, err, response.ErrDeleteFailed, "Failed to revoke invitation")
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Invitation revoked successfully"})
+}
+
+// GetReceivedInvitations returns the pending invitations addressed to the authenticated user's phone
+func (h *OrganizationHandler) GetReceivedInvitations(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	invitations, err := h.organizationService.GetReceivedInvitations(c.Request.Context(), userID)
+	if err != nil {
+		internalError(c, response.ErrListFailed, "Failed to list invitations", err)
+		return
+	}
+
+	response.Success(c, invitations)
+}
+
+// AcceptInvitation joins the organization of an invitation addressed to the authenticated user
+func (h *OrganizationHandler) AcceptInvitation(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, response.ErrInvalidID, "Invalid invitation ID", err.Error())
+		return
+	}
+
+	membership, err := h.organizationService.AcceptInvitation(c.Request.Context(), userID, id)
+	if err != nil {
+		organizationError(c, err, response.ErrUpdateFailed, "Failed to accept invitation")
+		return
+	}
+
+	response.Success(c, membership)
+}
+
+// DeclineInvitation declines an invitation addressed to the authenticated user
+func (h *OrganizationHandler) DeclineInvitation(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, response.ErrInvalidID, "Invalid invitation ID", err.Error())
+		return
+	}
+
+	if err := h.organizationService.DeclineInvitation(c.Request.Context(), userID, id); err != nil {
+		organizationError(c, err, response.ErrUpdateFailed, "Failed to decline invitation")
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Invitation declined"})
+}
+
+// Leave removes the authenticated user from an organization
+func (h *OrganizationHandler) Leave(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, response.ErrInvalidID, "Invalid organization ID", err.Error())
+		return
+	}
+
+	if err := h.organizationService.Leave(c.Request.Context(), userID, id); err != nil {
+		organizationError(c, err, response.ErrDeleteFailed, "Failed to leave organization")
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Left organization successfully"})
+}
+
+func organizationError(c *gin.Context, err error, code, message string) {
+	switch {
+	case errors.Is(err, organization.ErrOrganizationNotFound):
+		response.NotFound(c, response.ErrOrganizationNotFound, "Organization not found", "")
+	case errors.Is(err, organization.ErrMemberNotFound):
+		response.NotFound(c, response.ErrMemberNotFound, "Member not found", "")
+	case errors.Is(err, organization.ErrInvitationNotFound):
+		response.NotFound(c, response.ErrInvitationNotFound, "Invitation not found", "")
+	case errors.Is(err, organization.ErrInvitationPending), errors.Is(err, organization.ErrAlreadyMember):
+		response.Conflict(c, response.ErrConflict, err.Error(), "")
+	case errors.Is(err, organization.ErrCannotInviteSelf), errors.Is(err, organization.ErrOwnerCannotLeave):
+		response.BadRequest(c, response.ErrValidationFailed, err.Error(), "")
+	case errors.Is(err, user.ErrPhoneNotVerified):
+		response.Forbidden(c, response.ErrPhoneNotVerified, "Verify your phone number to accept invitations", "")
+	default:
+		internalError(c, code, message, err)
+	}
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"errors"
+
+	"callflow/internal/api/middleware"
+	"callflow/internal/api/response"
+	"callflow/internal/domain/otp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// OTPHandler handles HTTP requests related to one-time codes
+type OTPHandler struct {
+	otpService otp.Service
+	validate   *validator.Validate
+}
+
+// NewOTPHandler creates a new one-time code handler instance
+func NewOTPHandler(otpService otp.Service) *OTPHandler {
+	return &OTPHandler{
+		otpService: otpService,
+		validate:   validator.New(),
+	}
+}
+
+// RegisterRoutes registers the public one-time code routes
+func (h *OTPHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	otpGroup := rg.Group("/auth/otp")
+	{
+		otpGroup.POST("/request", middleware.RateLimitAuth(), h.Request)
+		otpGroup.POST("/verify", middleware.RateLimitAuth(), h.Verify)
+	}
+}
+
+// Request sends a one-time code to a phone
+func (h *OTPHandler) Request(c *gin.Context) {
+	var req otp.Request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, response.ErrInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		response.BadRequest(c, response.ErrValidationFailed, "Validation failed", err.Error())
+		return
+	}
+
+	if err := h.otpService.Request(c.Request.Context(), req); err != nil {
+		otpError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "If the number is registered, a code has been sent"})
+}
+
+// Verify checks a one-time code and marks the phone verified
+func (h *OTPHandler) Verify(c *gin.Context) {
+	var req otp.Verify
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, response.ErrInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		response.BadRequest(c, response.ErrValidationFailed, "Validation failed", err.Error())
+		return
+	}
+
+	if err := h.otpService.Verify(c.Request.Context(), req); err != nil {
+		otpError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"verified": true})
+}
+
+// otpError maps one-time code errors to responses
+func otpError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, otp.ErrInvalidCode):
+		response.BadRequest(c, response.ErrInvalidOTP, "Invalid code", "")
+	case errors.Is(err, otp.ErrCodeExpired):
+		response.BadRequest(c, response.ErrOTPExpired, "Code has expired", "")
+	case errors.Is(err, otp.ErrTooManyAttempts):
+		response.TooManyRequests(c, response.ErrTooManyAttempts, err.Error(), "")
+	case errors.Is(err, otp.ErrTooManyRequests):
+		response.TooManyRequests(c, response.ErrTooManyRequests, err.Error(), "")
+	default:
+		internalError(c, response.ErrAuthFailed, "Failed to process code", err)
+	}
+}
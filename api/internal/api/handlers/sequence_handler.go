@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"callflow/internal/api/response"
+	"callflow/internal/domain/sequence"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// SequenceHandler handles HTTP requests related to follow-up sequences
+type SequenceHandler struct {
+	sequenceService sequence.Service
+	validate        *validator.Validate
+}
+
+// NewSequenceHandler creates a new sequence handler instance
+func NewSequenceHandler(sequenceService sequence.Service) *SequenceHandler {
+	return &SequenceHandler{
+		sequenceService: sequenceService,
+		validate:        validator.New(),
+	}
+}
+
+// RegisterRoutes registers the sequence routes
+func (h *SequenceHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	sequences := rg.Group("/sequences")
+	{
+		sequences.GET("", h.Get)
+		sequences.POST("", h.Create)
+		sequences.PUT("/:id", h.Update)
+		sequences.DELETE("/:id", h.Delete)
+		sequences.GET("/:id/enrollments", h.GetEnrollments)
+	}
+}
+
+// Get returns the sequences for the authenticated user
+func (h *SequenceHandler) Get(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	sequences, err := h.sequenceService.Get(c.Request.Context(), userID)
+	if err != nil {
+		internalError(c, response.ErrGetFailed, "Failed to get sequences", err)
+		return
+	}
+
+	response.Success(c, sequences)
+}
+
+// Create creates a new sequence for the authenticated user
+func (h *SequenceHandler) Create(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req sequence.SequenceCreate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, response.ErrInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		response.BadRequest(c, response.ErrValidationFailed, "Validation failed", err.Error())
+		return
+	}
+
+	s, err := h.sequenceService.Create(c.Request.Context(), userID, req)
+	if err != nil {
+		if errors.Is(err, sequence.ErrStepsOutOfOrder) || errors.Is(err, sequence.ErrInvalidTemplate) {
+			response.BadRequest(c, response.ErrValidationFailed, err.Error(), "")
+			return
+		}
+		internalError(c, response.ErrCreateFailed, "Failed to create sequence", err)
+		return
+	}
+
+	response.Created(c, s)
+}
+
+// Update updates an existing sequence for the authenticated user
+func (h *SequenceHandler) Update(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, response.ErrInvalidID, "Invalid sequence ID", err.Error())
+		return
+	}
+
+	var req sequence.SequenceUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, response.ErrInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		response.BadRequest(c, response.ErrValidationFailed, "Validation failed", err.Error())
+		return
+	}
+
+	s, err := h.sequenceService.Update(c.Request.Context(), id, userID, req)
+	if err != nil {
+		if errors.Is(err, sequence.ErrSequenceNotFound) {
+			response.NotFound(c, response.ErrSequenceNotFound, "Sequence not found", "")
+			return
+		}
+		if errors.Is(err, sequence.ErrStepsOutOfOrder) || errors.Is(err, sequence.ErrInvalidTemplate) {
+			response.BadRequest(c, response.ErrValidationFailed, err.Error(), "")
+			return
+		}
+		internalError(c, response.ErrUpdateFailed, "Failed to update sequence", err)
+		return
+	}
+
+	response.Success(c, s)
+}
+
+// Delete deletes a sequence and its enrollments
+func (h *SequenceHandler) Delete(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, response.ErrInvalidID, "Invalid sequence ID", err.Error())
+		return
+	}
+
+	if err := h.sequenceService.Delete(c.Request.Context(), id, userID); err != nil {
+		internalError(c, response.ErrDeleteFailed, "Failed to delete sequence", err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Sequence deleted successfully"})
+}
+
+// GetEnrollments returns the most recent enrollments of a sequence
+func (h *SequenceHandler) GetEnrollments(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, response.ErrInvalidID, "Invalid sequence ID", err.Error())
+		return
+	}
+
+	enrollments, err := h.sequenceService.GetEnrollments(c.Request.Context(), id, userID)
+	if err != nil {
+		if errors.Is(err, sequence.ErrSequenceNotFound) {
+			response.NotFound(c, response.ErrSequenceNotFound, "Sequence not found", "")
+			return
+		}
+		internalError(c, response.ErrListFailed, "Failed to get enrollments", err)
+		return
+	}
+
+	response.Success(c, enrollments)
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"callflow/internal/api/middleware"
+	"callflow/internal/api/response"
+	"callflow/internal/domain/shortlink"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// ShortLinkHandler handles HTTP requests related to short links
+type ShortLinkHandler struct {
+	shortLinkService shortlink.Service
+	validate         *validator.Validate
+}
+
+// NewShortLinkHandler creates a new short link handler instance
+func NewShortLinkHandler(shortLinkService shortlink.Service) *ShortLinkHandler {
+	return &ShortLinkHandler{
+		shortLinkService: shortLinkService,
+		validate:         validator.New(),
+	}
+}
+
+// RegisterRoutes registers the short link management routes
+func (h *ShortLinkHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	links := rg.Group("/links")
+	{
+		links.GET("", h.List)
+		links.POST("", h.Create)
+		links.GET("/:id", h.Get)
+	}
+}
+
+// RegisterRedirectRoutes registers the public /s/:code redirect. It lives at
+// the root, outside /api/v1, to keep short URLs short.
+func (h *ShortLinkHandler) RegisterRedirectRoutes(rg *gin.RouterGroup) {
+	rg.GET("/s/:code", middleware.RateLimitPublic(), h.Follow)
+}
+
+// List returns the authenticated user's most recent short links
+func (h *ShortLinkHandler) List(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	links, err := h.shortLinkService.Get(c.Request.Context(), userID)
+	if err != nil {
+		internalError(c, response.ErrListFailed, "Failed to list short links", err)
+		return
+	}
+
+	response.Success(c, links)
+}
+
+// Create shortens a URL for the authenticated user
+func (h *ShortLinkHandler) Create(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req shortlink.ShortLinkCreate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, response.ErrInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		response.BadRequest(c, response.ErrValidationFailed, "Validation failed", err.Error())
+		return
+	}
+
+	link, err := h.shortLinkService.Create(c.Request.Context(), userID, req)
+	if err != nil {
+		internalError(c, response.ErrCreateFailed, "Failed to create short link", err)
+		return
+	}
+
+	response.Created(c, link)
+}
+
+// Get returns a short link with its most recent clicks
+func (h *ShortLinkHandler) Get(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, response.ErrInvalidID, "Invalid short link ID", err.Error())
+		return
+	}
+
+	link, err := h.shortLinkService.GetByID(c.Request.Context(), id, userID)
+	if err != nil {
+		if errors.Is(err, shortlink.ErrShortLinkNotFound) {
+			response.NotFound(c, response.ErrShortLinkNotFound, "Short link not found", "")
+			return
+		}
+		internalError(c, response.ErrGetFailed, "Failed to get short link", err)
+		return
+	}
+
+	response.Success(c, link)
+}
+
+// Follow logs a click and redirects to the link's URL. The redirect is not
+// permanent so browsers come back and every click is counted.
+func (h *ShortLinkHandler) Follow(c *gin.Context) {
+	url, err := h.shortLinkService.Follow(c.Request.Context(), c.Param("code"), c.Request.UserAgent())
+	if err != nil {
+		if errors.Is(err, shortlink.ErrShortLinkNotFound) {
+			response.NotFound(c, response.ErrShortLinkNotFound, "Short link not found", "")
+			return
+		}
+		internalError(c, response.ErrGetFailed, "Failed to follow short link", err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Redirect(http.StatusFound, url)
+}
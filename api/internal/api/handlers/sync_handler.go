@@ -1,7 +1,10 @@
 package handler
 
 import (
+	"callflow/internal/api/middleware"
 	"callflow/internal/api/response"
+	"callflow/internal/domain/device"
+	"callflow/internal/domain/landing"
 	"callflow/internal/domain/rule"
 	"callflow/internal/domain/template"
 	"callflow/internal/domain/user"
@@ -14,6 +17,8 @@ type SyncHandler struct {
 	userService     user.Service
 	templateService template.Service
 	ruleService     rule.Service
+	deviceService   device.Service
+	landingService  landing.Service
 }
 
 // NewSyncHandler creates a new sync handler instance
@@ -21,17 +26,21 @@ func NewSyncHandler(
 	userService user.Service,
 	templateService template.Service,
 	ruleService rule.Service,
+	deviceService device.Service,
+	landingService landing.Service,
 ) *SyncHandler {
 	return &SyncHandler{
 		userService:     userService,
 		templateService: templateService,
 		ruleService:     ruleService,
+		deviceService:   deviceService,
+		landingService:  landingService,
 	}
 }
 
 // RegisterRoutes registers the sync routes
 func (h *SyncHandler) RegisterRoutes(rg *gin.RouterGroup) {
-	sync := rg.Group("/sync")
+	sync := rg.Group("/sync", middleware.RateLimitSync())
 	{
 		sync.GET("/config", h.GetConfig)
 	}
@@ -65,17 +74,33 @@ func (h *SyncHandler) GetConfig(c *gin.Context) {
 		ruleConfig = nil
 	}
 
+	// Fetch SMS line assignment for the calling device
+	smsAssignment, err := h.deviceService.GetSMSAssignment(c.Request.Context(), userID, getActorID(c), getDeviceID(c))
+	if err != nil {
+		// Device might have been revoked meanwhile, that's ok
+		smsAssignment = nil
+	}
+
+	// Fetch the public landing URL the device appends to messages
+	landingURL := ""
+	if slug, err := h.landingService.GetSlug(c.Request.Context(), userID); err == nil {
+		landingURL = h.landingService.URL(slug.Slug)
+	}
+
 	response.Success(c, gin.H{
 		"user": gin.H{
 			"id":              u.ID,
 			"phone":           u.Phone,
+			"phone_verified":  u.PhoneVerified,
 			"business_name":   u.BusinessName,
 			"plan":            u.Plan,
 			"plan_started_at": u.PlanStartedAt,
 			"plan_expires_at": u.PlanExpiresAt,
 			"status":          u.Status,
 		},
-		"templates": templates,
-		"rules":     ruleConfig,
+		"templates":   templates,
+		"rules":       ruleConfig,
+		"device":      smsAssignment,
+		"landing_url": landingURL,
 	})
 }
@@ -7,7 +7,9 @@ import (
 	"strconv"
 	"strings"
 
+	"callflow/internal/api/middleware"
 	"callflow/internal/api/response"
+	"callflow/internal/domain/media"
 	"callflow/internal/domain/template"
 
 	"github.com/gin-gonic/gin"
@@ -41,9 +43,10 @@ func (h *TemplateHandler) RegisterRoutes(rg *gin.RouterGroup) {
 	tmpl := rg.Group("/template")
 	{
 		tmpl.GET("", h.Get)
-		tmpl.POST("/upload-image", h.UploadImage)
+		tmpl.POST("/upload-image", middleware.RateLimitUploads(), h.UploadImage)
 		tmpl.POST("", h.Create)
 		tmpl.PUT("/:id", h.Update)
+		tmpl.POST("/:id/render", h.Render)
 		tmpl.DELETE("/:id", h.Delete)
 	}
 }
@@ -96,6 +99,10 @@ func (h *TemplateHandler) Create(c *gin.Context) {
 			response.BadRequest(c, response.ErrValidationFailed, err.Error(), "")
 			return
 		}
+		if errors.Is(err, media.ErrMediaNotFound) {
+			response.NotFound(c, response.ErrMediaNotFound, "Media not found", "")
+			return
+		}
 		internalError(c, response.ErrCreateFailed, "Failed to create template", err)
 		return
 	}
@@ -141,6 +148,10 @@ func (h *TemplateHandler) Update(c *gin.Context) {
 			response.BadRequest(c, response.ErrValidationFailed, err.Error(), "")
 			return
 		}
+		if errors.Is(err, media.ErrMediaNotFound) {
+			response.NotFound(c, response.ErrMediaNotFound, "Media not found", "")
+			return
+		}
 		internalError(c, response.ErrUpdateFailed, "Failed to update template", err)
 		return
 	}
@@ -169,6 +180,43 @@ func (h *TemplateHandler) Delete(c *gin.Context) {
 	response.Success(c, gin.H{"message": "Template deleted successfully"})
 }
 
+// Render fills in a template for one recipient and shortens the URLs in it
+func (h *TemplateHandler) Render(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, response.ErrInvalidID, "Invalid template ID", err.Error())
+		return
+	}
+
+	var req template.RenderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, response.ErrInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		response.BadRequest(c, response.ErrValidationFailed, "Validation failed", err.Error())
+		return
+	}
+
+	rendered, err := h.templateService.Render(c.Request.Context(), id, userID, req)
+	if err != nil {
+		if errors.Is(err, template.ErrTemplateNotFound) {
+			response.NotFound(c, response.ErrTemplateNotFound, "Template not found", "")
+			return
+		}
+		internalError(c, response.ErrGetFailed, "Failed to render template", err)
+		return
+	}
+
+	response.Success(c, rendered)
+}
+
 // UploadImage uploads a template image and returns a public URL and storage key.
 func (h *TemplateHandler) UploadImage(c *gin.Context) {
 	userID, ok := getUserID(c)
@@ -221,6 +269,10 @@ func (h *TemplateHandler) UploadImage(c *gin.Context) {
 		content,
 	)
 	if err != nil {
+		if errors.Is(err, media.ErrInvalidImage) || errors.Is(err, media.ErrImageTooLarge) {
+			response.BadRequest(c, response.ErrValidationFailed, err.Error(), "")
+			return
+		}
 		if errors.Is(err, template.ErrUploadDisabled) {
 			internalError(c, response.ErrCreateFailed, "Image upload is not configured", err)
 			return
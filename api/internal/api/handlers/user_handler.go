@@ -1,7 +1,15 @@
 package handler
 
 import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
 	"callflow/internal/api/response"
+	"callflow/internal/domain/account"
 	"callflow/internal/domain/user"
 
 	"github.com/gin-gonic/gin"
@@ -10,15 +18,17 @@ import (
 
 // UserHandler handles HTTP requests related to users
 type UserHandler struct {
-	userService user.Service
-	validate    *validator.Validate
+	userService    user.Service
+	accountService account.Service
+	validate       *validator.Validate
 }
 
 // NewUserHandler creates a new user handler instance
-func NewUserHandler(userService user.Service) *UserHandler {
+func NewUserHandler(userService user.Service, accountService account.Service) *UserHandler {
 	return &UserHandler{
-		userService: userService,
-		validate:    validator.New(),
+		userService:    userService,
+		accountService: accountService,
+		validate:       validator.New(),
 	}
 }
 
@@ -28,6 +38,8 @@ func (h *UserHandler) RegisterRoutes(rg *gin.RouterGroup) {
 	{
 		users.GET("/profile", h.GetProfile)
 		users.PUT("/profile", h.UpdateProfile)
+		users.GET("/export", h.Export)
+		users.DELETE("", h.DeleteAccount)
 	}
 }
 
@@ -68,3 +80,116 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 
 	response.Success(c, u)
 }
+
+// Export downloads everything stored about the authenticated user as a JSON
+// file or, with ?format=zip, as a ZIP archive with one JSON file per section
+func (h *UserHandler) Export(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	format := c.DefaultQuery("format", account.FormatJSON)
+	if format != account.FormatJSON && format != account.FormatZIP {
+		response.BadRequest(c, response.ErrValidationFailed, "Format must be json or zip", "")
+		return
+	}
+
+	export, err := h.accountService.Export(c.Request.Context(), userID)
+	if err != nil {
+		internalError(c, response.ErrGetFailed, "Failed to export account data", err)
+		return
+	}
+
+	filename := fmt.Sprintf("callflow-export-%d-%s", userID, export.ExportedAt.Format("20060102"))
+	if format == account.FormatJSON {
+		data, err := json.MarshalIndent(export, "", "  ")
+		if err != nil {
+			internalError(c, response.ErrGetFailed, "Failed to export account data", err)
+			return
+		}
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename+".json"))
+		c.Data(http.StatusOK, "application/json", data)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename+".zip"))
+	c.Header("Content-Type", "application/zip")
+	c.Status(http.StatusOK)
+	if err := writeExportZip(c.Writer, export); err != nil {
+		// Headers are already sent, so the client only sees a truncated archive.
+		log.Printf("Failed to write export archive for user %d: %v", userID, err)
+	}
+}
+
+// DeleteAccount deletes the authenticated user's account after a grace period
+// and signs out every session
+func (h *UserHandler) DeleteAccount(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req account.DeleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, response.ErrInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		response.BadRequest(c, response.ErrValidationFailed, "Validation failed", err.Error())
+		return
+	}
+
+	if err := h.accountService.Delete(c.Request.Context(), userID, req); err != nil {
+		if errors.Is(err, account.ErrInvalidPassword) {
+			response.BadRequest(c, response.ErrInvalidCredentials, "Password is incorrect", "")
+			return
+		}
+		internalError(c, response.ErrDeleteFailed, "Failed to delete account", err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Account scheduled for deletion"})
+}
+
+// writeExportZip writes each section of the export as its own JSON file
+func writeExportZip(w http.ResponseWriter, export *account.Export) error {
+	zw := zip.NewWriter(w)
+	sections := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", export.Profile},
+		{"templates.json", export.Templates},
+		{"rule.json", export.Rule},
+		{"contacts.json", export.Contacts},
+		{"landing.json", export.Landing},
+		{"landing_draft.json", export.LandingDraft},
+		{"landing_revisions.json", export.LandingRevisions},
+		{"landing_slugs.json", export.LandingSlugs},
+		{"sequences.json", export.Sequences},
+		{"sequence_enrollments.json", export.SequenceEnrollments},
+		{"campaigns.json", export.Campaigns},
+		{"campaign_messages.json", export.CampaignMessages},
+		{"device_jobs.json", export.DeviceJobs},
+		{"leads.json", export.Leads},
+		{"media.json", export.Media},
+		{"webhooks.json", export.Webhooks},
+		{"api_keys.json", export.APIKeys},
+		{"short_links.json", export.ShortLinks},
+		{"short_link_clicks.json", export.ShortLinkClicks},
+	}
+	for _, section := range sections {
+		f, err := zw.Create(section.name)
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(section.data); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"callflow/internal/api/response"
+	"callflow/internal/domain/webhook"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// WebhookHandler handles HTTP requests related to webhooks
+type WebhookHandler struct {
+	webhookService webhook.Service
+	validate       *validator.Validate
+}
+
+// NewWebhookHandler creates a new webhook handler instance
+func NewWebhookHandler(webhookService webhook.Service) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+		validate:       validator.New(),
+	}
+}
+
+// RegisterRoutes registers the webhook routes
+func (h *WebhookHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	webhooks := rg.Group("/webhooks")
+	{
+		webhooks.GET("", h.Get)
+		webhooks.POST("", h.Create)
+		webhooks.PUT("/:id", h.Update)
+		webhooks.DELETE("/:id", h.Delete)
+		webhooks.GET("/:id/deliveries", h.GetDeliveries)
+		webhooks.POST("/:id/deliveries/:delivery_id/redeliver", h.Redeliver)
+	}
+}
+
+// Get returns the webhooks for the authenticated user
+func (h *WebhookHandler) Get(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	webhooks, err := h.webhookService.Get(c.Request.Context(), userID)
+	if err != nil {
+		internalError(c, response.ErrListFailed, "Failed to list webhooks", err)
+		return
+	}
+
+	response.Success(c, webhooks)
+}
+
+// Create subscribes a URL to events. The signing secret is only returned here.
+func (h *WebhookHandler) Create(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req webhook.WebhookCreate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, response.ErrInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		response.BadRequest(c, response.ErrValidationFailed, "Validation failed", err.Error())
+		return
+	}
+
+	w, err := h.webhookService.Create(c.Request.Context(), userID, req)
+	if err != nil {
+		webhookError(c, err, response.ErrCreateFailed, "Failed to create webhook")
+		return
+	}
+
+	response.Created(c, w)
+}
+
+// Update changes the URL, events or active flag of a webhook
+func (h *WebhookHandler) Update(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, response.ErrInvalidID, "Invalid webhook ID", err.Error())
+		return
+	}
+
+	var req webhook.WebhookUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, response.ErrInvalidRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		response.BadRequest(c, response.ErrValidationFailed, "Validation failed", err.Error())
+		return
+	}
+
+	w, err := h.webhookService.Update(c.Request.Context(), id, userID, req)
+	if err != nil {
+		webhookError(c, err, response.ErrUpdateFailed, "Failed to update webhook")
+		return
+	}
+
+	response.Success(c, w)
+}
+
+// Delete removes a webhook and its delivery log
+func (h *WebhookHandler) Delete(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, response.ErrInvalidID, "Invalid webhook ID", err.Error())
+		return
+	}
+
+	if err := h.webhookService.Delete(c.Request.Context(), id, userID); err != nil {
+		webhookError(c, err, response.ErrDeleteFailed, "Failed to delete webhook")
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Webhook deleted successfully"})
+}
+
+// GetDeliveries returns the most recent deliveries of a webhook
+func (h *WebhookHandler) GetDeliveries(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, response.ErrInvalidID, "Invalid webhook ID", err.Error())
+		return
+	}
+
+	deliveries, err := h.webhookService.GetDeliveries(c.Request.Context(), id, userID)
+	if err != nil {
+		webhookError(c, err, response.ErrListFailed, "Failed to list deliveries")
+		return
+	}
+
+	response.Success(c, deliveries)
+}
+
+// Redeliver queues a past delivery again with the same event
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, response.ErrInvalidID, "Invalid webhook ID", err.Error())
+		return
+	}
+
+	deliveryID, err := strconv.ParseInt(c.Param("delivery_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, response.ErrInvalidID, "Invalid delivery ID", err.Error())
+		return
+	}
+
+	delivery, err := h.webhookService.Redeliver(c.Request.Context(), id, deliveryID, userID)
+	if err != nil {
+		webhookError(c, err, response.ErrCreateFailed, "Failed to redeliver")
+		return
+	}
+
+	response.Created(c, delivery)
+}
+
+func webhookError(c *gin.Context, err error, code, message string) {
+	switch {
+	case errors.Is(err, webhook.ErrWebhookNotFound):
+		response.NotFound(c, response.ErrWebhookNotFound, "Webhook not found", "")
+	case errors.Is(err, webhook.ErrDeliveryNotFound):
+		response.NotFound(c, response.ErrDeliveryNotFound, "Delivery not found", "")
+	case errors.Is(err, webhook.ErrInvalidURL), errors.Is(err, webhook.ErrPrivateURL),
+		errors.Is(err, webhook.ErrInvalidEventType):
+		response.BadRequest(c, response.ErrValidationFailed, err.Error(), "")
+	default:
+		internalError(c, code, message, err)
+	}
+}
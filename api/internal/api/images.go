@@ -0,0 +1,36 @@
+package api
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"callflow/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// serveLocalImages serves uploaded images when IMAGE_STORE is "local". Keys are
+// unique per upload, so responses can be cached indefinitely.
+func serveLocalImages(router *gin.Engine) {
+	if os.Getenv("IMAGE_STORE") != service.ImageStoreLocal {
+		return
+	}
+
+	dir := service.LocalImageDir()
+	router.GET(service.LocalImageRoute+"/:key", func(c *gin.Context) {
+		key := c.Param("key")
+		if !service.IsLocalImageKey(key) {
+			c.Status(http.StatusNotFound)
+			return
+		}
+		filePath := filepath.Join(dir, key)
+		if stat, err := os.Stat(filePath); err != nil || stat.IsDir() {
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.Header("Cache-Control", "public, max-age=31536000, immutable")
+		c.Header("X-Content-Type-Options", "nosniff")
+		c.File(filePath)
+	})
+}
@@ -1,9 +1,12 @@
 package middleware
 
 import (
+	"errors"
+	"log"
 	"net/http"
 	"strings"
 
+	"callflow/internal/domain/apikey"
 	"callflow/internal/domain/auth"
 
 	"github.com/gin-gonic/gin"
@@ -11,13 +14,18 @@ import (
 
 // AuthMiddleware handles authentication for protected routes
 type AuthMiddleware struct {
-	authService auth.Service
+	authService   auth.Service
+	apiKeyService apikey.Service
 }
 
+// APIKeyHeader carries an API key as an alternative to the Authorization header
+const APIKeyHeader = "X-API-Key"
+
 // NewAuthMiddleware creates a new auth middleware instance
-func NewAuthMiddleware(authService auth.Service) *AuthMiddleware {
+func NewAuthMiddleware(authService auth.Service, apiKeyService apikey.Service) *AuthMiddleware {
 	return &AuthMiddleware{
-		authService: authService,
+		authService:   authService,
+		apiKeyService: apiKeyService,
 	}
 }
 
@@ -42,6 +50,13 @@ func (m *AuthMiddleware) authenticate(c *gin.Context) bool {
 		}
 		tokenString = headerParts[1]
 	}
+	if key := c.GetHeader(APIKeyHeader); key != "" && tokenString == "" {
+		tokenString = key
+	}
+
+	if strings.HasPrefix(tokenString, apikey.KeyPrefix) {
+		return m.authenticateAPIKey(c, tokenString)
+	}
 
 	if tokenString == "" {
 		c.JSON(http.StatusUnauthorized, gin.H{
@@ -61,6 +76,12 @@ func (m *AuthMiddleware) authenticate(c *gin.Context) bool {
 		message := "Invalid token"
 		if err == auth.ErrExpiredToken {
 			message = "Token has expired"
+		} else if err == auth.ErrRevokedToken {
+			message = "Token has been revoked"
+		} else if err == auth.ErrUserInactive {
+			message = "Account is inactive"
+		} else if err != auth.ErrInvalidToken {
+			log.Printf("Internal error [ERR_UNAUTHORIZED]: %v", err)
 		}
 
 		c.JSON(http.StatusUnauthorized, gin.H{
@@ -74,15 +95,92 @@ func (m *AuthMiddleware) authenticate(c *gin.Context) bool {
 		return false
 	}
 
-	// Set user context — simplified for CallFlow (no org context)
+	// Set user context. Under an organization, OrganizationMiddleware.Scope later
+	// replaces userID and plan with the owner's and keeps the caller as actorID.
 	c.Set("userID", claims.UserID)
 	c.Set("phone", claims.Phone)
 	c.Set("plan", claims.Plan)
+	if claims.ID != "" {
+		c.Set("tokenID", claims.ID)
+	}
+	if claims.DeviceID != 0 {
+		c.Set("deviceID", claims.DeviceID)
+	}
 
 	return true
 }
 
-// RequireAuth middleware ensures that requests have a valid JWT token
+// authenticateAPIKey sets user context for a request authenticated by an API key.
+// Keys act as their owner with the scopes they were granted; see RequireScope.
+func (m *AuthMiddleware) authenticateAPIKey(c *gin.Context, secret string) bool {
+	principal, err := m.apiKeyService.Authenticate(c.Request.Context(), secret)
+	if err != nil {
+		message := "Invalid API key"
+		switch {
+		case errors.Is(err, apikey.ErrExpiredKey):
+			message = "API key has expired"
+		case errors.Is(err, apikey.ErrRevokedKey):
+			message = "API key has been revoked"
+		case errors.Is(err, apikey.ErrUserInactive):
+			message = "Account is inactive"
+		case !errors.Is(err, apikey.ErrInvalidKey):
+			log.Printf("Internal error [ERR_UNAUTHORIZED]: %v", err)
+		}
+
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "ERR_UNAUTHORIZED",
+				"message": message,
+			},
+		})
+		c.Abort()
+		return false
+	}
+
+	c.Set("userID", principal.UserID)
+	c.Set("phone", principal.Phone)
+	c.Set("plan", principal.Plan)
+	c.Set("apiKeyID", principal.KeyID)
+	c.Set("apiKeyScopes", principal.Scopes)
+
+	return true
+}
+
+// RequireScope middleware limits API key requests to keys granted the resource:
+// reads need "<resource>:read" or "<resource>:write", anything else needs
+// "<resource>:write". Requests authenticated with a JWT pass through.
+func RequireScope(resource string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := c.Get("apiKeyID"); !ok {
+			c.Next()
+			return
+		}
+		access := apikey.AccessWrite
+		if isReadMethod(c.Request.Method) {
+			access = apikey.AccessRead
+		}
+		if !apikey.HasScope(c.GetStringSlice("apiKeyScopes"), resource, access) {
+			abortForbidden(c, "API key lacks the "+resource+":"+access+" scope")
+			return
+		}
+		c.Next()
+	}
+}
+
+// RejectAPIKeys middleware keeps account-level routes (profile, password,
+// sessions, organizations, API keys) available to signed-in users only.
+func RejectAPIKeys() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := c.Get("apiKeyID"); ok {
+			abortForbidden(c, "API keys cannot access this route")
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireAuth middleware ensures that requests have a valid JWT token or API key
 func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		if m.authenticate(c) {
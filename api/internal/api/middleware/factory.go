@@ -1,20 +1,24 @@
 package middleware
 
 import (
+	"callflow/internal/domain/apikey"
 	"callflow/internal/domain/auth"
+	"callflow/internal/domain/organization"
 
 	"github.com/gin-gonic/gin"
 )
 
 // MiddlewareFactory creates and organizes middleware with proper dependencies
 type MiddlewareFactory struct {
-	authMiddleware *AuthMiddleware
+	authMiddleware         *AuthMiddleware
+	organizationMiddleware *OrganizationMiddleware
 }
 
 // NewMiddlewareFactory creates a new middleware factory
-func NewMiddlewareFactory(authService auth.Service) *MiddlewareFactory {
+func NewMiddlewareFactory(authService auth.Service, apiKeyService apikey.Service, organizationService organization.Service) *MiddlewareFactory {
 	return &MiddlewareFactory{
-		authMiddleware: NewAuthMiddleware(authService),
+		authMiddleware:         NewAuthMiddleware(authService, apiKeyService),
+		organizationMiddleware: NewOrganizationMiddleware(organizationService),
 	}
 }
 
@@ -22,3 +26,8 @@ func NewMiddlewareFactory(authService auth.Service) *MiddlewareFactory {
 func (f *MiddlewareFactory) AuthChain() gin.HandlerFunc {
 	return f.authMiddleware.RequireAuth()
 }
+
+// OrganizationChain returns the middleware that scopes business routes to an organization
+func (f *MiddlewareFactory) OrganizationChain() gin.HandlerFunc {
+	return f.organizationMiddleware.Scope()
+}
@@ -0,0 +1,141 @@
+package middleware
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"callflow/internal/domain/ratelimit"
+)
+
+// Rate limit groups, each configured by RATE_LIMIT_<GROUP>
+const (
+	RateLimitGroupAuth    = "auth"
+	RateLimitGroupLogin   = "login"
+	RateLimitGroupUploads = "uploads"
+	RateLimitGroupSync    = "sync"
+	RateLimitGroupPublic  = "public"
+	RateLimitGroupEnquiry = "enquiry"
+)
+
+// Rate limiter backends selected by RATE_LIMIT_BACKEND
+const (
+	RateLimitBackendMemory   = "memory"
+	RateLimitBackendPostgres = "postgres"
+)
+
+var (
+	// AuthRateLimiter limits authentication endpoints per client IP
+	AuthRateLimiter RateLimiter = NewMemoryRateLimiter(DefaultAuthRateLimiterConfig())
+
+	// LoginRateLimiter limits login attempts per IP and phone combination
+	LoginRateLimiter RateLimiter = NewMemoryRateLimiter(DefaultLoginRateLimiterConfig())
+
+	// UploadRateLimiter limits image uploads per user
+	UploadRateLimiter RateLimiter = NewMemoryRateLimiter(DefaultUploadRateLimiterConfig())
+
+	// SyncRateLimiter limits app sync per user
+	SyncRateLimiter RateLimiter = NewMemoryRateLimiter(DefaultSyncRateLimiterConfig())
+
+	// PublicRateLimiter limits public landing pages per client IP
+	PublicRateLimiter RateLimiter = NewMemoryRateLimiter(DefaultPublicRateLimiterConfig())
+
+	// EnquiryRateLimiter limits landing page enquiries per client IP
+	EnquiryRateLimiter RateLimiter = NewMemoryRateLimiter(DefaultEnquiryRateLimiterConfig())
+
+	// bucketCleaner removes expired buckets when the Postgres backend is used
+	bucketCleaner *postgresBucketCleaner
+)
+
+// ConfigureRateLimiters replaces the default limiters using RATE_LIMIT_BACKEND
+// and the per-group RATE_LIMIT_<GROUP> settings, e.g. RATE_LIMIT_AUTH=100/1m.
+// It must run before the router is served.
+func ConfigureRateLimiters(repo ratelimit.Repository) error {
+	backend := os.Getenv("RATE_LIMIT_BACKEND")
+	if backend == "" {
+		backend = RateLimitBackendMemory
+	}
+	if backend != RateLimitBackendMemory && backend != RateLimitBackendPostgres {
+		return fmt.Errorf("unknown RATE_LIMIT_BACKEND %q", backend)
+	}
+
+	groups := []struct {
+		name    string
+		limiter *RateLimiter
+		config  RateLimiterConfig
+	}{
+		{RateLimitGroupAuth, &AuthRateLimiter, DefaultAuthRateLimiterConfig()},
+		{RateLimitGroupLogin, &LoginRateLimiter, DefaultLoginRateLimiterConfig()},
+		{RateLimitGroupUploads, &UploadRateLimiter, DefaultUploadRateLimiterConfig()},
+		{RateLimitGroupSync, &SyncRateLimiter, DefaultSyncRateLimiterConfig()},
+		{RateLimitGroupPublic, &PublicRateLimiter, DefaultPublicRateLimiterConfig()},
+		{RateLimitGroupEnquiry, &EnquiryRateLimiter, DefaultEnquiryRateLimiterConfig()},
+	}
+
+	var cleanupInterval time.Duration
+	for _, g := range groups {
+		config, err := rateLimiterConfigFromEnv(g.name, g.config)
+		if err != nil {
+			return err
+		}
+
+		(*g.limiter).Stop()
+		if backend == RateLimitBackendPostgres {
+			*g.limiter = NewPostgresRateLimiter(g.name, config, repo)
+		} else {
+			*g.limiter = NewMemoryRateLimiter(config)
+		}
+		if cleanupInterval == 0 || config.CleanupInterval < cleanupInterval {
+			cleanupInterval = config.CleanupInterval
+		}
+	}
+
+	if bucketCleaner != nil {
+		bucketCleaner.Stop()
+		bucketCleaner = nil
+	}
+	if backend == RateLimitBackendPostgres {
+		bucketCleaner = newPostgresBucketCleaner(repo, cleanupInterval)
+	}
+	return nil
+}
+
+// StopRateLimiters stops the cleanup goroutines of every limiter
+func StopRateLimiters() {
+	for _, rl := range []RateLimiter{AuthRateLimiter, LoginRateLimiter, UploadRateLimiter, SyncRateLimiter, PublicRateLimiter, EnquiryRateLimiter} {
+		rl.Stop()
+	}
+	if bucketCleaner != nil {
+		bucketCleaner.Stop()
+		bucketCleaner = nil
+	}
+}
+
+// rateLimiterConfigFromEnv overrides the default with a "<requests>/<window>"
+// value such as "100/1m" when RATE_LIMIT_<GROUP> is set.
+func rateLimiterConfigFromEnv(group string, def RateLimiterConfig) (RateLimiterConfig, error) {
+	envKey := "RATE_LIMIT_" + strings.ToUpper(group)
+	value := os.Getenv(envKey)
+	if value == "" {
+		return def, nil
+	}
+
+	count, window, ok := strings.Cut(value, "/")
+	if !ok {
+		return def, fmt.Errorf("invalid %s %q: expected <requests>/<window>", envKey, value)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(count))
+	if err != nil || n <= 0 {
+		return def, fmt.Errorf("invalid %s request count %q", envKey, count)
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(window))
+	if err != nil || d <= 0 {
+		return def, fmt.Errorf("invalid %s window %q", envKey, window)
+	}
+
+	def.MaxRequests = n
+	def.WindowDuration = d
+	return def, nil
+}
@@ -1,7 +1,10 @@
 package middleware
 
 import (
+	"context"
+	"log"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
@@ -24,8 +27,71 @@ func DefaultAuthRateLimiterConfig() RateLimiterConfig {
 	}
 }
 
-// RateLimiter provides IP-based rate limiting
-type RateLimiter struct {
+// DefaultLoginRateLimiterConfig returns default config for login attempts per IP and phone
+func DefaultLoginRateLimiterConfig() RateLimiterConfig {
+	return RateLimiterConfig{
+		MaxRequests:     10,
+		WindowDuration:  15 * time.Minute,
+		CleanupInterval: 5 * time.Minute,
+	}
+}
+
+// DefaultUploadRateLimiterConfig returns default config for image uploads
+func DefaultUploadRateLimiterConfig() RateLimiterConfig {
+	return RateLimiterConfig{
+		MaxRequests:     30,
+		WindowDuration:  1 * time.Minute,
+		CleanupInterval: 5 * time.Minute,
+	}
+}
+
+// DefaultSyncRateLimiterConfig returns default config for app sync
+func DefaultSyncRateLimiterConfig() RateLimiterConfig {
+	return RateLimiterConfig{
+		MaxRequests:     60,
+		WindowDuration:  1 * time.Minute,
+		CleanupInterval: 5 * time.Minute,
+	}
+}
+
+// DefaultPublicRateLimiterConfig returns default config for public landing pages
+func DefaultPublicRateLimiterConfig() RateLimiterConfig {
+	return RateLimiterConfig{
+		MaxRequests:     120,
+		WindowDuration:  1 * time.Minute,
+		CleanupInterval: 5 * time.Minute,
+	}
+}
+
+// DefaultEnquiryRateLimiterConfig returns default config for landing page enquiries
+func DefaultEnquiryRateLimiterConfig() RateLimiterConfig {
+	return RateLimiterConfig{
+		MaxRequests:     5,
+		WindowDuration:  10 * time.Minute,
+		CleanupInterval: 5 * time.Minute,
+	}
+}
+
+// RateLimitResult is the outcome of a rate limit check
+type RateLimitResult struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	Reset     time.Duration
+}
+
+// RateLimiter decides whether another request for a key is allowed
+type RateLimiter interface {
+	// Allow records a request for key and reports whether it is within the limit
+	Allow(ctx context.Context, key string) (RateLimitResult, error)
+
+	// Stop stops any background cleanup
+	Stop()
+}
+
+// MemoryRateLimiter provides fixed-window rate limiting in process memory.
+// Limits are per replica and reset on restart.
+type MemoryRateLimiter struct {
 	config   RateLimiterConfig
 	requests map[string]*requestInfo
 	mu       sync.RWMutex
@@ -37,9 +103,9 @@ type requestInfo struct {
 	windowEnd time.Time
 }
 
-// NewRateLimiter creates a new rate limiter with the given configuration
-func NewRateLimiter(config RateLimiterConfig) *RateLimiter {
-	rl := &RateLimiter{
+// NewMemoryRateLimiter creates a new in-memory rate limiter with the given configuration
+func NewMemoryRateLimiter(config RateLimiterConfig) *MemoryRateLimiter {
+	rl := &MemoryRateLimiter{
 		config:   config,
 		requests: make(map[string]*requestInfo),
 		stopCh:   make(chan struct{}),
@@ -50,7 +116,7 @@ func NewRateLimiter(config RateLimiterConfig) *RateLimiter {
 	return rl
 }
 
-func (rl *RateLimiter) cleanupLoop() {
+func (rl *MemoryRateLimiter) cleanupLoop() {
 	ticker := time.NewTicker(rl.config.CleanupInterval)
 	defer ticker.Stop()
 
@@ -64,89 +130,132 @@ func (rl *RateLimiter) cleanupLoop() {
 	}
 }
 
-func (rl *RateLimiter) cleanup() {
+func (rl *MemoryRateLimiter) cleanup() {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
 	now := time.Now()
-	for ip, info := range rl.requests {
+	for key, info := range rl.requests {
 		if now.After(info.windowEnd) {
-			delete(rl.requests, ip)
+			delete(rl.requests, key)
 		}
 	}
 }
 
 // Stop stops the cleanup goroutine
-func (rl *RateLimiter) Stop() {
+func (rl *MemoryRateLimiter) Stop() {
 	close(rl.stopCh)
 }
 
-func (rl *RateLimiter) isAllowed(ip string) bool {
+// Allow records a request for key and reports whether it is within the limit
+func (rl *MemoryRateLimiter) Allow(_ context.Context, key string) (RateLimitResult, error) {
+	return rl.allowAt(key, time.Now()), nil
+}
+
+// allowAt is Allow for a request made at now
+func (rl *MemoryRateLimiter) allowAt(key string, now time.Time) RateLimitResult {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
-	now := time.Now()
-	info, exists := rl.requests[ip]
+	info, exists := rl.requests[key]
 
 	if !exists || now.After(info.windowEnd) {
-		rl.requests[ip] = &requestInfo{
-			count:     1,
-			windowEnd: now.Add(rl.config.WindowDuration),
-		}
-		return true
+		info = &requestInfo{windowEnd: now.Add(rl.config.WindowDuration)}
+		rl.requests[key] = info
 	}
 
+	result := RateLimitResult{
+		Limit: rl.config.MaxRequests,
+		Reset: info.windowEnd.Sub(now),
+	}
 	if info.count >= rl.config.MaxRequests {
-		return false
+		return result
 	}
 
 	info.count++
-	return true
+	result.Allowed = true
+	result.Remaining = rl.config.MaxRequests - info.count
+	return result
 }
 
-func (rl *RateLimiter) getRemainingTime(ip string) time.Duration {
-	rl.mu.RLock()
-	defer rl.mu.RUnlock()
-
-	info, exists := rl.requests[ip]
-	if !exists {
-		return 0
+// CheckRateLimit records a request for key, sets the RateLimit-* headers and
+// aborts with 429 when the limit is exceeded. A limiter error lets the request
+// through so that a storage outage does not take the API down.
+func CheckRateLimit(c *gin.Context, rl RateLimiter, key string) bool {
+	result, err := rl.Allow(c.Request.Context(), key)
+	if err != nil {
+		log.Printf("rate limiter error for %s: %v", key, err)
+		return true
 	}
 
-	remaining := time.Until(info.windowEnd)
-	if remaining < 0 {
-		return 0
+	reset := int(result.Reset.Seconds())
+	c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+	c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	c.Header("RateLimit-Reset", strconv.Itoa(reset))
+
+	if !result.Allowed {
+		c.Header("Retry-After", strconv.Itoa(reset))
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":        "RATE_LIMIT_EXCEEDED",
+				"message":     "Too many requests. Please try again later.",
+				"retry_after": reset,
+			},
+		})
+		c.Abort()
+		return false
 	}
-	return remaining
+	return true
 }
 
-// Middleware returns a Gin middleware that rate limits requests
-func (rl *RateLimiter) Middleware() gin.HandlerFunc {
+// rateLimitMiddleware returns a Gin middleware that rate limits requests per
+// client. The limiter is looked up on every request so that limiters replaced
+// by ConfigureRateLimiters apply to routes registered earlier.
+func rateLimitMiddleware(limiter func() RateLimiter, key func(c *gin.Context) string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ip := c.ClientIP()
-
-		if !rl.isAllowed(ip) {
-			remaining := rl.getRemainingTime(ip)
-			c.JSON(http.StatusTooManyRequests, gin.H{
-				"success": false,
-				"error": gin.H{
-					"code":        "RATE_LIMIT_EXCEEDED",
-					"message":     "Too many requests. Please try again later.",
-					"retry_after": int(remaining.Seconds()),
-				},
-			})
-			c.Abort()
-			return
+		if CheckRateLimit(c, limiter(), key(c)) {
+			c.Next()
 		}
-
-		c.Next()
 	}
 }
 
-// AuthRateLimiter is a pre-configured rate limiter for authentication endpoints
-var AuthRateLimiter = NewRateLimiter(DefaultAuthRateLimiterConfig())
+// clientIPKey identifies a request by its client IP
+func clientIPKey(c *gin.Context) string {
+	return c.ClientIP()
+}
+
+// userOrIPKey identifies a request by the authenticated user, falling back to the client IP
+func userOrIPKey(c *gin.Context) string {
+	if userID, ok := c.Get("userID"); ok {
+		if id, ok := userID.(int64); ok {
+			return "user:" + strconv.FormatInt(id, 10)
+		}
+	}
+	return "ip:" + c.ClientIP()
+}
 
 // RateLimitAuth returns a middleware that rate limits authentication attempts
 func RateLimitAuth() gin.HandlerFunc {
-	return AuthRateLimiter.Middleware()
+	return rateLimitMiddleware(func() RateLimiter { return AuthRateLimiter }, clientIPKey)
+}
+
+// RateLimitUploads returns a middleware that rate limits image uploads per user
+func RateLimitUploads() gin.HandlerFunc {
+	return rateLimitMiddleware(func() RateLimiter { return UploadRateLimiter }, userOrIPKey)
+}
+
+// RateLimitSync returns a middleware that rate limits app sync per user
+func RateLimitSync() gin.HandlerFunc {
+	return rateLimitMiddleware(func() RateLimiter { return SyncRateLimiter }, userOrIPKey)
+}
+
+// RateLimitPublic returns a middleware that rate limits public landing pages
+func RateLimitPublic() gin.HandlerFunc {
+	return rateLimitMiddleware(func() RateLimiter { return PublicRateLimiter }, clientIPKey)
+}
+
+// RateLimitEnquiry returns a middleware that rate limits landing page enquiries
+func RateLimitEnquiry() gin.HandlerFunc {
+	return rateLimitMiddleware(func() RateLimiter { return EnquiryRateLimiter }, clientIPKey)
 }
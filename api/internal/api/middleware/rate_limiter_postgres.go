@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"math"
+	"time"
+
+	"callflow/internal/domain/ratelimit"
+)
+
+// PostgresRateLimiter provides sliding-window rate limiting shared by every
+// replica. Requests are counted in fixed windows and the previous window is
+// weighted by how much of it still overlaps the sliding window.
+type PostgresRateLimiter struct {
+	name   string
+	config RateLimiterConfig
+	repo   ratelimit.Repository
+}
+
+// NewPostgresRateLimiter creates a new Postgres-backed rate limiter. The name
+// namespaces its keys in the shared bucket table.
+func NewPostgresRateLimiter(name string, config RateLimiterConfig, repo ratelimit.Repository) *PostgresRateLimiter {
+	return &PostgresRateLimiter{
+		name:   name,
+		config: config,
+		repo:   repo,
+	}
+}
+
+// Stop does nothing; expired buckets of all Postgres limiters are removed by
+// one postgresBucketCleaner.
+func (rl *PostgresRateLimiter) Stop() {}
+
+// Allow records a request for key and reports whether it is within the limit
+func (rl *PostgresRateLimiter) Allow(ctx context.Context, key string) (RateLimitResult, error) {
+	return rl.allowAt(ctx, key, time.Now())
+}
+
+// allowAt is Allow for a request made at now. Like the memory limiter, it
+// only counts requests it allows, so a client retrying while limited is let
+// through again once the window has moved on.
+func (rl *PostgresRateLimiter) allowAt(ctx context.Context, key string, now time.Time) (RateLimitResult, error) {
+	window := rl.config.WindowDuration
+	windowStart := now.Truncate(window)
+	bucketKey := rl.name + ":" + key
+
+	result := RateLimitResult{
+		Limit: rl.config.MaxRequests,
+		Reset: windowStart.Add(window).Sub(now),
+	}
+
+	previous, err := rl.repo.Count(ctx, bucketKey, windowStart.Add(-window))
+	if err != nil {
+		return RateLimitResult{}, err
+	}
+	overlap := float64(previous) * (1 - float64(now.Sub(windowStart))/float64(window))
+
+	// The most requests the current window may hold with the previous one's overlap.
+	allowance := int(math.Floor(float64(rl.config.MaxRequests) - overlap))
+	if allowance <= 0 {
+		return result, nil
+	}
+	current, ok, err := rl.repo.Hit(ctx, bucketKey, windowStart, windowStart.Add(2*window), allowance)
+	if err != nil {
+		return RateLimitResult{}, err
+	}
+	if !ok {
+		return result, nil
+	}
+
+	result.Allowed = true
+	result.Remaining = rl.config.MaxRequests - int(math.Ceil(overlap+float64(current)))
+	return result, nil
+}
+
+// postgresBucketCleaner deletes expired buckets from the table shared by every
+// Postgres limiter, so a replica runs one cleanup rather than one per group.
+type postgresBucketCleaner struct {
+	repo     ratelimit.Repository
+	interval time.Duration
+	stopCh   chan struct{}
+}
+
+func newPostgresBucketCleaner(repo ratelimit.Repository, interval time.Duration) *postgresBucketCleaner {
+	c := &postgresBucketCleaner{
+		repo:     repo,
+		interval: interval,
+		stopCh:   make(chan struct{}),
+	}
+
+	go c.cleanupLoop()
+
+	return c
+}
+
+func (c *postgresBucketCleaner) cleanupLoop() {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.repo.DeleteExpired(context.Background()); err != nil {
+				log.Printf("failed to delete expired rate limit buckets: %v", err)
+			}
+		case <-c.stopCh:
+			return
+		}
+	}
+}
+
+// Stop stops the cleanup goroutine
+func (c *postgresBucketCleaner) Stop() {
+	close(c.stopCh)
+}
@@ -0,0 +1,205 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// fakeRateLimitRepo keeps rate limit counters in a map
+type fakeRateLimitRepo struct {
+	counts map[string]int
+}
+
+func bucket(key string, windowStart time.Time) string {
+	return fmt.Sprintf("%s@%d", key, windowStart.Unix())
+}
+
+func (r *fakeRateLimitRepo) Hit(_ context.Context, key string, windowStart, _ time.Time, limit int) (int, bool, error) {
+	if r.counts[bucket(key, windowStart)] >= limit {
+		return 0, false, nil
+	}
+	r.counts[bucket(key, windowStart)]++
+	return r.counts[bucket(key, windowStart)], true, nil
+}
+
+func (r *fakeRateLimitRepo) Count(_ context.Context, key string, windowStart time.Time) (int, error) {
+	return r.counts[bucket(key, windowStart)], nil
+}
+
+func (r *fakeRateLimitRepo) DeleteExpired(context.Context) error {
+	return nil
+}
+
+func TestMemoryRateLimiterWindow(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	type request struct {
+		key           string
+		at            time.Duration
+		wantAllowed   bool
+		wantRemaining int
+		wantReset     time.Duration
+	}
+	tests := []struct {
+		name     string
+		requests []request
+	}{
+		{
+			name: "counts requests within the window",
+			requests: []request{
+				{"a", 0, true, 1, time.Minute},
+				{"a", 10 * time.Second, true, 0, 50 * time.Second},
+				{"a", 20 * time.Second, false, 0, 40 * time.Second},
+			},
+		},
+		{
+			name: "starts a new window once the old one ends",
+			requests: []request{
+				{"a", 0, true, 1, time.Minute},
+				{"a", time.Second, true, 0, 59 * time.Second},
+				{"a", time.Minute, false, 0, 0},
+				{"a", time.Minute + time.Second, true, 1, time.Minute},
+			},
+		},
+		{
+			name: "keeps keys apart",
+			requests: []request{
+				{"a", 0, true, 1, time.Minute},
+				{"a", 0, true, 0, time.Minute},
+				{"a", 0, false, 0, time.Minute},
+				{"b", 0, true, 1, time.Minute},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rl := &MemoryRateLimiter{
+				config:   RateLimiterConfig{MaxRequests: 2, WindowDuration: time.Minute},
+				requests: make(map[string]*requestInfo),
+			}
+			for i, req := range tt.requests {
+				got := rl.allowAt(req.key, start.Add(req.at))
+				if got.Allowed != req.wantAllowed || got.Remaining != req.wantRemaining || got.Reset != req.wantReset {
+					t.Errorf("request %d: got allowed=%v remaining=%d reset=%v, want allowed=%v remaining=%d reset=%v",
+						i, got.Allowed, got.Remaining, got.Reset, req.wantAllowed, req.wantRemaining, req.wantReset)
+				}
+				if got.Limit != 2 {
+					t.Errorf("request %d: limit = %d, want 2", i, got.Limit)
+				}
+			}
+		})
+	}
+}
+
+func TestPostgresRateLimiterSlidingWindow(t *testing.T) {
+	windowStart := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		previous      int
+		current       int
+		at            time.Duration
+		wantAllowed   bool
+		wantRemaining int
+		wantReset     time.Duration
+	}{
+		{"first request", 0, 0, 0, true, 9, time.Minute},
+		{"last request of the limit", 0, 9, 0, true, 0, time.Minute},
+		{"over the limit in the current window", 0, 10, 0, false, 0, time.Minute},
+		{"full previous window at the start", 10, 0, 0, false, 0, time.Minute},
+		{"previous window half overlapping", 10, 0, 30 * time.Second, true, 4, 30 * time.Second},
+		{"previous window barely overlapping", 10, 0, 54 * time.Second, true, 8, 6 * time.Second},
+		{"partial overlap rounds up", 3, 0, 45 * time.Second, true, 8, 15 * time.Second},
+		{"overlap and current window together", 10, 4, 30 * time.Second, true, 0, 30 * time.Second},
+		{"overlap and current window over the limit", 10, 5, 30 * time.Second, false, 0, 30 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeRateLimitRepo{counts: map[string]int{
+				bucket("test:key", windowStart.Add(-time.Minute)): tt.previous,
+				bucket("test:key", windowStart):                   tt.current,
+			}}
+			rl := &PostgresRateLimiter{
+				name:   "test",
+				config: RateLimiterConfig{MaxRequests: 10, WindowDuration: time.Minute},
+				repo:   repo,
+			}
+
+			got, err := rl.allowAt(context.Background(), "key", windowStart.Add(tt.at))
+			if err != nil {
+				t.Fatalf("allowAt() error = %v", err)
+			}
+			if got.Allowed != tt.wantAllowed || got.Remaining != tt.wantRemaining || got.Reset != tt.wantReset {
+				t.Errorf("got allowed=%v remaining=%d reset=%v, want allowed=%v remaining=%d reset=%v",
+					got.Allowed, got.Remaining, got.Reset, tt.wantAllowed, tt.wantRemaining, tt.wantReset)
+			}
+			wantCount := tt.current
+			if tt.wantAllowed {
+				wantCount++
+			}
+			if n := repo.counts[bucket("test:key", windowStart)]; n != wantCount {
+				t.Errorf("current window count = %d, want %d", n, wantCount)
+			}
+		})
+	}
+}
+
+func TestRateLimitersAgreeOnRetries(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	config := RateLimiterConfig{MaxRequests: 3, WindowDuration: time.Minute}
+	memory := &MemoryRateLimiter{config: config, requests: make(map[string]*requestInfo)}
+	postgres := &PostgresRateLimiter{name: "test", config: config, repo: &fakeRateLimitRepo{counts: map[string]int{}}}
+
+	// A client that keeps retrying once limited is not counted again by either backend.
+	for i, at := range []time.Duration{0, time.Second, 2 * time.Second, 3 * time.Second, 4 * time.Second, 5 * time.Second} {
+		now := start.Add(at)
+		want := memory.allowAt("key", now)
+		got, err := postgres.allowAt(context.Background(), "key", now)
+		if err != nil {
+			t.Fatalf("request %d: allowAt() error = %v", i, err)
+		}
+		if got != want {
+			t.Errorf("request %d: postgres %+v, memory %+v", i, got, want)
+		}
+	}
+	if n := postgres.repo.(*fakeRateLimitRepo).counts[bucket("test:key", start)]; n != config.MaxRequests {
+		t.Errorf("postgres count = %d, want %d", n, config.MaxRequests)
+	}
+}
+
+func TestRateLimiterConfigFromEnv(t *testing.T) {
+	def := RateLimiterConfig{MaxRequests: 5, WindowDuration: time.Minute, CleanupInterval: time.Hour}
+
+	tests := []struct {
+		name    string
+		value   string
+		want    RateLimiterConfig
+		wantErr bool
+	}{
+		{"unset keeps the default", "", def, false},
+		{"overrides requests and window", "100/10m", RateLimiterConfig{MaxRequests: 100, WindowDuration: 10 * time.Minute, CleanupInterval: time.Hour}, false},
+		{"allows spaces", " 3 / 30s ", RateLimiterConfig{MaxRequests: 3, WindowDuration: 30 * time.Second, CleanupInterval: time.Hour}, false},
+		{"missing window", "100", def, true},
+		{"zero requests", "0/1m", def, true},
+		{"negative requests", "-1/1m", def, true},
+		{"invalid window", "10/soon", def, true},
+		{"zero window", "10/0s", def, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("RATE_LIMIT_TEST", tt.value)
+			got, err := rateLimiterConfigFromEnv("test", def)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -16,6 +16,12 @@ const (
 	ErrExpiredToken       = "ERR_EXPIRED_TOKEN"
 	ErrAuthFailed         = "ERR_AUTH_FAILED"
 	ErrUserInactive       = "ERR_USER_INACTIVE"
+	ErrPhoneNotVerified   = "ERR_PHONE_NOT_VERIFIED"
+	ErrInvalidOTP         = "ERR_INVALID_OTP"
+	ErrOTPExpired         = "ERR_OTP_EXPIRED"
+	ErrTooManyAttempts    = "ERR_TOO_MANY_ATTEMPTS"
+	ErrTooManyRequests    = "ERR_TOO_MANY_REQUESTS"
+	ErrAccountLocked      = "ERR_ACCOUNT_LOCKED"
 )
 
 // CRUD operation errors
@@ -39,11 +45,76 @@ const (
 	ErrSMSTooLong       = "ERR_SMS_TOO_LONG"
 )
 
+// Landing errors
+const (
+	ErrSlugTaken        = "ERR_SLUG_TAKEN"
+	ErrDraftNotFound    = "ERR_DRAFT_NOT_FOUND"
+	ErrRevisionNotFound = "ERR_REVISION_NOT_FOUND"
+)
+
 // Rule errors
 const (
 	ErrRuleNotFound = "ERR_RULE_NOT_FOUND"
 )
 
+// Contact errors
+const (
+	ErrContactNotFound = "ERR_CONTACT_NOT_FOUND"
+)
+
+// Sequence errors
+const (
+	ErrSequenceNotFound = "ERR_SEQUENCE_NOT_FOUND"
+)
+
+// Campaign errors
+const (
+	ErrCampaignNotFound   = "ERR_CAMPAIGN_NOT_FOUND"
+	ErrCampaignTransition = "ERR_CAMPAIGN_INVALID_TRANSITION"
+)
+
+// Device errors
+const (
+	ErrDeviceNotFound = "ERR_DEVICE_NOT_FOUND"
+	ErrJobLeaseLost   = "ERR_JOB_LEASE_LOST"
+)
+
+// Organization errors
+const (
+	ErrOrganizationNotFound = "ERR_ORGANIZATION_NOT_FOUND"
+	ErrMemberNotFound       = "ERR_MEMBER_NOT_FOUND"
+	ErrInvitationNotFound   = "ERR_INVITATION_NOT_FOUND"
+)
+
+// API key errors
+const (
+	ErrAPIKeyNotFound = "ERR_API_KEY_NOT_FOUND"
+	ErrInvalidScope   = "ERR_INVALID_SCOPE"
+)
+
+// Webhook errors
+const (
+	ErrWebhookNotFound  = "ERR_WEBHOOK_NOT_FOUND"
+	ErrDeliveryNotFound = "ERR_DELIVERY_NOT_FOUND"
+)
+
+// Lead errors
+const (
+	ErrLeadEndpointNotFound = "ERR_LEAD_ENDPOINT_NOT_FOUND"
+	ErrInvalidSignature     = "ERR_INVALID_SIGNATURE"
+)
+
+// Media errors
+const (
+	ErrMediaNotFound = "ERR_MEDIA_NOT_FOUND"
+	ErrMediaInUse    = "ERR_MEDIA_IN_USE"
+)
+
+// Short link errors
+const (
+	ErrShortLinkNotFound = "ERR_SHORT_LINK_NOT_FOUND"
+)
+
 // Plan errors
 const (
 	ErrPlanRequired     = "ERR_PLAN_REQUIRED"
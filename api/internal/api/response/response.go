@@ -85,3 +85,8 @@ func Unauthorized(c *gin.Context, code, message, detail string) {
 func Conflict(c *gin.Context, code, message, detail string) {
 	Error(c, http.StatusConflict, code, message, detail)
 }
+
+// TooManyRequests sends a 429 error
+func TooManyRequests(c *gin.Context, code, message, detail string) {
+	Error(c, http.StatusTooManyRequests, code, message, detail)
+}
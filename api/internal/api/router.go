@@ -9,7 +9,9 @@ import (
 	handler "callflow/internal/api/handlers"
 	"callflow/internal/api/middleware"
 	"callflow/internal/api/response"
+	"callflow/internal/domain/apikey"
 	"callflow/internal/domain/auth"
+	"callflow/internal/domain/organization"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -61,13 +63,25 @@ func getHealthCheck() HealthCheckResponse {
 // SetupRouter configures and returns the Gin router
 func SetupRouter(
 	authService auth.Service,
+	apiKeyService apikey.Service,
+	organizationService organization.Service,
 	authHandler *handler.AuthHandler,
+	otpHandler *handler.OTPHandler,
 	userHandler *handler.UserHandler,
 	templateHandler *handler.TemplateHandler,
 	landingHandler *handler.LandingHandler,
 	ruleHandler *handler.RuleHandler,
 	syncHandler *handler.SyncHandler,
 	contactHandler *handler.ContactHandler,
+	sequenceHandler *handler.SequenceHandler,
+	campaignHandler *handler.CampaignHandler,
+	deviceHandler *handler.DeviceHandler,
+	organizationHandler *handler.OrganizationHandler,
+	apiKeyHandler *handler.APIKeyHandler,
+	webhookHandler *handler.WebhookHandler,
+	leadHandler *handler.LeadHandler,
+	mediaHandler *handler.MediaHandler,
+	shortLinkHandler *handler.ShortLinkHandler,
 	adminHandler *handler.AdminHandler,
 ) *gin.Engine {
 	router := gin.Default()
@@ -75,9 +89,16 @@ func SetupRouter(
 	// CORS
 	corsConfig := cors.DefaultConfig()
 	corsConfig.AllowAllOrigins = true
-	corsConfig.AllowHeaders = append(corsConfig.AllowHeaders, "Authorization")
+	corsConfig.AllowHeaders = append(corsConfig.AllowHeaders, "Authorization", middleware.APIKeyHeader, middleware.OrganizationHeader)
+	corsConfig.ExposeHeaders = append(corsConfig.ExposeHeaders, "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After")
 	router.Use(cors.New(corsConfig))
 
+	// Token verification keys for other services
+	authHandler.RegisterWellKnownRoutes(router.Group(""))
+
+	// Short link redirects (public)
+	shortLinkHandler.RegisterRedirectRoutes(router.Group(""))
+
 	// API v1
 	v1 := router.Group("/api/v1")
 
@@ -100,33 +121,72 @@ func SetupRouter(
 
 	// Auth routes (public)
 	authHandler.RegisterRoutes(v1)
+	otpHandler.RegisterRoutes(v1)
 
 	// Public landing routes
 	landingHandler.RegisterPublicRoutes(v1)
 
+	// Inbound lead routes (signed per user)
+	leadHandler.RegisterPublicRoutes(v1)
+
 	// Protected routes
-	mf := middleware.NewMiddlewareFactory(authService)
+	mf := middleware.NewMiddlewareFactory(authService, apiKeyService, organizationService)
 	protected := v1.Group("")
 	protected.Use(mf.AuthChain())
+
+	// Account routes (signed-in users only, not API keys)
+	account := protected.Group("")
+	account.Use(middleware.RejectAPIKeys())
 	{
 		// User routes
-		userHandler.RegisterRoutes(protected)
+		userHandler.RegisterRoutes(account)
+		authHandler.RegisterProtectedRoutes(account)
+
+		// Organization routes
+		organizationHandler.RegisterRoutes(account)
+
+		// API key routes
+		apiKeyHandler.RegisterRoutes(account)
+	}
 
+	// Business routes, acting on the organization selected by X-Organization-ID.
+	// API keys reach each group only with the matching scope.
+	business := protected.Group("")
+	business.Use(mf.OrganizationChain())
+	{
 		// Template routes
-		templateHandler.RegisterRoutes(protected)
+		templateHandler.RegisterRoutes(business.Group("", middleware.RequireScope("templates")))
 
 		// Landing routes
-		landingHandler.RegisterRoutes(protected)
+		landingHandler.RegisterRoutes(business.Group("", middleware.RequireScope("landing")))
 
 		// Rule routes
-		ruleHandler.RegisterRoutes(protected)
+		ruleHandler.RegisterRoutes(business.Group("", middleware.RequireScope("rules")))
 
 		// Contact routes
-		contactHandler.RegisterRoutes(protected)
+		contactHandler.RegisterRoutes(business.Group("", middleware.RequireScope("contacts")))
+
+		// Sequence routes
+		sequenceHandler.RegisterRoutes(business.Group("", middleware.RequireScope("sequences")))
+
+		// Campaign routes
+		campaignHandler.RegisterRoutes(business.Group("", middleware.RequireScope("campaigns")))
+
+		// Device job routes
+		deviceHandler.RegisterRoutes(business.Group("", middleware.RequireScope("devices")))
 
 		// Sync routes
-		syncHandler.RegisterRoutes(protected)
+		syncHandler.RegisterRoutes(business.Group("", middleware.RequireScope("sync")))
+
+		// Webhook routes
+		webhookHandler.RegisterRoutes(business.Group("", middleware.RequireScope("webhooks")))
+
+		// Lead routes
+		leadHandler.RegisterRoutes(business.Group("", middleware.RequireScope("leads")))
+		mediaHandler.RegisterRoutes(business.Group("", middleware.RequireScope("media")))
 
+		// Short link routes
+		shortLinkHandler.RegisterRoutes(business.Group("", middleware.RequireScope("links")))
 	}
 
 	// Admin routes (no auth — local use only)
@@ -134,6 +194,7 @@ func SetupRouter(
 
 	// Serve admin UI at /admin
 	serveAdmin(router)
+	serveLocalImages(router)
 
 	return router
 }
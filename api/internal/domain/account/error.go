@@ -0,0 +1,7 @@
+package account
+
+import "errors"
+
+var (
+	ErrInvalidPassword = errors.New("password is incorrect")
+)
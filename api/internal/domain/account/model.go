@@ -0,0 +1,60 @@
+package account
+
+import (
+	"time"
+
+	"callflow/internal/domain/apikey"
+	"callflow/internal/domain/campaign"
+	"callflow/internal/domain/contact"
+	"callflow/internal/domain/device"
+	"callflow/internal/domain/landing"
+	"callflow/internal/domain/lead"
+	"callflow/internal/domain/media"
+	"callflow/internal/domain/rule"
+	"callflow/internal/domain/sequence"
+	"callflow/internal/domain/shortlink"
+	"callflow/internal/domain/template"
+	"callflow/internal/domain/user"
+	"callflow/internal/domain/webhook"
+)
+
+// Export is everything stored about a user, as handed out by the data export
+type Export struct {
+	ExportedAt          time.Time              `json:"exported_at"`
+	Profile             *user.User             `json:"profile"`
+	Templates           []*template.Template   `json:"templates"`
+	Rule                *rule.Rule             `json:"rule,omitempty"`
+	Contacts            []*contact.Contact     `json:"contacts"`
+	Landing             *landing.Landing       `json:"landing,omitempty"`
+	LandingDraft        *landing.Draft         `json:"landing_draft,omitempty"`
+	LandingRevisions    []*landing.Revision    `json:"landing_revisions"`
+	LandingSlugs        []*landing.Slug        `json:"landing_slugs"`
+	Sequences           []*sequence.Sequence   `json:"sequences"`
+	SequenceEnrollments []*sequence.Enrollment `json:"sequence_enrollments"`
+	Campaigns           []*campaign.Campaign   `json:"campaigns"`
+	CampaignMessages    []*campaign.Recipient  `json:"campaign_messages"`
+	DeviceJobs          []*device.Job          `json:"device_jobs"`
+	Leads               []*lead.Lead           `json:"leads"`
+	Media               []*media.Item          `json:"media"`
+	Webhooks            []*webhook.Webhook     `json:"webhooks"`
+	APIKeys             []*apikey.Key          `json:"api_keys"`
+	ShortLinks          []*shortlink.ShortLink `json:"short_links"`
+	ShortLinkClicks     []*shortlink.Click     `json:"short_link_clicks"`
+}
+
+// DeleteRequest confirms the deletion of the authenticated user's account
+type DeleteRequest struct {
+	Password string `json:"password" validate:"required"`
+}
+
+// Export formats
+const (
+	FormatJSON = "json"
+	FormatZIP  = "zip"
+)
+
+// Deletion limits
+const (
+	DefaultPurgeAfter = 30 * 24 * time.Hour
+	PurgeBatchSize    = 50
+)
@@ -0,0 +1,29 @@
+package account
+
+import (
+	"context"
+	"time"
+
+	"callflow/internal/domain/campaign"
+	"callflow/internal/domain/device"
+	"callflow/internal/domain/landing"
+	"callflow/internal/domain/lead"
+	"callflow/internal/domain/sequence"
+	"callflow/internal/domain/shortlink"
+	"callflow/internal/domain/user"
+)
+
+// Repository defines the interface for account-wide data access
+type Repository interface {
+	ListCampaignMessages(ctx context.Context, userID int64) ([]*campaign.Recipient, error)
+	ListDeviceJobs(ctx context.Context, userID int64) ([]*device.Job, error)
+	ListSequenceEnrollments(ctx context.Context, userID int64) ([]*sequence.Enrollment, error)
+	ListLeads(ctx context.Context, userID int64) ([]*lead.Lead, error)
+	ListShortLinks(ctx context.Context, userID int64) ([]*shortlink.ShortLink, error)
+	ListShortLinkClicks(ctx context.Context, userID int64) ([]*shortlink.Click, error)
+	ListLandingSlugs(ctx context.Context, userID int64) ([]*landing.Slug, error)
+	ListLandingRevisions(ctx context.Context, userID int64) ([]*landing.Revision, error)
+	SoftDelete(ctx context.Context, userID int64, purgeAfter time.Time) error
+	ListDueForPurge(ctx context.Context, limit int) ([]*user.User, error)
+	Purge(ctx context.Context, u *user.User) error
+}
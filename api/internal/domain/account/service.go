@@ -0,0 +1,13 @@
+package account
+
+import "context"
+
+// Service defines the interface for account export and deletion
+type Service interface {
+	// Export collects everything stored about the user
+	Export(ctx context.Context, userID int64) (*Export, error)
+
+	// Delete re-checks the password, signs out every session and schedules
+	// the account and its stored images for permanent deletion.
+	Delete(ctx context.Context, userID int64, req DeleteRequest) error
+}
@@ -0,0 +1,14 @@
+package apikey
+
+import "errors"
+
+var (
+	ErrKeyNotFound   = errors.New("api key not found")
+	ErrInvalidKey    = errors.New("invalid api key")
+	ErrExpiredKey    = errors.New("api key has expired")
+	ErrRevokedKey    = errors.New("api key has been revoked")
+	ErrInvalidScope  = errors.New("invalid scope")
+	ErrInvalidExpiry = errors.New("expiry must be in the future")
+	ErrTooManyKeys   = errors.New("too many active api keys")
+	ErrUserInactive  = errors.New("user account is inactive")
+)
@@ -0,0 +1,94 @@
+package apikey
+
+import (
+	"strings"
+	"time"
+)
+
+// Key is an API key that lets an integration act on a user's account
+type Key struct {
+	ID         int64      `json:"id"`
+	UserID     int64      `json:"user_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	KeyHash    string     `json:"-"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// KeyCreate contains data for creating an API key
+type KeyCreate struct {
+	Name      string     `json:"name" validate:"required,max=100"`
+	Scopes    []string   `json:"scopes" validate:"required,min=1"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// CreatedKey is returned once on creation; the plaintext key is not stored
+type CreatedKey struct {
+	*Key
+	Secret string `json:"key"`
+}
+
+// Principal is the identity an authenticated API key acts as
+type Principal struct {
+	KeyID  int64
+	UserID int64
+	Phone  string
+	Plan   string
+	Scopes []string
+}
+
+// KeyPrefix marks API keys so they can be told apart from JWTs
+const KeyPrefix = "cfk_"
+
+// MaxActiveKeys is the number of usable keys a user may hold
+const MaxActiveKeys = 20
+
+// Scope resources. A scope is "<resource>:read" or "<resource>:write"; write implies read.
+var Resources = []string{
+	"templates",
+	"rules",
+	"contacts",
+	"landing",
+	"sequences",
+	"campaigns",
+	"devices",
+	"sync",
+	"webhooks",
+	"leads",
+	"media",
+	"links",
+}
+
+// Scope access levels
+const (
+	AccessRead  = "read"
+	AccessWrite = "write"
+)
+
+// IsValidScope reports whether scope names a known resource and access level
+func IsValidScope(scope string) bool {
+	resource, access, ok := strings.Cut(scope, ":")
+	if !ok || (access != AccessRead && access != AccessWrite) {
+		return false
+	}
+	for _, r := range Resources {
+		if r == resource {
+			return true
+		}
+	}
+	return false
+}
+
+// HasScope reports whether the scopes grant the access level on the resource
+func HasScope(scopes []string, resource, access string) bool {
+	for _, s := range scopes {
+		if s == resource+":"+access || s == resource+":"+AccessWrite {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,14 @@
+package apikey
+
+import "context"
+
+// Repository defines the interface for API key data access
+type Repository interface {
+	Create(ctx context.Context, userID int64, data KeyCreate, prefix, keyHash string) (*Key, error)
+	GetByHash(ctx context.Context, keyHash string) (*Key, error)
+	GetByUserID(ctx context.Context, userID int64) ([]*Key, error)
+	CountActive(ctx context.Context, userID int64) (int, error)
+	Revoke(ctx context.Context, id, userID int64) error
+	// Touch records a use of the key; uses within a minute of the last one are not written.
+	Touch(ctx context.Context, id int64) error
+}
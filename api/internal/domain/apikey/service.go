@@ -0,0 +1,14 @@
+package apikey
+
+import "context"
+
+// Service defines the interface for API key business logic
+type Service interface {
+	Create(ctx context.Context, userID int64, data KeyCreate) (*CreatedKey, error)
+	GetByUserID(ctx context.Context, userID int64) ([]*Key, error)
+	Revoke(ctx context.Context, id, userID int64) error
+
+	// Authenticate resolves a plaintext key to the account it acts for, or
+	// returns ErrInvalidKey, ErrExpiredKey, ErrRevokedKey or ErrUserInactive.
+	Authenticate(ctx context.Context, secret string) (*Principal, error)
+}
@@ -7,6 +7,9 @@ var (
 	ErrPhoneTaken         = errors.New("phone number already registered")
 	ErrExpiredToken       = errors.New("token has expired")
 	ErrInvalidToken       = errors.New("invalid token")
+	ErrRevokedToken       = errors.New("token has been revoked")
 	ErrUserInactive       = errors.New("user account is inactive")
 	ErrUnauthorized       = errors.New("unauthorized")
+	ErrAccountLocked      = errors.New("account is temporarily locked")
+	ErrAttemptsNotFound   = errors.New("no login attempts recorded")
 )
@@ -3,14 +3,17 @@ package auth
 import (
 	"time"
 
+	"callflow/internal/domain/device"
+
 	"github.com/golang-jwt/jwt/v5"
 )
 
 // AuthClaims defines the claims in JWT tokens
 type AuthClaims struct {
-	UserID int64  `json:"user_id"`
-	Phone  string `json:"phone"`
-	Plan   string `json:"plan"`
+	UserID   int64  `json:"user_id"`
+	Phone    string `json:"phone"`
+	Plan     string `json:"plan"`
+	DeviceID int64  `json:"device_id,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -22,26 +25,55 @@ type RegisterRequest struct {
 	BusinessName string `json:"business_name"`
 	City         string `json:"city"`
 	Address      string `json:"address"`
+
+	Device *device.Registration `json:"device,omitempty"`
 }
 
 // LoginRequest represents a request to log in
 type LoginRequest struct {
 	Phone    string `json:"phone" validate:"required"`
 	Password string `json:"password" validate:"required"`
+
+	// Device registers the phone logging in; tokens issued for it are revoked
+	// when the device is revoked.
+	Device *device.Registration `json:"device,omitempty"`
+
+	// ClientIP is filled in by the handler and recorded with failed attempts.
+	ClientIP string `json:"-"`
+}
+
+// ForgotPasswordRequest asks for a password reset code
+type ForgotPasswordRequest struct {
+	Phone string `json:"phone" validate:"required"`
+}
+
+// ResetPasswordRequest sets a new password using a reset code
+type ResetPasswordRequest struct {
+	Phone       string `json:"phone" validate:"required"`
+	Code        string `json:"code" validate:"required,len=6,numeric"`
+	NewPassword string `json:"new_password" validate:"required,min=6"`
+}
+
+// ChangePasswordRequest changes the password of a logged in user
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=6,nefield=CurrentPassword"`
 }
 
 // TokenResponse represents the response returned after authentication
 type TokenResponse struct {
-	AccessToken string    `json:"access_token"`
-	TokenType   string    `json:"token_type"`
-	ExpiresAt   time.Time `json:"expires_at"`
-	User        *UserInfo `json:"user"`
+	AccessToken string         `json:"access_token"`
+	TokenType   string         `json:"token_type"`
+	ExpiresAt   time.Time      `json:"expires_at"`
+	User        *UserInfo      `json:"user"`
+	Device      *device.Device `json:"device,omitempty"`
 }
 
 // UserInfo represents user data included in auth responses
 type UserInfo struct {
 	ID            int64      `json:"id"`
 	Phone         string     `json:"phone"`
+	PhoneVerified bool       `json:"phone_verified"`
 	Name          string     `json:"name,omitempty"`
 	BusinessName  string     `json:"business_name,omitempty"`
 	City          string     `json:"city,omitempty"`
@@ -52,3 +84,42 @@ type UserInfo struct {
 	PlanExpiresAt *time.Time `json:"plan_expires_at,omitempty"`
 	Status        string     `json:"status"`
 }
+
+// LoginAttempts tracks failed logins for a phone number
+type LoginAttempts struct {
+	Phone        string     `json:"phone"`
+	FailedCount  int        `json:"failed_count"`
+	Lockouts     int        `json:"lockouts"`
+	LockedUntil  *time.Time `json:"locked_until,omitempty"`
+	LastFailedAt *time.Time `json:"last_failed_at,omitempty"`
+	LastIP       string     `json:"last_ip,omitempty"`
+}
+
+// IsLocked reports whether the account is locked at the given time
+func (a *LoginAttempts) IsLocked(now time.Time) bool {
+	return a.LockedUntil != nil && a.LockedUntil.After(now)
+}
+
+// Failed-login lockout policy. Each lockout doubles the previous one.
+const (
+	MaxFailedLogins = 5
+	BaseLockout     = 1 * time.Minute
+	MaxLockout      = 24 * time.Hour
+)
+
+// JWK is a public signing key in JSON Web Key format
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+}
+
+// JWKSet is the set of keys that verify issued tokens
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
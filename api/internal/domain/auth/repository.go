@@ -0,0 +1,15 @@
+package auth
+
+import (
+	"context"
+	"time"
+)
+
+// Repository defines the interface for login attempt data access
+type Repository interface {
+	GetAttempts(ctx context.Context, phone string) (*LoginAttempts, error)
+	RecordFailure(ctx context.Context, phone, ip string) (*LoginAttempts, error)
+	Lock(ctx context.Context, phone string, until time.Time) error
+	ResetAttempts(ctx context.Context, phone string) error
+	ListLocked(ctx context.Context) ([]*LoginAttempts, error)
+}
@@ -7,9 +7,28 @@ type Service interface {
 	// Register creates a new user with phone and password
 	Register(ctx context.Context, req RegisterRequest) (*TokenResponse, error)
 
-	// Login authenticates a user with phone and password
+	// Login authenticates a user with phone and password. Repeated failures
+	// lock the phone number with an exponentially growing lockout.
 	Login(ctx context.Context, req LoginRequest) (*TokenResponse, error)
 
+	// GetLockedAccounts returns the phone numbers that are currently locked
+	GetLockedAccounts(ctx context.Context) ([]*LoginAttempts, error)
+
+	// Unlock clears the failed-login state of a user's phone number
+	Unlock(ctx context.Context, userID int64) error
+
+	// ResetPassword sets a new password for the phone's account and signs out
+	// every session. The caller must have verified a reset code first.
+	ResetPassword(ctx context.Context, phone, newPassword string) error
+
+	// ChangePassword re-checks the current password, sets the new one and signs
+	// out every session except the one identified by tokenID.
+	ChangePassword(ctx context.Context, userID int64, tokenID string, req ChangePasswordRequest) error
+
 	// VerifyToken verifies a JWT token and returns the claims
 	VerifyToken(ctx context.Context, tokenString string) (*AuthClaims, error)
+
+	// GetJWKS returns the public keys that verify issued tokens. The legacy
+	// shared secret is never published.
+	GetJWKS(ctx context.Context) (*JWKSet, error)
 }
@@ -0,0 +1,9 @@
+package campaign
+
+import "errors"
+
+var (
+	ErrCampaignNotFound  = errors.New("campaign not found")
+	ErrInvalidTemplate   = errors.New("campaign template does not exist")
+	ErrInvalidTransition = errors.New("campaign cannot change to the requested status")
+)
@@ -0,0 +1,100 @@
+package campaign
+
+import "time"
+
+// Campaign represents a one-off broadcast of a template to a contact segment
+type Campaign struct {
+	ID                int64      `json:"id"`
+	UserID            int64      `json:"user_id"`
+	Name              string     `json:"name"`
+	TemplateID        int64      `json:"template_id"`
+	Segment           Segment    `json:"segment"`
+	ScheduledAt       time.Time  `json:"scheduled_at"`
+	ThrottlePerMinute int        `json:"throttle_per_minute"`
+	Status            string     `json:"status"`
+	TotalRecipients   int        `json:"total_recipients"`
+	Stats             *Stats     `json:"stats,omitempty"`
+	StartedAt         *time.Time `json:"started_at,omitempty"`
+	CompletedAt       *time.Time `json:"completed_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// Segment selects the contacts a campaign is sent to.
+// Empty fields do not restrict the segment; opted-out contacts are always excluded.
+type Segment struct {
+	Tags                 []string `json:"tags,omitempty" validate:"max=20,dive,required,max=50"`
+	LastCalledWithinDays int      `json:"last_called_within_days,omitempty" validate:"min=0,max=365"`
+	LastCallDirection    string   `json:"last_call_direction,omitempty" validate:"omitempty,oneof=incoming outgoing missed"`
+	ContactFilter        string   `json:"contact_filter,omitempty" validate:"omitempty,oneof=all contacts_only non_contacts_only"`
+}
+
+// CampaignCreate contains data for creating a campaign
+type CampaignCreate struct {
+	Name              string     `json:"name" validate:"required,max=255"`
+	TemplateID        int64      `json:"template_id" validate:"required"`
+	Segment           Segment    `json:"segment"`
+	ScheduledAt       *time.Time `json:"scheduled_at,omitempty"`
+	ThrottlePerMinute int        `json:"throttle_per_minute" validate:"omitempty,min=1,max=60"`
+}
+
+// Stats counts the recipients of a campaign by status
+type Stats struct {
+	Pending    int `json:"pending"`
+	Dispatched int `json:"dispatched"`
+	Sent       int `json:"sent"`
+	Failed     int `json:"failed"`
+	Skipped    int `json:"skipped"`
+}
+
+// Recipient tracks delivery of a campaign to a single phone number
+type Recipient struct {
+	ID           int64      `json:"id"`
+	CampaignID   int64      `json:"campaign_id"`
+	ContactID    *int64     `json:"contact_id,omitempty"`
+	Phone        string     `json:"phone"`
+	Status       string     `json:"status"`
+	Attempts     int        `json:"attempts"`
+	Error        string     `json:"error,omitempty"`
+	DispatchedAt *time.Time `json:"dispatched_at,omitempty"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// Result is the delivery outcome for a single recipient
+type Result struct {
+	RecipientID int64
+	Status      string
+	Error       string
+}
+
+// Campaign status constants
+const (
+	StatusScheduled = "scheduled"
+	StatusRunning   = "running"
+	StatusPaused    = "paused"
+	StatusCompleted = "completed"
+	StatusCancelled = "cancelled"
+)
+
+// Recipient status constants
+const (
+	RecipientPending    = "pending"
+	RecipientDispatched = "dispatched"
+	RecipientSent       = "sent"
+	RecipientFailed     = "failed"
+	RecipientSkipped    = "skipped"
+)
+
+// Contact filter constants mirror rule contact filter modes
+const (
+	ContactFilterAll             = "all"
+	ContactFilterContactsOnly    = "contacts_only"
+	ContactFilterNonContactsOnly = "non_contacts_only"
+)
+
+// Limits
+const (
+	DefaultThrottlePerMinute = 10
+	MaxAttempts              = 3
+)
@@ -0,0 +1,32 @@
+package campaign
+
+import (
+	"context"
+	"time"
+)
+
+// Repository defines the interface for campaign data access
+type Repository interface {
+	GetByUserID(ctx context.Context, userID int64) ([]*Campaign, error)
+	GetByID(ctx context.Context, id int64, userID int64) (*Campaign, error)
+	Create(ctx context.Context, userID int64, data CampaignCreate) (*Campaign, error)
+	GetDueScheduled(ctx context.Context, userID int64) ([]*Campaign, error)
+	GetRunning(ctx context.Context, userID int64) ([]*Campaign, error)
+
+	Start(ctx context.Context, c *Campaign, calledAfter *time.Time) (*Campaign, error)
+	Complete(ctx context.Context, id int64) error
+	Pause(ctx context.Context, id int64, userID int64) (*Campaign, error)
+	Resume(ctx context.Context, id int64, userID int64) (*Campaign, error)
+	Cancel(ctx context.Context, id int64, userID int64) (*Campaign, error)
+
+	GetRecipients(ctx context.Context, campaignID int64, limit int) ([]*Recipient, error)
+	GetStats(ctx context.Context, campaignID int64) (*Stats, error)
+	CountRecentDispatches(ctx context.Context, campaignID int64, since time.Time) (int, error)
+	CountOpenRecipients(ctx context.Context, campaignID int64) (int, error)
+	ClaimRecipients(ctx context.Context, campaignID int64, limit int) ([]*Recipient, error)
+	ReportRecipient(ctx context.Context, userID int64, result Result) (int64, error)
+	SkipOpenRecipients(ctx context.Context, campaignID int64) error
+
+	// DeleteQueuedJobs removes the campaign's device jobs no device has taken yet.
+	DeleteQueuedJobs(ctx context.Context, campaignID int64) error
+}
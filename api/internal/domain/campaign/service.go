@@ -0,0 +1,24 @@
+package campaign
+
+import (
+	"context"
+
+	"callflow/internal/domain/device"
+)
+
+// Service defines the interface for campaign business logic
+type Service interface {
+	Get(ctx context.Context, userID int64) ([]*Campaign, error)
+	GetByID(ctx context.Context, id int64, userID int64) (*Campaign, error)
+	Create(ctx context.Context, userID int64, data CampaignCreate) (*Campaign, error)
+	GetRecipients(ctx context.Context, id int64, userID int64) ([]*Recipient, error)
+
+	Pause(ctx context.Context, id int64, userID int64) (*Campaign, error)
+	Resume(ctx context.Context, id int64, userID int64) (*Campaign, error)
+	Cancel(ctx context.Context, id int64, userID int64) (*Campaign, error)
+
+	// QueueDue starts due campaigns and queues the next messages as device jobs
+	// without exceeding each campaign's throttle. JobFinished records a job's
+	// outcome and completes campaigns with no open recipients.
+	device.JobSource
+}
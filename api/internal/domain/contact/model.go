@@ -4,11 +4,15 @@ import "time"
 
 // Contact represents a recipient of automated messages
 type Contact struct {
-	ID        int64     `json:"id"`
-	UserID    int64     `json:"user_id"`
-	Phone     string    `json:"phone"`
-	Name      string    `json:"name,omitempty"`
-	CreatedAt time.Time `json:"created_at"`
+	ID                int64      `json:"id"`
+	UserID            int64      `json:"user_id"`
+	Phone             string     `json:"phone"`
+	Name              string     `json:"name,omitempty"`
+	LastCalledAt      *time.Time `json:"last_called_at,omitempty"`
+	LastCallDirection string     `json:"last_call_direction,omitempty"`
+	OptedOutAt        *time.Time `json:"opted_out_at,omitempty"`
+	Tags              []string   `json:"tags"`
+	CreatedAt         time.Time  `json:"created_at"`
 }
 
 // ContactUpsert contains data for creating or updating a contact
@@ -21,3 +25,33 @@ type ContactUpsert struct {
 type BatchRequest struct {
 	Contacts []ContactUpsert `json:"contacts" validate:"required,min=1"`
 }
+
+// CallEvent represents a call observed by the device
+type CallEvent struct {
+	Phone      string     `json:"phone" validate:"required"`
+	Name       string     `json:"name,omitempty"`
+	Direction  string     `json:"direction" validate:"required,oneof=incoming outgoing missed"`
+	OccurredAt *time.Time `json:"occurred_at,omitempty"`
+}
+
+// OptOutRequest represents a request to stop messaging a phone number
+type OptOutRequest struct {
+	Phone string `json:"phone" validate:"required"`
+}
+
+// TagsUpdate replaces the tags of a contact
+type TagsUpdate struct {
+	Tags []string `json:"tags" validate:"max=20,dive,required,max=50"`
+}
+
+// Call direction constants
+const (
+	DirectionIncoming = "incoming"
+	DirectionOutgoing = "outgoing"
+	DirectionMissed   = "missed"
+)
+
+// IsOptedOut reports whether the contact asked not to be messaged
+func (c *Contact) IsOptedOut() bool {
+	return c.OptedOutAt != nil
+}
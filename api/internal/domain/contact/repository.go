@@ -5,6 +5,12 @@ import "context"
 // Repository defines the interface for contact data access
 type Repository interface {
 	GetByUserID(ctx context.Context, userID int64) ([]*Contact, error)
-	Upsert(ctx context.Context, userID int64, data ContactUpsert) (*Contact, error)
-	UpsertBatch(ctx context.Context, userID int64, contacts []ContactUpsert) error
+	GetByPhone(ctx context.Context, userID int64, phone string) (*Contact, error)
+	// Upsert, RecordCall and OptOut also report whether they created the contact.
+	Upsert(ctx context.Context, userID int64, data ContactUpsert) (*Contact, bool, error)
+	// UpsertBatch stores the contacts in one statement and returns those it created.
+	UpsertBatch(ctx context.Context, userID int64, contacts []ContactUpsert) ([]*Contact, error)
+	RecordCall(ctx context.Context, userID int64, event CallEvent) (*Contact, bool, error)
+	OptOut(ctx context.Context, userID int64, phone string) (*Contact, bool, error)
+	SetTags(ctx context.Context, id int64, userID int64, tags []string) (*Contact, error)
 }
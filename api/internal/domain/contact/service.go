@@ -7,4 +7,7 @@ type Service interface {
 	Get(ctx context.Context, userID int64) ([]*Contact, error)
 	Upsert(ctx context.Context, userID int64, data ContactUpsert) (*Contact, error)
 	UpsertBatch(ctx context.Context, userID int64, contacts []ContactUpsert) error
+	RecordCall(ctx context.Context, userID int64, event CallEvent) (*Contact, error)
+	OptOut(ctx context.Context, userID int64, phone string) (*Contact, error)
+	SetTags(ctx context.Context, id int64, userID int64, tags []string) (*Contact, error)
 }
@@ -0,0 +1,11 @@
+package device
+
+import "errors"
+
+var (
+	ErrDeviceNotFound  = errors.New("device not found")
+	ErrLineAssigned    = errors.New("line is already assigned to another device")
+	ErrJobNotFound     = errors.New("job not found or lease expired")
+	ErrInvalidPayload  = errors.New("job payload is invalid for its type")
+	ErrInvalidTemplate = errors.New("job template does not exist")
+)
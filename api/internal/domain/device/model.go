@@ -0,0 +1,208 @@
+package device
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Device is a phone logged into a user's account
+type Device struct {
+	ID         int64      `json:"id"`
+	UserID     int64      `json:"user_id"`
+	DeviceID   string     `json:"device_id"`
+	Name       string     `json:"name,omitempty"`
+	Model      string     `json:"model,omitempty"`
+	AppVersion string     `json:"app_version,omitempty"`
+	SIMs       []SIM      `json:"sims"`
+	PushToken  string     `json:"push_token,omitempty"`
+	SMSLine    string     `json:"sms_line,omitempty"`
+	LastSeenAt *time.Time `json:"last_seen_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+
+	Healthy         bool       `json:"healthy"`
+	Health          *Health    `json:"health,omitempty"`
+	LastHeartbeatAt *time.Time `json:"last_heartbeat_at,omitempty"`
+}
+
+// Heartbeat is the periodic status report of the app's foreground service.
+// DeviceID is only needed when the token was issued without a device.
+type Heartbeat struct {
+	DeviceID         string          `json:"device_id,omitempty" validate:"max=100"`
+	BatteryOptimized bool            `json:"battery_optimized"`
+	Permissions      map[string]bool `json:"permissions"`
+	AppVersion       string          `json:"app_version,omitempty" validate:"max=30"`
+	QueueDepth       int             `json:"queue_depth" validate:"min=0"`
+	LastCallAt       *time.Time      `json:"last_call_at,omitempty"`
+}
+
+// Health is the latest heartbeat of a device with the problems found in it
+type Health struct {
+	BatteryOptimized bool            `json:"battery_optimized"`
+	Permissions      map[string]bool `json:"permissions"`
+	AppVersion       string          `json:"app_version,omitempty"`
+	QueueDepth       int             `json:"queue_depth"`
+	LastCallAt       *time.Time      `json:"last_call_at,omitempty"`
+	Problems         []string        `json:"problems"`
+	ReportedAt       time.Time       `json:"reported_at"`
+}
+
+// Alert flags a device that stopped reporting or reported problems
+type Alert struct {
+	ID         int64      `json:"id"`
+	DeviceID   int64      `json:"device_id"`
+	UserID     int64      `json:"user_id"`
+	Kind       string     `json:"kind"`
+	Message    string     `json:"message"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+}
+
+// SIM describes a SIM card slot reported by the device
+type SIM struct {
+	Slot        int    `json:"slot"`
+	Carrier     string `json:"carrier,omitempty"`
+	PhoneNumber string `json:"phone_number,omitempty"`
+}
+
+// Registration contains the device details sent at login.
+// DeviceID is a stable identifier generated by the app on first launch.
+type Registration struct {
+	DeviceID   string `json:"device_id" validate:"required,max=100"`
+	Name       string `json:"name,omitempty" validate:"max=100"`
+	Model      string `json:"model,omitempty" validate:"max=100"`
+	AppVersion string `json:"app_version,omitempty" validate:"max=30"`
+	SIMs       []SIM  `json:"sims,omitempty" validate:"max=4"`
+	PushToken  string `json:"push_token,omitempty"`
+}
+
+// SMSLineUpdate designates a device as the only SMS sender for a line.
+// An empty Line removes the designation.
+type SMSLineUpdate struct {
+	Line string `json:"line" validate:"max=30"`
+}
+
+// SMSAssignment tells a device which lines it sends SMS for.
+// Lines assigned to other devices must not be used by this device.
+type SMSAssignment struct {
+	DeviceID      int64    `json:"device_id,omitempty"`
+	SMSLine       string   `json:"sms_line,omitempty"`
+	AssignedLines []string `json:"assigned_lines"`
+}
+
+// Job is a unit of work the server asks a user's phone to carry out.
+// Source and RefID name the sequence enrollment or campaign recipient a job
+// was queued for; they are empty for jobs queued through the API or by rules.
+type Job struct {
+	ID             int64           `json:"id"`
+	UserID         int64           `json:"user_id"`
+	DeviceID       string          `json:"device_id,omitempty"`
+	Type           string          `json:"type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	MaxAttempts    int             `json:"max_attempts"`
+	RunAfter       time.Time       `json:"run_after"`
+	LeaseToken     string          `json:"lease_token,omitempty"`
+	LeasedBy       string          `json:"leased_by,omitempty"`
+	LeaseExpiresAt *time.Time      `json:"lease_expires_at,omitempty"`
+	LandingURL     string          `json:"landing_url,omitempty"`
+	Source         string          `json:"source,omitempty"`
+	RefID          *int64          `json:"ref_id,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	CompletedAt    *time.Time      `json:"completed_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// SMSPayload is the payload of an sms job.
+// Either TemplateID or Body is set; the device renders templates itself.
+type SMSPayload struct {
+	Phone      string `json:"phone" validate:"required"`
+	TemplateID *int64 `json:"template_id,omitempty"`
+	Body       string `json:"body,omitempty" validate:"max=1600"`
+}
+
+// JobCreate contains data for enqueuing a job.
+// An empty DeviceID lets any of the user's devices take the job. Source and
+// RefID are set by server-side features and cannot be sent by clients.
+type JobCreate struct {
+	DeviceID    string          `json:"device_id,omitempty" validate:"max=100"`
+	Type        string          `json:"type" validate:"required,oneof=sms"`
+	Payload     json.RawMessage `json:"payload" validate:"required"`
+	RunAfter    *time.Time      `json:"run_after,omitempty"`
+	MaxAttempts int             `json:"max_attempts" validate:"omitempty,min=1,max=20"`
+	Source      string          `json:"-"`
+	RefID       *int64          `json:"-"`
+}
+
+// LeaseRequest asks for jobs to work on.
+// Leased jobs reappear in the queue once VisibilitySeconds pass without an ack or fail.
+type LeaseRequest struct {
+	Limit             int `json:"limit" validate:"omitempty,min=1,max=100"`
+	VisibilitySeconds int `json:"visibility_seconds" validate:"omitempty,min=30,max=3600"`
+}
+
+// Lease is a batch of jobs handed to a device under one lease token
+type Lease struct {
+	LeaseToken string    `json:"lease_token"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Jobs       []*Job    `json:"jobs"`
+}
+
+// AckRequest marks a leased job as done
+type AckRequest struct {
+	JobID      int64  `json:"job_id" validate:"required"`
+	LeaseToken string `json:"lease_token" validate:"required"`
+}
+
+// FailRequest reports that a leased job could not be completed.
+// Retryable defaults to true; failed jobs are retried with exponential backoff until MaxAttempts.
+type FailRequest struct {
+	JobID      int64  `json:"job_id" validate:"required"`
+	LeaseToken string `json:"lease_token" validate:"required"`
+	Error      string `json:"error" validate:"required,max=500"`
+	Retryable  *bool  `json:"retryable"`
+}
+
+// Alert kind constants
+const (
+	AlertSilent    = "silent"
+	AlertUnhealthy = "unhealthy"
+)
+
+// Health problem constants
+const (
+	ProblemBatteryOptimized  = "battery_optimization_enabled"
+	ProblemPermissionMissing = "permission_missing"
+	ProblemQueueBacklog      = "queue_backlog"
+)
+
+// Job type constants
+const (
+	JobTypeSMS = "sms"
+)
+
+// Job source constants
+const (
+	JobSourceSequence = "sequence"
+	JobSourceCampaign = "campaign"
+)
+
+// Job status constants
+const (
+	JobStatusQueued = "queued"
+	JobStatusLeased = "leased"
+	JobStatusDone   = "done"
+	JobStatusFailed = "failed"
+)
+
+// Limits
+const (
+	DefaultLeaseLimit        = 10
+	DefaultVisibilitySeconds = 300
+	DefaultMaxAttempts       = 5
+	MaxListedJobs            = 100
+	MaxListedAlerts          = 200
+	QueueBacklogThreshold    = 50
+)
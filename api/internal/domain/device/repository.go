@@ -0,0 +1,32 @@
+package device
+
+import (
+	"context"
+	"time"
+)
+
+// Repository defines the interface for device data access
+type Repository interface {
+	Upsert(ctx context.Context, userID int64, data Registration) (*Device, error)
+	GetByUserID(ctx context.Context, userID int64) ([]*Device, error)
+	GetByID(ctx context.Context, id int64, userID int64) (*Device, error)
+	GetByDeviceID(ctx context.Context, userID int64, deviceID string) (*Device, error)
+	GetAssignedLines(ctx context.Context, userID int64) ([]string, error)
+	SetSMSLine(ctx context.Context, id int64, userID int64, line string) (*Device, error)
+	Revoke(ctx context.Context, id int64, userID int64) (*Device, error)
+
+	RecordHeartbeat(ctx context.Context, id int64, hb Heartbeat, health Health) (*Device, error)
+	OpenAlert(ctx context.Context, d *Device, kind, message string) error
+	ResolveAlert(ctx context.Context, deviceID int64, kind string) error
+	OpenSilentAlerts(ctx context.Context, silentBefore time.Time) (int64, error)
+	DeleteHeartbeatsBefore(ctx context.Context, before time.Time) (int64, error)
+	GetUnhealthy(ctx context.Context, silentBefore time.Time) ([]*Device, error)
+	GetOpenAlerts(ctx context.Context, limit int) ([]*Alert, error)
+
+	CreateJob(ctx context.Context, userID int64, data JobCreate) (*Job, error)
+	GetJobs(ctx context.Context, userID int64, limit int) ([]*Job, error)
+	FailExhaustedJobs(ctx context.Context, userID int64) ([]*Job, error)
+	LeaseJobs(ctx context.Context, userID int64, deviceID, leaseToken string, expiresAt time.Time, limit int) ([]*Job, error)
+	AckJob(ctx context.Context, userID int64, jobID int64, leaseToken string) (*Job, error)
+	FailJob(ctx context.Context, userID int64, data FailRequest) (*Job, error)
+}
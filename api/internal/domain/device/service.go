@@ -0,0 +1,48 @@
+package device
+
+import "context"
+
+// Service defines the interface for device business logic
+type Service interface {
+	// Get, Revoke and SetSMSLine cover the account's devices, which for an
+	// organization owner include the devices registered to its members.
+	Get(ctx context.Context, userID int64) ([]*Device, error)
+	Revoke(ctx context.Context, id int64, userID int64) error
+
+	// SetSMSLine makes the device the only SMS sender for the line, taking the
+	// line away from any other device of the account.
+	SetSMSLine(ctx context.Context, id int64, userID int64, line string) (*Device, error)
+
+	// GetSMSAssignment returns the SMS lines assigned across the account's
+	// devices and the line of the calling device, which is registered to actorID.
+	GetSMSAssignment(ctx context.Context, userID, actorID, deviceID int64) (*SMSAssignment, error)
+
+	// Heartbeat stores a status report and opens or resolves the device's alerts.
+	// userID is the user the device is registered to.
+	Heartbeat(ctx context.Context, userID int64, deviceID int64, hb Heartbeat) (*Device, error)
+
+	// GetUnhealthy returns devices that reported problems or went silent, across all users.
+	GetUnhealthy(ctx context.Context) ([]*Device, error)
+	GetOpenAlerts(ctx context.Context) ([]*Alert, error)
+
+	// Enqueue adds a job for the user's phone. Server-side features use it to
+	// send messages through the user's SIM.
+	Enqueue(ctx context.Context, userID int64, data JobCreate) (*Job, error)
+	GetJobs(ctx context.Context, userID int64) ([]*Job, error)
+
+	// Lease hands out the account's ready jobs, including jobs whose previous
+	// lease expired, to the calling device, which is registered to actorID.
+	Lease(ctx context.Context, userID, actorID, deviceID int64, req LeaseRequest) (*Lease, error)
+	Ack(ctx context.Context, userID int64, req AckRequest) (*Job, error)
+	Fail(ctx context.Context, userID int64, req FailRequest) (*Job, error)
+}
+
+// JobSource is a server-side feature that sends its messages as jobs. Lease
+// lets every source queue what it has due first, and a source is told about
+// its jobs once they are done or have failed for good.
+type JobSource interface {
+	// JobSource returns the Source its jobs are queued with.
+	JobSource() string
+	QueueDue(ctx context.Context, userID int64, limit int) error
+	JobFinished(ctx context.Context, job *Job) error
+}
@@ -3,8 +3,17 @@ package landing
 import "errors"
 
 var (
-	ErrLandingNotFound = errors.New("landing page not found")
-	ErrInvalidImageURL = errors.New("image_url must be a valid https URL")
-	ErrMissingImageKey = errors.New("image_key is required when image_url is set")
-	ErrUploadDisabled  = errors.New("image upload is not configured")
+	ErrLandingNotFound  = errors.New("landing page not found")
+	ErrInvalidImageURL  = errors.New("image_url must be a valid https URL")
+	ErrMissingImageKey  = errors.New("image_key is required when image_url is set")
+	ErrUploadDisabled   = errors.New("image upload is not configured")
+	ErrSlugNotFound     = errors.New("landing slug not found")
+	ErrInvalidSlug      = errors.New("slug must be 3-40 lowercase letters, digits or hyphens and contain a letter")
+	ErrSlugReserved     = errors.New("slug is reserved")
+	ErrSlugTaken        = errors.New("slug is already taken")
+	ErrInvalidEvent     = errors.New("click events need a target")
+	ErrInvalidRange     = errors.New("date range must be YYYY-MM-DD dates at most 366 days apart")
+	ErrInvalidSection   = errors.New("invalid landing section")
+	ErrDraftNotFound    = errors.New("landing draft not found")
+	ErrRevisionNotFound = errors.New("landing revision not found")
 )
@@ -1,42 +1,285 @@
 package landing
 
-import "time"
+import (
+	"time"
+
+	"callflow/internal/domain/media"
+)
 
 // Landing represents a user's public landing page content
-// ImageKey is stored but not exposed in JSON responses.
+// ImageKey is stored but not exposed in JSON responses. The hero fields are
+// followed by Sections in display order; SectionsVersion is the schema version
+// they were stored with.
 type Landing struct {
-	ID           int64     `json:"id"`
-	UserID       int64     `json:"user_id"`
-	Headline     *string   `json:"headline,omitempty"`
-	Description  *string   `json:"description,omitempty"`
-	ImageURL     *string   `json:"image_url,omitempty"`
-	ImageKey     *string   `json:"-"`
-	WhatsappURL  *string   `json:"whatsapp_url,omitempty"`
-	FacebookURL  *string   `json:"facebook_url,omitempty"`
-	InstagramURL *string   `json:"instagram_url,omitempty"`
-	YoutubeURL   *string   `json:"youtube_url,omitempty"`
-	Email        *string   `json:"email,omitempty"`
-	WebsiteURL   *string   `json:"website_url,omitempty"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID              int64      `json:"id"`
+	UserID          int64      `json:"user_id"`
+	Headline        *string    `json:"headline,omitempty"`
+	Description     *string    `json:"description,omitempty"`
+	ImageURL        *string    `json:"image_url,omitempty"`
+	ImageKey        *string    `json:"-"`
+	MediaID         *int64     `json:"media_id,omitempty"`
+	WhatsappURL     *string    `json:"whatsapp_url,omitempty"`
+	FacebookURL     *string    `json:"facebook_url,omitempty"`
+	InstagramURL    *string    `json:"instagram_url,omitempty"`
+	YoutubeURL      *string    `json:"youtube_url,omitempty"`
+	Email           *string    `json:"email,omitempty"`
+	WebsiteURL      *string    `json:"website_url,omitempty"`
+	Sections        []*Section `json:"sections"`
+	SectionsVersion int        `json:"sections_version"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
 }
 
 // LandingUpsert contains data for creating or updating landing content.
+// MediaID picks an image from the media library in place of ImageURL and
+// ImageKey. Sections replace the page's sections in the order given; when
+// omitted, the current sections are kept.
 type LandingUpsert struct {
-	Headline     *string `json:"headline,omitempty"`
-	Description  *string `json:"description,omitempty"`
-	ImageURL     *string `json:"image_url,omitempty"`
-	ImageKey     *string `json:"image_key,omitempty"`
-	WhatsappURL  *string `json:"whatsapp_url,omitempty"`
-	FacebookURL  *string `json:"facebook_url,omitempty"`
-	InstagramURL *string `json:"instagram_url,omitempty"`
-	YoutubeURL   *string `json:"youtube_url,omitempty"`
-	Email        *string `json:"email,omitempty"`
-	WebsiteURL   *string `json:"website_url,omitempty"`
+	Headline     *string     `json:"headline,omitempty"`
+	Description  *string     `json:"description,omitempty"`
+	ImageURL     *string     `json:"image_url,omitempty"`
+	ImageKey     *string     `json:"image_key,omitempty"`
+	MediaID      *int64      `json:"media_id,omitempty" validate:"omitempty,gt=0"`
+	WhatsappURL  *string     `json:"whatsapp_url,omitempty"`
+	FacebookURL  *string     `json:"facebook_url,omitempty"`
+	InstagramURL *string     `json:"instagram_url,omitempty"`
+	YoutubeURL   *string     `json:"youtube_url,omitempty"`
+	Email        *string     `json:"email,omitempty"`
+	WebsiteURL   *string     `json:"website_url,omitempty"`
+	Sections     *[]*Section `json:"sections,omitempty" validate:"omitempty,max=20,dive,required"`
+}
+
+// Section is a block of structured content on the landing page. Type decides
+// which one of Images, Services, Hours, Offer or FAQs it carries. ID
+// identifies the section across edits and is generated when empty.
+type Section struct {
+	ID       string          `json:"id" validate:"omitempty,max=32,alphanum"`
+	Type     string          `json:"type" validate:"required,oneof=gallery services hours offer faq"`
+	Title    string          `json:"title,omitempty" validate:"max=100"`
+	Hidden   bool            `json:"hidden,omitempty"`
+	Images   []*GalleryImage `json:"images,omitempty" validate:"max=12,dive,required"`
+	Services []*ServiceItem  `json:"services,omitempty" validate:"max=50,dive,required"`
+	Hours    []*OpeningHours `json:"hours,omitempty" validate:"max=7,dive,required"`
+	Offer    *Offer          `json:"offer,omitempty"`
+	FAQs     []*FAQ          `json:"faqs,omitempty" validate:"max=30,dive,required"`
+}
+
+// GalleryImage is a media library image. URL and ThumbnailURL are filled in
+// when the page is read, so moved images keep working.
+type GalleryImage struct {
+	MediaID      int64   `json:"media_id" validate:"required,gt=0"`
+	Caption      string  `json:"caption,omitempty" validate:"max=200"`
+	URL          string  `json:"url,omitempty"`
+	ThumbnailURL *string `json:"thumbnail_url,omitempty"`
+}
+
+// ServiceItem is an entry of a services and price list. Price is shown as
+// written, currency included.
+type ServiceItem struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description,omitempty" validate:"max=500"`
+	Price       string `json:"price,omitempty" validate:"max=30"`
+}
+
+// OpeningHours are the hours of one weekday, as HH:MM in the business's
+// local time. Days that are not listed are shown as unknown.
+type OpeningHours struct {
+	Day    string `json:"day" validate:"required,oneof=mon tue wed thu fri sat sun"`
+	Closed bool   `json:"closed,omitempty"`
+	Open   string `json:"open,omitempty"`
+	Close  string `json:"close,omitempty"`
+}
+
+// Offer is a promotion shown until ExpiresAt
+type Offer struct {
+	Title       string     `json:"title" validate:"required,max=100"`
+	Description string     `json:"description,omitempty" validate:"max=500"`
+	Code        string     `json:"code,omitempty" validate:"max=30"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
 }
 
+// FAQ is a question and answer pair
+type FAQ struct {
+	Question string `json:"question" validate:"required,max=200"`
+	Answer   string `json:"answer" validate:"required,max=1000"`
+}
+
+// Section type constants
+const (
+	SectionGallery  = "gallery"
+	SectionServices = "services"
+	SectionHours    = "hours"
+	SectionOffer    = "offer"
+	SectionFAQ      = "faq"
+)
+
+// SectionsVersion is the current schema version of stored sections. Rows
+// written with an older version are upgraded when read.
+const SectionsVersion = 1
+
+// Draft is an unpublished version of the landing page. Anyone with PreviewURL
+// can view it; the live page is unchanged until the draft is published.
+type Draft struct {
+	*Landing
+	PreviewToken string `json:"-"`
+	PreviewURL   string `json:"preview_url"`
+}
+
+// Revision is a copy of the landing page as it was published. Landing is left
+// out when revisions are listed.
+type Revision struct {
+	ID          int64     `json:"id"`
+	Number      int       `json:"number"`
+	Headline    *string   `json:"headline,omitempty"`
+	PublishedAt time.Time `json:"published_at"`
+	Landing     *Landing  `json:"landing,omitempty"`
+}
+
+// Revision limits. Publishing prunes revisions beyond MaxRevisions.
+const (
+	MaxRevisions = 50
+	// PreviewTokenBytes gives 32 hex characters
+	PreviewTokenBytes = 16
+)
+
 // UploadedImage represents an uploaded landing image.
 type UploadedImage struct {
-	URL string `json:"image_url"`
-	Key string `json:"image_key"`
+	MediaID  int64            `json:"media_id"`
+	URL      string           `json:"image_url"`
+	Key      string           `json:"image_key"`
+	Variants []*media.Variant `json:"variants"`
+}
+
+// Slug is a public landing page address. A user has one current slug; slugs
+// they used before stay reserved to them and redirect to the current one.
+type Slug struct {
+	Slug      string    `json:"slug"`
+	UserID    int64     `json:"-"`
+	IsCurrent bool      `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
 }
+
+// SlugUpdate contains a new slug chosen by the user.
+type SlugUpdate struct {
+	Slug string `json:"slug"`
+}
+
+// Slug limits. Slugs are lowercase letters, digits and single hyphens, and
+// must contain a letter so they never collide with legacy numeric user IDs.
+const (
+	SlugMinLength = 3
+	SlugMaxLength = 40
+)
+
+// ReservedSlugs cannot be chosen because they name routes of the web app or
+// could be mistaken for the service itself.
+var ReservedSlugs = map[string]struct{}{
+	"admin":    {},
+	"api":      {},
+	"app":      {},
+	"callflow": {},
+	"help":     {},
+	"images":   {},
+	"landing":  {},
+	"login":    {},
+	"logout":   {},
+	"public":   {},
+	"register": {},
+	"s":        {},
+	"settings": {},
+	"signup":   {},
+	"static":   {},
+	"support":  {},
+	"www":      {},
+}
+
+// EventCreate is a page view or button click reported by the public landing
+// page. Token is the attribution token from the link that was opened.
+type EventCreate struct {
+	Type   string `json:"type" validate:"required,oneof=view click"`
+	Target string `json:"target,omitempty" validate:"omitempty,oneof=whatsapp call map facebook instagram youtube email website"`
+	Token  string `json:"token,omitempty" validate:"max=32"`
+}
+
+// Link is the landing URL carried by one message. Visits through it are
+// attributed to that message.
+type Link struct {
+	ID           int64      `json:"id"`
+	Token        string     `json:"token"`
+	URL          string     `json:"url"`
+	Source       string     `json:"source"`
+	RefID        *int64     `json:"ref_id,omitempty"`
+	Phone        *string    `json:"phone,omitempty"`
+	Views        int        `json:"views"`
+	Clicks       int        `json:"clicks"`
+	FirstVisitAt *time.Time `json:"first_visit_at,omitempty"`
+	LastVisitAt  *time.Time `json:"last_visit_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// LinkCreate describes the message a link is issued for. Links with a RefID
+// are issued once per message.
+type LinkCreate struct {
+	Source string  `json:"source" validate:"omitempty,oneof=call manual"`
+	RefID  *int64  `json:"-"`
+	Phone  *string `json:"phone,omitempty" validate:"omitempty,max=30"`
+}
+
+// DailyStat counts one kind of event on one UTC day
+type DailyStat struct {
+	Day        time.Time
+	Event      string
+	Target     string
+	Attributed bool
+	Count      int
+}
+
+// Stats sums landing events. Attributed counts come from links carried by
+// messages.
+type Stats struct {
+	Views            int            `json:"views"`
+	Clicks           int            `json:"clicks"`
+	AttributedViews  int            `json:"attributed_views"`
+	AttributedClicks int            `json:"attributed_clicks"`
+	ClicksByTarget   map[string]int `json:"clicks_by_target"`
+}
+
+// DayStats are the stats of one UTC day
+type DayStats struct {
+	Date string `json:"date"`
+	Stats
+}
+
+// Analytics summarizes a landing page over a date range, with the most
+// recently visited message links.
+type Analytics struct {
+	From   string      `json:"from"`
+	To     string      `json:"to"`
+	Totals Stats       `json:"totals"`
+	Days   []*DayStats `json:"days"`
+	Links  []*Link     `json:"links"`
+}
+
+// Event type constants
+const (
+	EventView  = "view"
+	EventClick = "click"
+)
+
+// Link source constants. Job and campaign links are issued when a message is
+// handed to the device; call and manual links are requested by the client.
+const (
+	LinkSourceJob      = "job"
+	LinkSourceCampaign = "campaign"
+	LinkSourceCall     = "call"
+	LinkSourceManual   = "manual"
+)
+
+// Analytics limits
+const (
+	DefaultAnalyticsDays = 30
+	MaxAnalyticsDays     = 366
+	MaxAnalyticsLinks    = 50
+	// LinkTokenBytes gives 10 hex characters, short enough for SMS
+	LinkTokenBytes = 5
+)
@@ -1,9 +1,42 @@
 package landing
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Repository defines the interface for landing data access.
 type Repository interface {
 	GetByUserID(ctx context.Context, userID int64) (*Landing, error)
-	UpsertByUserID(ctx context.Context, userID int64, data LandingUpsert) (*Landing, error)
+	// GetWithImages returns the landing pages of all users that have a stored image
+	GetWithImages(ctx context.Context) ([]*Landing, error)
+	// ReplaceImage swaps the stored image of a landing page, provided it still has oldKey
+	ReplaceImage(ctx context.Context, userID int64, oldKey, imageURL, imageKey string) error
+
+	GetDraft(ctx context.Context, userID int64) (*Draft, error)
+	// SaveDraft creates or replaces the user's draft. previewToken is only used
+	// for a new draft; an existing one keeps its token.
+	SaveDraft(ctx context.Context, userID int64, previewToken string, data LandingUpsert) (*Draft, error)
+	SetPreviewToken(ctx context.Context, userID int64, previewToken string) (*Draft, error)
+	DeleteDraft(ctx context.Context, userID int64) error
+	// PublishDraft makes data the live page, records it as a revision and
+	// deletes the draft in the same transaction.
+	PublishDraft(ctx context.Context, userID int64, data LandingUpsert) (*Landing, error)
+	GetRevisions(ctx context.Context, userID int64, limit int) ([]*Revision, error)
+	GetRevision(ctx context.Context, id, userID int64) (*Revision, error)
+
+	GetSlug(ctx context.Context, slug string) (*Slug, error)
+	GetCurrentSlug(ctx context.Context, userID int64) (*Slug, error)
+	// SetCurrentSlug makes slug the user's current slug, keeping the previous
+	// one as history. It fails with ErrSlugTaken when another user owns it.
+	SetCurrentSlug(ctx context.Context, userID int64, slug string) (*Slug, error)
+
+	// CreateLink issues a link, or returns the one already issued for the
+	// same source and RefID.
+	CreateLink(ctx context.Context, userID int64, token string, data LinkCreate) (*Link, error)
+	// RecordEvent counts an event for today and, when token names one of the
+	// user's links, on that link. It reports whether the event was attributed.
+	RecordEvent(ctx context.Context, userID int64, event, target, token string) (bool, error)
+	GetDailyStats(ctx context.Context, userID int64, from, to time.Time) ([]*DailyStat, error)
+	GetVisitedLinks(ctx context.Context, userID int64, since time.Time, limit int) ([]*Link, error)
 }
@@ -1,10 +1,47 @@
 package landing
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Service defines the interface for landing business logic.
 type Service interface {
 	GetByUserID(ctx context.Context, userID int64) (*Landing, error)
-	UpsertByUserID(ctx context.Context, userID int64, data LandingUpsert) (*Landing, error)
+
+	GetDraft(ctx context.Context, userID int64) (*Draft, error)
+	// SaveDraft applies data to the draft, starting one from the live page when
+	// there is none. Omitted sections are kept.
+	SaveDraft(ctx context.Context, userID int64, data LandingUpsert) (*Draft, error)
+	DiscardDraft(ctx context.Context, userID int64) error
+	// Publish makes the draft the live page and records a revision
+	Publish(ctx context.Context, userID int64) (*Landing, error)
+	// RotatePreviewToken replaces the draft's preview link, revoking the old one
+	RotatePreviewToken(ctx context.Context, userID int64) (*Draft, error)
+	// GetPreview returns the draft of the user whose preview token matches
+	GetPreview(ctx context.Context, userID int64, token string) (*Landing, error)
+	GetRevisions(ctx context.Context, userID int64) ([]*Revision, error)
+	GetRevision(ctx context.Context, id, userID int64) (*Revision, error)
+	// RestoreRevision copies a revision into the draft, dropping images that
+	// have since left the media library. It does not publish it.
+	RestoreRevision(ctx context.Context, id, userID int64) (*Draft, error)
+
 	UploadImage(ctx context.Context, userID int64, filename, contentType string, file []byte) (*UploadedImage, error)
+	// GetSlug returns the user's current slug, creating one from the business
+	// name when the user has none yet.
+	GetSlug(ctx context.Context, userID int64) (*Slug, error)
+	UpdateSlug(ctx context.Context, userID int64, slug string) (*Slug, error)
+	// ResolveSlug finds the user behind a public landing address, which is a
+	// current or former slug or a legacy numeric user ID, and returns the
+	// current slug to redirect to.
+	ResolveSlug(ctx context.Context, ref string) (userID int64, current string, err error)
+	// URL returns the public landing page address for a slug
+	URL(slug string) string
+
+	// TrackEvent records a view or click on the landing page at ref
+	TrackEvent(ctx context.Context, ref string, data EventCreate) error
+	// CreateLink issues the landing URL, with its attribution token, for a message
+	CreateLink(ctx context.Context, userID int64, data LinkCreate) (*Link, error)
+	// GetAnalytics returns daily stats between from and to, inclusive
+	GetAnalytics(ctx context.Context, userID int64, from, to time.Time) (*Analytics, error)
 }
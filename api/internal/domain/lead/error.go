@@ -0,0 +1,9 @@
+package lead
+
+import "errors"
+
+var (
+	ErrEndpointNotFound = errors.New("lead endpoint not found")
+	ErrInvalidSignature = errors.New("invalid lead signature")
+	ErrInvalidPhone     = errors.New("invalid phone number")
+)
@@ -0,0 +1,73 @@
+package lead
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Lead is a prospect received from an external source such as a lead form
+type Lead struct {
+	ID        int64           `json:"id"`
+	UserID    int64           `json:"user_id"`
+	ContactID *int64          `json:"contact_id,omitempty"`
+	Phone     string          `json:"phone"`
+	Name      string          `json:"name,omitempty"`
+	Source    string          `json:"source"`
+	Fields    json.RawMessage `json:"fields"`
+	JobID     *int64          `json:"job_id,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// LeadCreate is the body accepted by the inbound lead endpoint
+type LeadCreate struct {
+	Phone  string         `json:"phone" validate:"required,max=20"`
+	Name   string         `json:"name,omitempty" validate:"max=255"`
+	Source string         `json:"source,omitempty" validate:"max=100"`
+	Fields map[string]any `json:"fields,omitempty"`
+}
+
+// Enquiry is the form a visitor submits on the public landing page. Website is
+// a honeypot: it is hidden from people, so a filled value marks a bot.
+type Enquiry struct {
+	Name    string `json:"name" validate:"required,max=255"`
+	Phone   string `json:"phone" validate:"required,max=20"`
+	Message string `json:"message,omitempty" validate:"max=1000"`
+	Website string `json:"website,omitempty"`
+}
+
+// SourceLanding is the source of leads submitted through the landing page
+const SourceLanding = "landing"
+
+// Endpoint is a user's inbound lead endpoint. Requests to it are signed with Secret.
+type Endpoint struct {
+	UserID    int64     `json:"user_id"`
+	Token     string    `json:"token"`
+	Secret    string    `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CreatedEndpoint is returned once when an endpoint is created or rotated
+type CreatedEndpoint struct {
+	*Endpoint
+	Secret string `json:"secret"`
+}
+
+// SignatureHeader carries "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<t>.<body>">",
+// the same scheme outbound webhooks use.
+const SignatureHeader = "X-CallFlow-Signature"
+
+// SignatureTolerance is how far a signature timestamp may be from now
+const SignatureTolerance = 5 * time.Minute
+
+// Enquiry auto-replies are limited per landing page and per recipient, so
+// strangers cannot make an account's SIM text arbitrary numbers.
+const (
+	MaxEnquiryRepliesPerDay = 50
+	EnquiryReplyInterval    = 24 * time.Hour
+)
+
+// MaxListedLeads caps the leads returned by the list endpoint
+const MaxListedLeads = 200
+
+// MaxBodyBytes caps the size of an inbound lead request
+const MaxBodyBytes = 64 << 10
@@ -0,0 +1,25 @@
+package lead
+
+import (
+	"context"
+	"time"
+)
+
+// Repository defines the interface for lead data access
+type Repository interface {
+	// SaveEndpoint creates the user's endpoint or replaces its token and secret.
+	SaveEndpoint(ctx context.Context, userID int64, token, secret string) (*Endpoint, error)
+	GetEndpoint(ctx context.Context, userID int64) (*Endpoint, error)
+	GetEndpointByToken(ctx context.Context, token string) (*Endpoint, error)
+	DeleteEndpoint(ctx context.Context, userID int64) error
+
+	Create(ctx context.Context, userID int64, contactID int64, data LeadCreate) (*Lead, error)
+	SetJob(ctx context.Context, id, jobID int64) error
+	// HasRecentReply reports whether a lead of the contact from the source got
+	// a follow-up since the given time.
+	HasRecentReply(ctx context.Context, userID, contactID int64, source string, since time.Time) (bool, error)
+	// ReserveEnquiryReply counts an enquiry auto-reply against today's cap and
+	// returns false, counting nothing, once the cap is reached.
+	ReserveEnquiryReply(ctx context.Context, userID int64, maxPerDay int) (bool, error)
+	GetByUserID(ctx context.Context, userID int64, limit int) ([]*Lead, error)
+}
@@ -0,0 +1,22 @@
+package lead
+
+import "context"
+
+// Service defines the interface for lead business logic
+type Service interface {
+	GetEndpoint(ctx context.Context, userID int64) (*Endpoint, error)
+	RotateEndpoint(ctx context.Context, userID int64) (*CreatedEndpoint, error)
+	DeleteEndpoint(ctx context.Context, userID int64) error
+	Get(ctx context.Context, userID int64) ([]*Lead, error)
+
+	// Authenticate verifies a signed request to the endpoint with the given
+	// token and returns the user it belongs to.
+	Authenticate(ctx context.Context, token, signature string, body []byte) (int64, error)
+	// Receive stores a lead, upserts its contact and, when the rules have a
+	// lead template, queues the follow-up SMS.
+	Receive(ctx context.Context, userID int64, data LeadCreate) (*Lead, error)
+	// ReceiveEnquiry stores a landing page enquiry as a lead and, when the
+	// rules switch on enquiry auto-replies, queues the auto-reply SMS within
+	// the per-recipient and daily limits.
+	ReceiveEnquiry(ctx context.Context, userID int64, data Enquiry) (*Lead, error)
+}
@@ -0,0 +1,11 @@
+package media
+
+import "errors"
+
+var (
+	ErrInvalidImage   = errors.New("image could not be decoded")
+	ErrImageTooLarge  = errors.New("image dimensions are too large")
+	ErrMediaNotFound  = errors.New("media not found")
+	ErrMediaInUse     = errors.New("media is used by a template or landing page")
+	ErrUploadDisabled = errors.New("image upload is not configured")
+)
@@ -0,0 +1,84 @@
+package media
+
+import "time"
+
+// Media tracks an uploaded image until its objects are deleted from the image
+// store. UserID is nil once the owner's account has been purged.
+type Media struct {
+	ID                int64      `json:"id"`
+	UserID            *int64     `json:"user_id,omitempty"`
+	ImageKey          string     `json:"image_key"`
+	URL               string     `json:"url"`
+	Status            string     `json:"status"`
+	UnreferencedSince *time.Time `json:"unreferenced_since,omitempty"`
+	DeleteAttempts    int        `json:"delete_attempts"`
+	NextAttemptAt     *time.Time `json:"next_attempt_at,omitempty"`
+	LastError         *string    `json:"last_error,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// Media status constants. Uploads start unattached and become attached once a
+// template or landing page references them. Uploads never attached within the
+// grace period, and media deleted by their owner, are deleted.
+const (
+	StatusUnattached = "unattached"
+	StatusAttached   = "attached"
+	StatusDeleting   = "deleting"
+)
+
+// Variant is one rendition of an uploaded image. Every upload has an
+// "original" variant whose Key is the image key itself.
+type Variant struct {
+	ID          int64     `json:"-"`
+	ImageKey    string    `json:"-"`
+	Name        string    `json:"variant"`
+	Key         string    `json:"key"`
+	URL         string    `json:"url"`
+	ContentType string    `json:"content_type"`
+	Width       int       `json:"width"`
+	Height      int       `json:"height"`
+	SizeBytes   int       `json:"size_bytes"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Item is an image in a user's media library. UsageCount is the number of
+// templates and landing pages that reference it.
+type Item struct {
+	ID           int64     `json:"id"`
+	URL          string    `json:"url"`
+	ThumbnailURL *string   `json:"thumbnail_url,omitempty"`
+	ContentType  string    `json:"content_type,omitempty"`
+	Width        int       `json:"width"`
+	Height       int       `json:"height"`
+	SizeBytes    int       `json:"size_bytes"`
+	UsageCount   int       `json:"usage_count"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Upload is a processed and stored image with its variants
+type Upload struct {
+	ID       int64      `json:"media_id"`
+	URL      string     `json:"url"`
+	Key      string     `json:"key"`
+	Variants []*Variant `json:"variants"`
+}
+
+// Variant names
+const (
+	VariantOriginal  = "original"
+	VariantThumbnail = "thumbnail"
+	VariantMMS       = "mms"
+)
+
+// Processing limits. Uploads are re-encoded, which drops EXIF and GPS
+// metadata, after being rotated upright and fit within MaxDimension.
+const (
+	MaxDimension       = 2048
+	ThumbnailDimension = 320
+	MMSDimension       = 1024
+	// MMSMaxBytes keeps the MMS variant under common carrier limits
+	MMSMaxBytes = 300 * 1024
+	// MaxPixels rejects images that would take too much memory to decode
+	MaxPixels = 50_000_000
+)
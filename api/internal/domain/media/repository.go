@@ -0,0 +1,37 @@
+package media
+
+import (
+	"context"
+	"time"
+)
+
+// Repository defines the interface for media data access
+type Repository interface {
+	CreateVariant(ctx context.Context, v Variant) (*Variant, error)
+	GetVariants(ctx context.Context, imageKey string) ([]*Variant, error)
+	DeleteVariants(ctx context.Context, imageKey string) error
+
+	Create(ctx context.Context, userID int64, imageKey, url string) (*Media, error)
+	GetByID(ctx context.Context, id, userID int64) (*Media, error)
+	GetByImageKey(ctx context.Context, userID int64, imageKey string) (*Media, error)
+	// ListItems returns a user's library, newest first, with dimensions and usage
+	ListItems(ctx context.Context, userID int64) ([]*Item, error)
+	GetItem(ctx context.Context, id, userID int64) (*Item, error)
+	// MarkDeleting queues an image for deletion, tracking it if it was not yet
+	MarkDeleting(ctx context.Context, imageKey string) error
+	MarkUserDeleting(ctx context.Context, userID int64) error
+	// Rename points a tracked image at the key it was moved to
+	Rename(ctx context.Context, imageKey, newImageKey, url string) error
+
+	// RefreshReferences marks media attached or unattached from the templates
+	// and landing pages that use them, and returns how many changed.
+	RefreshReferences(ctx context.Context) (attached, unattached int64, err error)
+	// ExpireUnattached queues media unreferenced since before the given time for deletion
+	ExpireUnattached(ctx context.Context, before time.Time) (int64, error)
+	// ClaimDeleting locks unreferenced media due for deletion until lockedUntil
+	// and counts the attempt.
+	ClaimDeleting(ctx context.Context, lockedUntil time.Time, limit int) ([]*Media, error)
+	Delete(ctx context.Context, id int64) error
+	// FailDeletion schedules another attempt with exponential backoff
+	FailDeletion(ctx context.Context, id int64, lastError string) error
+}
@@ -0,0 +1,20 @@
+package media
+
+import "context"
+
+// Service defines the interface for media business logic
+type Service interface {
+	// Upload processes an image and stores it with its variants
+	Upload(ctx context.Context, userID int64, filename, contentType string, file []byte) (*Upload, error)
+	List(ctx context.Context, userID int64) ([]*Item, error)
+	Get(ctx context.Context, id, userID int64) (*Item, error)
+	// Delete queues a library image for deletion; it fails with ErrMediaInUse
+	// while a template or landing page references it.
+	Delete(ctx context.Context, id, userID int64) error
+	// Resolve finds the library image a template or landing page points at,
+	// by ID or else by image key. It returns nil when neither is given or the
+	// key is not tracked.
+	Resolve(ctx context.Context, userID int64, mediaID *int64, imageKey *string) (*Media, error)
+	// ReleaseAll queues every image of a user for deletion
+	ReleaseAll(ctx context.Context, userID int64) error
+}
@@ -0,0 +1,14 @@
+package organization
+
+import "errors"
+
+var (
+	ErrOrganizationNotFound = errors.New("organization not found")
+	ErrNotMember            = errors.New("not a member of this organization")
+	ErrMemberNotFound       = errors.New("member not found")
+	ErrInvitationNotFound   = errors.New("invitation not found")
+	ErrInvitationPending    = errors.New("phone number already has a pending invitation")
+	ErrAlreadyMember        = errors.New("user is already a member")
+	ErrCannotInviteSelf     = errors.New("cannot invite your own phone number")
+	ErrOwnerCannotLeave     = errors.New("the owner cannot leave the organization")
+)
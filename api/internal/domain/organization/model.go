@@ -0,0 +1,96 @@
+package organization
+
+import "time"
+
+// Organization is a business account owned by one user. Its templates, rules,
+// contacts and landing page are the owner's; members act on them by role.
+type Organization struct {
+	ID        int64     `json:"id"`
+	OwnerID   int64     `json:"owner_id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// OrganizationUpdate contains data for renaming an organization
+type OrganizationUpdate struct {
+	Name string `json:"name" validate:"required,max=255"`
+}
+
+// Member is a user's membership in an organization
+type Member struct {
+	OrganizationID int64     `json:"organization_id"`
+	UserID         int64     `json:"user_id"`
+	Phone          string    `json:"phone,omitempty"`
+	Name           string    `json:"name,omitempty"`
+	Role           string    `json:"role"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// Membership is an organization the user belongs to, with the user's role in it
+type Membership struct {
+	Organization *Organization `json:"organization"`
+	Role         string        `json:"role"`
+}
+
+// RoleUpdate changes a member's role
+type RoleUpdate struct {
+	Role string `json:"role" validate:"required,oneof=manager viewer"`
+}
+
+// Invitation invites a phone number to join an organization
+type Invitation struct {
+	ID             int64      `json:"id"`
+	OrganizationID int64      `json:"organization_id"`
+	Phone          string     `json:"phone"`
+	Role           string     `json:"role"`
+	InvitedBy      int64      `json:"invited_by"`
+	Status         string     `json:"status"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	RespondedAt    *time.Time `json:"responded_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// InvitationCreate contains data for inviting a phone number
+type InvitationCreate struct {
+	Phone string `json:"phone" validate:"required"`
+	Role  string `json:"role" validate:"required,oneof=manager viewer"`
+}
+
+// ReceivedInvitation is a pending invitation addressed to the user's phone
+type ReceivedInvitation struct {
+	*Invitation
+	OrganizationName string `json:"organization_name"`
+}
+
+// Access is the outcome of resolving a user's access to an organization
+type Access struct {
+	OrganizationID int64
+	OwnerID        int64
+	OwnerPlan      string
+	Role           string
+}
+
+// Role constants
+const (
+	RoleOwner   = "owner"
+	RoleManager = "manager"
+	RoleViewer  = "viewer"
+)
+
+// Invitation status constants
+const (
+	InvitationPending  = "pending"
+	InvitationAccepted = "accepted"
+	InvitationDeclined = "declined"
+	InvitationRevoked  = "revoked"
+	InvitationExpired  = "expired"
+)
+
+// InvitationTTL is how long an invitation can be accepted
+const InvitationTTL = 7 * 24 * time.Hour
+
+// CanWrite reports whether the role may change the organization's data
+func CanWrite(role string) bool {
+	return role == RoleOwner || role == RoleManager
+}
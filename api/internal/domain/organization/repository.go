@@ -0,0 +1,30 @@
+package organization
+
+import (
+	"context"
+	"time"
+)
+
+// Repository defines the interface for organization data access
+type Repository interface {
+	// Create creates the organization and adds the owner as a member
+	Create(ctx context.Context, ownerID int64, name string) (*Organization, error)
+	GetByID(ctx context.Context, id int64) (*Organization, error)
+	GetByOwnerID(ctx context.Context, ownerID int64) (*Organization, error)
+	UpdateName(ctx context.Context, id int64, name string) (*Organization, error)
+
+	AddMember(ctx context.Context, organizationID, userID int64, role string) error
+	GetMember(ctx context.Context, organizationID, userID int64) (*Member, error)
+	ListMembers(ctx context.Context, organizationID int64) ([]*Member, error)
+	ListMemberships(ctx context.Context, userID int64) ([]*Member, error)
+	UpdateMemberRole(ctx context.Context, organizationID, userID int64, role string) error
+	RemoveMember(ctx context.Context, organizationID, userID int64) error
+
+	CreateInvitation(ctx context.Context, organizationID, invitedBy int64, data InvitationCreate, expiresAt time.Time) (*Invitation, error)
+	GetInvitation(ctx context.Context, id int64) (*Invitation, error)
+	ListInvitations(ctx context.Context, organizationID int64) ([]*Invitation, error)
+	ListPendingInvitationsByPhone(ctx context.Context, phone string) ([]*Invitation, error)
+	// RespondInvitation moves a pending invitation to status; it fails with
+	// ErrInvitationNotFound when the invitation is no longer pending.
+	RespondInvitation(ctx context.Context, id int64, status string) error
+}
@@ -0,0 +1,26 @@
+package organization
+
+import "context"
+
+// Service defines the interface for organization business logic. Owner
+// operations act on the organization owned by the calling user.
+type Service interface {
+	GetOwn(ctx context.Context, ownerID int64) (*Organization, error)
+	UpdateOwn(ctx context.Context, ownerID int64, data OrganizationUpdate) (*Organization, error)
+	GetMembers(ctx context.Context, ownerID int64) ([]*Member, error)
+	UpdateMemberRole(ctx context.Context, ownerID, memberID int64, data RoleUpdate) error
+	RemoveMember(ctx context.Context, ownerID, memberID int64) error
+	Invite(ctx context.Context, ownerID int64, data InvitationCreate) (*Invitation, error)
+	GetInvitations(ctx context.Context, ownerID int64) ([]*Invitation, error)
+	RevokeInvitation(ctx context.Context, ownerID, invitationID int64) error
+
+	GetMemberships(ctx context.Context, userID int64) ([]*Membership, error)
+	GetReceivedInvitations(ctx context.Context, userID int64) ([]*ReceivedInvitation, error)
+	AcceptInvitation(ctx context.Context, userID, invitationID int64) (*Membership, error)
+	DeclineInvitation(ctx context.Context, userID, invitationID int64) error
+	Leave(ctx context.Context, userID, organizationID int64) error
+
+	// ResolveAccess returns the owner and role through which the user acts on
+	// the organization, or ErrNotMember.
+	ResolveAccess(ctx context.Context, organizationID, userID int64) (*Access, error)
+}
@@ -0,0 +1,11 @@
+package otp
+
+import "errors"
+
+var (
+	ErrCodeNotFound    = errors.New("no active code for this phone")
+	ErrInvalidCode     = errors.New("invalid code")
+	ErrCodeExpired     = errors.New("code has expired")
+	ErrTooManyAttempts = errors.New("too many incorrect attempts; request a new code")
+	ErrTooManyRequests = errors.New("too many codes requested; try again later")
+)
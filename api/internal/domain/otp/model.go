@@ -0,0 +1,57 @@
+package otp
+
+import "time"
+
+// Code is a one-time code sent to a phone. Only a hash of the code is stored.
+type Code struct {
+	ID          int64
+	Phone       string
+	Purpose     string
+	CodeHash    string
+	Attempts    int
+	MaxAttempts int
+	ExpiresAt   time.Time
+	ConsumedAt  *time.Time
+	CreatedAt   time.Time
+}
+
+// CodeCreate contains data for storing a new code
+type CodeCreate struct {
+	Phone       string
+	Purpose     string
+	CodeHash    string
+	MaxAttempts int
+	ExpiresAt   time.Time
+}
+
+// Request asks for a code to be sent to a phone
+type Request struct {
+	Phone   string `json:"phone" validate:"required"`
+	Purpose string `json:"purpose,omitempty" validate:"omitempty,oneof=verify_phone"`
+}
+
+// Verify checks a code received on a phone
+type Verify struct {
+	Phone   string `json:"phone" validate:"required"`
+	Code    string `json:"code" validate:"required,len=6,numeric"`
+	Purpose string `json:"purpose,omitempty" validate:"omitempty,oneof=verify_phone"`
+}
+
+// Purpose constants
+const (
+	PurposeVerifyPhone   = "verify_phone"
+	PurposePasswordReset = "password_reset"
+)
+
+// Limits
+const (
+	CodeLength         = 6
+	CodeTTL            = 10 * time.Minute
+	MaxAttempts        = 5
+	MaxRequestsPerHour = 5
+)
+
+// IsExpired reports whether the code can no longer be used
+func (c *Code) IsExpired() bool {
+	return time.Now().After(c.ExpiresAt)
+}
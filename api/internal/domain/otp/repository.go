@@ -0,0 +1,18 @@
+package otp
+
+import (
+	"context"
+	"time"
+)
+
+// Repository defines the interface for one-time code data access
+type Repository interface {
+	Create(ctx context.Context, data CodeCreate) (*Code, error)
+	GetActive(ctx context.Context, phone, purpose string) (*Code, error)
+	CountSince(ctx context.Context, phone, purpose string, since time.Time) (int, error)
+	// UseAttempt counts a guess against the code. It reports false, without
+	// counting, once the code has no attempts left.
+	UseAttempt(ctx context.Context, id int64) (bool, error)
+	Consume(ctx context.Context, id int64) (bool, error)
+	Invalidate(ctx context.Context, phone, purpose string) error
+}
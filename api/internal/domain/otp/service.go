@@ -0,0 +1,18 @@
+package otp
+
+import "context"
+
+// Sender delivers a code to a phone, e.g. through an SMS gateway
+type Sender interface {
+	Send(ctx context.Context, phone, code, purpose string) error
+}
+
+// Service defines the interface for one-time code business logic
+type Service interface {
+	// Request generates a code and sends it. No code is sent for phones without
+	// an account, but the call succeeds so registered numbers cannot be probed.
+	Request(ctx context.Context, req Request) error
+
+	// Verify checks a code and applies its purpose, e.g. marks the phone verified.
+	Verify(ctx context.Context, req Verify) error
+}
@@ -0,0 +1,20 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Repository defines the interface for shared rate limit counters
+type Repository interface {
+	// Hit increments the counter of the window starting at windowStart unless
+	// it already reached limit, and returns its new value. ok is false when the
+	// counter was left unchanged.
+	Hit(ctx context.Context, key string, windowStart, expiresAt time.Time, limit int) (count int, ok bool, err error)
+
+	// Count returns the counter of the window starting at windowStart, or 0.
+	Count(ctx context.Context, key string, windowStart time.Time) (int, error)
+
+	// DeleteExpired removes counters that can no longer affect a decision.
+	DeleteExpired(ctx context.Context) error
+}
@@ -2,6 +2,7 @@ package rule
 
 import (
 	"encoding/json"
+	"strings"
 	"time"
 )
 
@@ -23,12 +24,16 @@ type RuleConfig struct {
 	ContactFilter   *ContactFilter `json:"contact_filter,omitempty"`
 }
 
-// ChannelConfig represents per-channel rule settings
+// ChannelConfig represents per-channel rule settings.
+// Enquiries are only answered when EnquiryAutoReply is switched on.
 type ChannelConfig struct {
 	Enabled            bool   `json:"enabled"`
 	IncomingTemplateID *int64 `json:"incoming_template_id,omitempty"`
 	OutgoingTemplateID *int64 `json:"outgoing_template_id,omitempty"`
 	MissedTemplateID   *int64 `json:"missed_template_id,omitempty"`
+	LeadTemplateID     *int64 `json:"lead_template_id,omitempty"`
+	EnquiryTemplateID  *int64 `json:"enquiry_template_id,omitempty"`
+	EnquiryAutoReply   bool   `json:"enquiry_auto_reply"`
 }
 
 // WorkingHours represents working hour constraints
@@ -48,3 +53,83 @@ type ContactFilter struct {
 type RuleUpdate struct {
 	Config json.RawMessage `json:"config" validate:"required"`
 }
+
+// Trigger constants name what a channel template answers: a call direction,
+// an inbound lead or a landing page enquiry
+const (
+	TriggerIncoming = "incoming"
+	TriggerOutgoing = "outgoing"
+	TriggerMissed   = "missed"
+	TriggerLead     = "lead"
+	TriggerEnquiry  = "enquiry"
+)
+
+// TemplateFor returns the template configured for the trigger, or nil
+func (c ChannelConfig) TemplateFor(trigger string) *int64 {
+	var id *int64
+	switch trigger {
+	case TriggerIncoming:
+		id = c.IncomingTemplateID
+	case TriggerOutgoing:
+		id = c.OutgoingTemplateID
+	case TriggerMissed:
+		id = c.MissedTemplateID
+	case TriggerLead:
+		id = c.LeadTemplateID
+	case TriggerEnquiry:
+		if c.EnquiryAutoReply {
+			id = c.EnquiryTemplateID
+		}
+	}
+	if id == nil || *id <= 0 {
+		return nil
+	}
+	return id
+}
+
+// IsExcluded reports whether the phone matches an excluded number. Like the
+// app, it compares digits only and matches on the number's suffix.
+func (c *RuleConfig) IsExcluded(phone string) bool {
+	digits := onlyDigits(phone)
+	for _, excluded := range c.ExcludedNumbers {
+		e := onlyDigits(excluded)
+		if e != "" && strings.HasSuffix(digits, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains reports whether t falls within the working hours. Disabled or
+// unparsable working hours contain every time.
+func (w *WorkingHours) Contains(t time.Time) bool {
+	if w == nil || !w.Enabled {
+		return true
+	}
+	if w.Timezone != "" {
+		loc, err := time.LoadLocation(w.Timezone)
+		if err != nil {
+			return true
+		}
+		t = t.In(loc)
+	}
+	start, err := time.Parse("15:04", w.StartTime)
+	if err != nil {
+		return true
+	}
+	end, err := time.Parse("15:04", w.EndTime)
+	if err != nil {
+		return true
+	}
+	minutes := t.Hour()*60 + t.Minute()
+	return minutes >= start.Hour()*60+start.Minute() && minutes < end.Hour()*60+end.Minute()
+}
+
+func onlyDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, s)
+}
@@ -0,0 +1,10 @@
+package sequence
+
+import "errors"
+
+var (
+	ErrSequenceNotFound = errors.New("sequence not found")
+	ErrAlreadyEnrolled  = errors.New("phone is already enrolled in this sequence")
+	ErrStepsOutOfOrder  = errors.New("step delays must not decrease")
+	ErrInvalidTemplate  = errors.New("step template does not exist")
+)
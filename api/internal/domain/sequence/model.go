@@ -0,0 +1,92 @@
+package sequence
+
+import "time"
+
+// Sequence represents a follow-up series of templates sent after a call
+type Sequence struct {
+	ID             int64     `json:"id"`
+	UserID         int64     `json:"user_id"`
+	Name           string    `json:"name"`
+	Trigger        string    `json:"trigger"` // all/incoming/outgoing/missed
+	Steps          []Step    `json:"steps"`
+	StopOnCallback bool      `json:"stop_on_callback"`
+	StopOnOptOut   bool      `json:"stop_on_opt_out"`
+	Enabled        bool      `json:"enabled"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// Step is a single message in a sequence.
+// DelaySeconds is measured from the call that started the sequence.
+type Step struct {
+	TemplateID   int64 `json:"template_id" validate:"required"`
+	DelaySeconds int   `json:"delay_seconds" validate:"min=0"`
+}
+
+// SequenceCreate contains data for creating a sequence
+type SequenceCreate struct {
+	Name           string `json:"name" validate:"required,max=255"`
+	Trigger        string `json:"trigger" validate:"required,oneof=all incoming outgoing missed"`
+	Steps          []Step `json:"steps" validate:"required,min=1,max=10,dive"`
+	StopOnCallback *bool  `json:"stop_on_callback"`
+	StopOnOptOut   *bool  `json:"stop_on_opt_out"`
+	Enabled        *bool  `json:"enabled"`
+}
+
+// SequenceUpdate contains data for updating a sequence
+type SequenceUpdate struct {
+	Name           string `json:"name" validate:"required,max=255"`
+	Trigger        string `json:"trigger" validate:"required,oneof=all incoming outgoing missed"`
+	Steps          []Step `json:"steps" validate:"required,min=1,max=10,dive"`
+	StopOnCallback *bool  `json:"stop_on_callback"`
+	StopOnOptOut   *bool  `json:"stop_on_opt_out"`
+	Enabled        *bool  `json:"enabled"`
+}
+
+// Enrollment tracks a single phone number's progress through a sequence
+type Enrollment struct {
+	ID         int64      `json:"id"`
+	SequenceID int64      `json:"sequence_id"`
+	UserID     int64      `json:"user_id"`
+	Phone      string     `json:"phone"`
+	Status     string     `json:"status"`
+	NextStep   int        `json:"next_step"`
+	NextRunAt  *time.Time `json:"next_run_at,omitempty"`
+	StopReason string     `json:"stop_reason,omitempty"`
+	StartedAt  time.Time  `json:"started_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// EnrollmentAdvance describes the state an enrollment moves to after a step is dispatched
+type EnrollmentAdvance struct {
+	NextStep  int
+	NextRunAt *time.Time
+	Status    string
+}
+
+// Trigger constants mirror template types
+const (
+	TriggerAll      = "all"
+	TriggerIncoming = "incoming"
+	TriggerOutgoing = "outgoing"
+	TriggerMissed   = "missed"
+)
+
+// Enrollment status constants
+const (
+	StatusActive    = "active"
+	StatusCompleted = "completed"
+	StatusStopped   = "stopped"
+)
+
+// Stop reason constants
+const (
+	StopReasonCalledBack       = "called_back"
+	StopReasonOptedOut         = "opted_out"
+	StopReasonSequenceDisabled = "sequence_disabled"
+)
+
+// Limits
+const (
+	MaxSteps = 10
+)
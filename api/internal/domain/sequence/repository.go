@@ -0,0 +1,27 @@
+package sequence
+
+import (
+	"context"
+	"time"
+
+	"callflow/internal/domain/device"
+)
+
+// Repository defines the interface for sequence data access
+type Repository interface {
+	GetByUserID(ctx context.Context, userID int64) ([]*Sequence, error)
+	GetByID(ctx context.Context, id int64, userID int64) (*Sequence, error)
+	GetEnabledByTrigger(ctx context.Context, userID int64, trigger string) ([]*Sequence, error)
+	Create(ctx context.Context, userID int64, data SequenceCreate) (*Sequence, error)
+	Update(ctx context.Context, id int64, userID int64, data SequenceUpdate) (*Sequence, error)
+	Delete(ctx context.Context, id int64, userID int64) error
+
+	Enroll(ctx context.Context, sequenceID, userID int64, phone string, firstRunAt time.Time) (*Enrollment, error)
+	GetEnrollments(ctx context.Context, sequenceID, userID int64, limit int) ([]*Enrollment, error)
+	ClaimDueEnrollments(ctx context.Context, userID int64, limit int) ([]*Enrollment, error)
+	AdvanceEnrollment(ctx context.Context, id int64, data EnrollmentAdvance) error
+	QueueStep(ctx context.Context, id int64, userID int64, job device.JobCreate, data EnrollmentAdvance) error
+	StopEnrollment(ctx context.Context, id int64, reason string) error
+	StopOnCallback(ctx context.Context, userID int64, phone string) (int64, error)
+	StopOnOptOut(ctx context.Context, userID int64, phone string) (int64, error)
+}
@@ -0,0 +1,29 @@
+package sequence
+
+import (
+	"context"
+
+	"callflow/internal/domain/contact"
+	"callflow/internal/domain/device"
+)
+
+// Service defines the interface for follow-up sequence business logic
+type Service interface {
+	Get(ctx context.Context, userID int64) ([]*Sequence, error)
+	GetByID(ctx context.Context, id int64, userID int64) (*Sequence, error)
+	Create(ctx context.Context, userID int64, data SequenceCreate) (*Sequence, error)
+	Update(ctx context.Context, id int64, userID int64, data SequenceUpdate) (*Sequence, error)
+	Delete(ctx context.Context, id int64, userID int64) error
+	GetEnrollments(ctx context.Context, id int64, userID int64) ([]*Enrollment, error)
+
+	// HandleCall stops sequences the caller called back into and enrolls the
+	// contact into every enabled sequence matching the call direction.
+	HandleCall(ctx context.Context, userID int64, c *contact.Contact, direction string) ([]*Enrollment, error)
+
+	// HandleOptOut stops every active enrollment for the phone that honours opt-outs.
+	HandleOptOut(ctx context.Context, userID int64, phone string) error
+
+	// QueueDue queues the steps that are due as device jobs and advances their
+	// enrollments.
+	device.JobSource
+}
@@ -0,0 +1,8 @@
+package shortlink
+
+import "errors"
+
+var (
+	ErrShortLinkNotFound = errors.New("short link not found")
+	ErrCodeTaken         = errors.New("short link code is already taken")
+)
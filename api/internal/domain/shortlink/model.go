@@ -0,0 +1,67 @@
+package shortlink
+
+import "time"
+
+// ShortLink redirects /s/<Code> to URL and counts the clicks
+type ShortLink struct {
+	ID          int64      `json:"id"`
+	UserID      int64      `json:"user_id"`
+	Code        string     `json:"code"`
+	ShortURL    string     `json:"short_url"`
+	URL         string     `json:"url"`
+	Source      string     `json:"source"`
+	RefID       *int64     `json:"ref_id,omitempty"`
+	Phone       *string    `json:"phone,omitempty"`
+	Clicks      int        `json:"clicks"`
+	LastClickAt *time.Time `json:"last_click_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	// RecentClicks is only filled in when a single link is requested
+	RecentClicks []*Click `json:"recent_clicks,omitempty"`
+}
+
+// ShortLinkCreate describes the URL to shorten. Links with a RefID are issued
+// once per source and message.
+type ShortLinkCreate struct {
+	URL    string  `json:"url" validate:"required,http_url,max=2048"`
+	Phone  *string `json:"phone,omitempty" validate:"omitempty,max=30"`
+	Source string  `json:"-"`
+	RefID  *int64  `json:"-"`
+}
+
+// Click is one visit through a short link. ShortLinkID is only set where
+// clicks of several links are listed together.
+type Click struct {
+	ShortLinkID int64     `json:"short_link_id,omitempty"`
+	UserAgent   string    `json:"user_agent"`
+	Device      string    `json:"device"`
+	ClickedAt   time.Time `json:"clicked_at"`
+}
+
+// Source constants
+const (
+	SourceManual   = "manual"
+	SourceRender   = "render"
+	SourceJob      = "job"
+	SourceCampaign = "campaign"
+)
+
+// Device constants, coarsely derived from the user agent. Bot clicks, such as
+// link previews, are logged but not counted.
+const (
+	DeviceMobile  = "mobile"
+	DeviceTablet  = "tablet"
+	DeviceDesktop = "desktop"
+	DeviceBot     = "bot"
+	DeviceUnknown = "unknown"
+)
+
+const (
+	// CodeLength is the number of base62 characters in a code
+	CodeLength = 7
+	// MaxUserAgentLength caps the stored user agent
+	MaxUserAgentLength = 512
+	// MaxListedLinks caps the links returned by the list endpoint
+	MaxListedLinks = 200
+	// MaxListedClicks caps the clicks returned with a single link
+	MaxListedClicks = 100
+)
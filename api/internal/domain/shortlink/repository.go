@@ -0,0 +1,17 @@
+package shortlink
+
+import "context"
+
+// Repository defines the interface for short link data access
+type Repository interface {
+	// Create stores a link, or returns the one already issued for the same
+	// source and RefID. It fails with ErrCodeTaken when code is in use.
+	Create(ctx context.Context, userID int64, code string, data ShortLinkCreate) (*ShortLink, error)
+	GetByCode(ctx context.Context, code string) (*ShortLink, error)
+	GetByID(ctx context.Context, id, userID int64) (*ShortLink, error)
+	GetByRef(ctx context.Context, userID int64, source string, refID int64) (*ShortLink, error)
+	GetByUserID(ctx context.Context, userID int64, limit int) ([]*ShortLink, error)
+	// RecordClick logs a click and, unless it comes from a bot, counts it on the link.
+	RecordClick(ctx context.Context, id int64, userAgent, device string) error
+	GetClicks(ctx context.Context, id int64, limit int) ([]*Click, error)
+}
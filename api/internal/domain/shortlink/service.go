@@ -0,0 +1,19 @@
+package shortlink
+
+import "context"
+
+// Service defines the interface for short link business logic
+type Service interface {
+	Create(ctx context.Context, userID int64, data ShortLinkCreate) (*ShortLink, error)
+	Get(ctx context.Context, userID int64) ([]*ShortLink, error)
+	GetByID(ctx context.Context, id, userID int64) (*ShortLink, error)
+	// GetByRef returns the link already issued for the source and RefID.
+	GetByRef(ctx context.Context, userID int64, source string, refID int64) (*ShortLink, error)
+
+	// Follow logs a click on the link with the given code and returns the URL
+	// to redirect to.
+	Follow(ctx context.Context, code, userAgent string) (string, error)
+	// ShortenText replaces every http(s) URL in text with a new short link,
+	// so each message gets its own codes.
+	ShortenText(ctx context.Context, userID int64, text string, phone *string) (string, []*ShortLink, error)
+}
@@ -1,6 +1,11 @@
 package template
 
-import "time"
+import (
+	"time"
+
+	"callflow/internal/domain/media"
+	"callflow/internal/domain/shortlink"
+)
 
 // Template represents a message template
 type Template struct {
@@ -12,13 +17,15 @@ type Template struct {
 	Channel   string    `json:"channel"` // sms
 	ImageURL  *string   `json:"image_url,omitempty"`
 	ImageKey  *string   `json:"-"`
+	MediaID   *int64    `json:"media_id,omitempty"`
 	Language  string    `json:"language"`
 	IsDefault bool      `json:"is_default"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
-// TemplateCreate contains data for creating a template
+// TemplateCreate contains data for creating a template. MediaID picks an
+// image from the media library in place of ImageURL and ImageKey.
 type TemplateCreate struct {
 	Name      string  `json:"name" validate:"required,max=255"`
 	Body      string  `json:"body" validate:"required"`
@@ -26,11 +33,13 @@ type TemplateCreate struct {
 	Channel   string  `json:"channel" validate:"omitempty"`
 	ImageURL  *string `json:"image_url" validate:"omitempty"`
 	ImageKey  *string `json:"image_key" validate:"omitempty"`
+	MediaID   *int64  `json:"media_id" validate:"omitempty,gt=0"`
 	Language  string  `json:"language"`
 	IsDefault bool    `json:"is_default"`
 }
 
-// TemplateUpdate contains data for updating a template
+// TemplateUpdate contains data for updating a template. MediaID picks an
+// image from the media library in place of ImageURL and ImageKey.
 type TemplateUpdate struct {
 	Name      string  `json:"name" validate:"required,max=255"`
 	Body      string  `json:"body" validate:"required"`
@@ -38,13 +47,16 @@ type TemplateUpdate struct {
 	Channel   string  `json:"channel" validate:"omitempty"`
 	ImageURL  *string `json:"image_url" validate:"omitempty"`
 	ImageKey  *string `json:"image_key" validate:"omitempty"`
+	MediaID   *int64  `json:"media_id" validate:"omitempty,gt=0"`
 	Language  string  `json:"language"`
 	IsDefault bool    `json:"is_default"`
 }
 
 type UploadedImage struct {
-	URL string `json:"image_url"`
-	Key string `json:"image_key"`
+	MediaID  int64            `json:"media_id"`
+	URL      string           `json:"image_url"`
+	Key      string           `json:"image_key"`
+	Variants []*media.Variant `json:"variants"`
 }
 
 // Type constants
@@ -64,3 +76,19 @@ const (
 const (
 	SMSMaxChars = 918 // 6 parts × 153 chars
 )
+
+// RenderRequest names the recipient of a rendered message. Variables fill or
+// override placeholders such as {call_duration}.
+type RenderRequest struct {
+	Phone       string            `json:"phone" validate:"required,max=30"`
+	ContactName string            `json:"contact_name,omitempty" validate:"max=255"`
+	Variables   map[string]string `json:"variables,omitempty" validate:"max=20,dive,keys,max=50,endkeys,max=500"`
+}
+
+// Rendered is a template filled in for one message, with every URL in the
+// body replaced by a short link of its own
+type Rendered struct {
+	Body     string                 `json:"body"`
+	ImageURL *string                `json:"image_url,omitempty"`
+	Links    []*shortlink.ShortLink `json:"links"`
+}
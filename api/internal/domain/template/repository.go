@@ -9,4 +9,8 @@ type Repository interface {
 	Create(ctx context.Context, userID int64, data TemplateCreate) (*Template, error)
 	Update(ctx context.Context, id int64, userID int64, data TemplateUpdate) (*Template, error)
 	Delete(ctx context.Context, id int64, userID int64) error
+	// GetWithImages returns the templates of all users that have a stored image
+	GetWithImages(ctx context.Context) ([]*Template, error)
+	// ReplaceImage swaps the stored image of a template, provided it still has oldKey
+	ReplaceImage(ctx context.Context, id int64, oldKey, imageURL, imageKey string) error
 }
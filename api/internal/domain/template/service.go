@@ -10,4 +10,7 @@ type Service interface {
 	Update(ctx context.Context, id int64, userID int64, data TemplateUpdate) (*Template, error)
 	Delete(ctx context.Context, id int64, userID int64) error
 	UploadImage(ctx context.Context, userID int64, filename, contentType string, file []byte) (*UploadedImage, error)
+	// Render fills in the template's placeholders for one recipient, the way
+	// the device does, and shortens the URLs in the result.
+	Render(ctx context.Context, id int64, userID int64, data RenderRequest) (*Rendered, error)
 }
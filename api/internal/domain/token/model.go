@@ -29,3 +29,9 @@ type TokenCreate struct {
 	ClientIP  pgtype.Text `json:"client_ip,omitempty"`
 	UserAgent pgtype.Text `json:"user_agent,omitempty"`
 }
+
+// Token type constants
+const (
+	TypeAccess  = "access"
+	TypeRefresh = "refresh"
+)
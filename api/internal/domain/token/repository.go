@@ -11,10 +11,12 @@ type Repository interface {
 	GetRefreshTokenByToken(ctx context.Context, token string) (*Token, error)
 	RevokeToken(ctx context.Context, token string) error
 	RevokeAllUserTokens(ctx context.Context, userID int64) error
+	RevokeOtherUserTokens(ctx context.Context, userID int64, keepTokenID string) error
 	UpdateTokenLastUsed(ctx context.Context, id int64) error
 	DeleteExpiredTokens(ctx context.Context, before time.Time) error
 	StoreTokenID(ctx context.Context, tokenID string, userID int64, expiresAt time.Time, tokenType string) error
 	IsTokenRevoked(ctx context.Context, tokenID string) (bool, error)
 	RevokeTokenByID(ctx context.Context, tokenID string) error
+	StoreDeviceTokenID(ctx context.Context, tokenID string, userID int64, deviceID *int64, expiresAt time.Time) error
 	RevokeAllTokensByType(ctx context.Context, userID int64, tokenType string) error
 }
@@ -5,4 +5,6 @@ import "errors"
 var (
 	ErrUserNotFound = errors.New("user not found")
 	ErrPhoneTaken   = errors.New("phone number already registered")
+
+	ErrPhoneNotVerified = errors.New("phone number is not verified")
 )
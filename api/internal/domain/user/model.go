@@ -2,23 +2,26 @@ package user
 
 import "time"
 
-// User represents a user in the system
+// User represents a user in the system. TokensValidAfter invalidates the
+// user's jti-less legacy tokens issued before it.
 type User struct {
-	ID            int64      `json:"id"`
-	Phone         string     `json:"phone"`
-	PasswordHash  string     `json:"-"`
-	PhoneVerified bool       `json:"phone_verified"`
-	Name          string     `json:"name,omitempty"`
-	BusinessName  string     `json:"business_name,omitempty"`
-	City          string     `json:"city,omitempty"`
-	Address       string     `json:"address,omitempty"`
-	LocationURL   string     `json:"location_url,omitempty"`
-	Plan          string     `json:"plan"`
-	PlanStartedAt *time.Time `json:"plan_started_at,omitempty"`
-	PlanExpiresAt *time.Time `json:"plan_expires_at,omitempty"`
-	Status        string     `json:"status"`
-	CreatedAt     time.Time  `json:"created_at"`
-	UpdatedAt     time.Time  `json:"updated_at"`
+	ID               int64      `json:"id"`
+	Phone            string     `json:"phone"`
+	PasswordHash     string     `json:"-"`
+	PhoneVerified    bool       `json:"phone_verified"`
+	Name             string     `json:"name,omitempty"`
+	BusinessName     string     `json:"business_name,omitempty"`
+	City             string     `json:"city,omitempty"`
+	Address          string     `json:"address,omitempty"`
+	LocationURL      string     `json:"location_url,omitempty"`
+	Plan             string     `json:"plan"`
+	PlanStartedAt    *time.Time `json:"plan_started_at,omitempty"`
+	PlanExpiresAt    *time.Time `json:"plan_expires_at,omitempty"`
+	Status           string     `json:"status"`
+	PurgeAfter       *time.Time `json:"purge_after,omitempty"`
+	TokensValidAfter *time.Time `json:"-"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
 }
 
 // UserCreate contains data for creating a new user
@@ -51,6 +54,7 @@ const (
 const (
 	StatusActive   = "active"
 	StatusInactive = "inactive"
+	StatusDeleted  = "deleted"
 )
 
 // HasChannel checks if user's plan includes the given channel
@@ -10,5 +10,8 @@ type Repository interface {
 	Update(ctx context.Context, id int64, data UserUpdate) (*User, error)
 	UpdatePlan(ctx context.Context, id int64, plan string) error
 	UpdateStatus(ctx context.Context, id int64, status string) error
+	MarkPhoneVerified(ctx context.Context, phone string) error
+	UpdatePassword(ctx context.Context, id int64, passwordHash string) error
+	InvalidateTokens(ctx context.Context, id int64) error
 	ListAll(ctx context.Context) ([]*User, error)
 }
@@ -0,0 +1,11 @@
+package webhook
+
+import "errors"
+
+var (
+	ErrWebhookNotFound  = errors.New("webhook not found")
+	ErrDeliveryNotFound = errors.New("delivery not found")
+	ErrInvalidEventType = errors.New("unknown event type")
+	ErrInvalidURL       = errors.New("webhook url must use http or https")
+	ErrPrivateURL       = errors.New("webhook url must resolve to a public address")
+)
@@ -0,0 +1,140 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Webhook is a user's subscription to events, delivered by POST to URL
+type Webhook struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"user_id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Secret    string    `json:"-"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WebhookCreate contains data for creating a webhook. An empty secret is generated.
+type WebhookCreate struct {
+	URL    string   `json:"url" validate:"required,url,max=2000"`
+	Events []string `json:"events" validate:"required,min=1"`
+	Secret string   `json:"secret,omitempty" validate:"omitempty,min=16,max=100"`
+}
+
+// WebhookUpdate contains data for updating a webhook
+type WebhookUpdate struct {
+	URL    string   `json:"url" validate:"required,url,max=2000"`
+	Events []string `json:"events" validate:"required,min=1"`
+	Active bool     `json:"active"`
+}
+
+// CreatedWebhook is returned once on creation with the signing secret
+type CreatedWebhook struct {
+	*Webhook
+	Secret string `json:"secret"`
+}
+
+// Delivery is one event queued for, or sent to, a webhook
+type Delivery struct {
+	ID             int64           `json:"id"`
+	WebhookID      int64           `json:"webhook_id"`
+	EventID        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	MaxAttempts    int             `json:"max_attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	ResponseStatus *int            `json:"response_status,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	DurationMs     *int            `json:"duration_ms,omitempty"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// Event is the JSON body posted to a webhook
+type Event struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+// MessageData is the data of message.sent and message.failed events. Source
+// tells whether the message was a plain device job, a campaign message or a
+// sequence step.
+type MessageData struct {
+	Source       string `json:"source"`
+	JobID        int64  `json:"job_id,omitempty"`
+	CampaignID   int64  `json:"campaign_id,omitempty"`
+	RecipientID  int64  `json:"recipient_id,omitempty"`
+	EnrollmentID int64  `json:"enrollment_id,omitempty"`
+	Phone        string `json:"phone,omitempty"`
+	TemplateID   *int64 `json:"template_id,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// Attempt is the outcome of posting a delivery
+type Attempt struct {
+	ResponseStatus int
+	Error          string
+	Duration       time.Duration
+	// Retryable is false for failures that would fail again, such as a removed
+	// or disabled webhook.
+	Retryable bool
+}
+
+// Event type constants
+const (
+	EventCallMissed     = "call.missed"
+	EventMessageSent    = "message.sent"
+	EventMessageFailed  = "message.failed"
+	EventContactCreated = "contact.created"
+)
+
+// EventTypes lists the events a webhook can subscribe to
+var EventTypes = []string{
+	EventCallMissed,
+	EventMessageSent,
+	EventMessageFailed,
+	EventContactCreated,
+}
+
+// Message source constants
+const (
+	SourceDeviceJob = "device_job"
+	SourceCampaign  = "campaign"
+	SourceSequence  = "sequence"
+)
+
+// Delivery status constants
+const (
+	DeliveryPending    = "pending"
+	DeliveryDelivering = "delivering"
+	DeliveryDelivered  = "delivered"
+	DeliveryFailed     = "failed"
+)
+
+// Signing headers sent with every delivery. The signature is
+// "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<t>.<body>" keyed by the secret>".
+const (
+	HeaderEvent     = "X-CallFlow-Event"
+	HeaderDelivery  = "X-CallFlow-Delivery"
+	HeaderSignature = "X-CallFlow-Signature"
+)
+
+// MaxListedDeliveries caps the delivery log returned per webhook
+const MaxListedDeliveries = 100
+
+// IsValidEventType reports whether t is a known event type
+func IsValidEventType(t string) bool {
+	for _, e := range EventTypes {
+		if e == t {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package webhook
+
+import (
+	"context"
+	"time"
+)
+
+// Repository defines the interface for webhook data access
+type Repository interface {
+	Create(ctx context.Context, userID int64, data WebhookCreate) (*Webhook, error)
+	GetByID(ctx context.Context, id, userID int64) (*Webhook, error)
+	GetByUserID(ctx context.Context, userID int64) ([]*Webhook, error)
+	GetForEvent(ctx context.Context, userID int64, eventType string) ([]*Webhook, error)
+	Update(ctx context.Context, id, userID int64, data WebhookUpdate) (*Webhook, error)
+	Delete(ctx context.Context, id, userID int64) error
+
+	CreateDelivery(ctx context.Context, webhookID int64, eventID, eventType string, payload []byte) (*Delivery, error)
+	GetDelivery(ctx context.Context, id, webhookID int64) (*Delivery, error)
+	GetDeliveries(ctx context.Context, webhookID int64, limit int) ([]*Delivery, error)
+
+	// GetForDelivery loads a webhook regardless of owner, for the dispatcher.
+	GetForDelivery(ctx context.Context, id int64) (*Webhook, error)
+	// ClaimDeliveries locks due deliveries until lockedUntil and counts the attempt.
+	ClaimDeliveries(ctx context.Context, lockedUntil time.Time, limit int) ([]*Delivery, error)
+	CompleteDelivery(ctx context.Context, id int64, attempt Attempt) error
+	// FailDelivery schedules a retry with exponential backoff, or marks the
+	// delivery failed once it is out of attempts or not retryable.
+	FailDelivery(ctx context.Context, id int64, attempt Attempt) error
+}
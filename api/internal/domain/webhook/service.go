@@ -0,0 +1,21 @@
+package webhook
+
+import "context"
+
+// Service defines the interface for webhook business logic
+type Service interface {
+	Create(ctx context.Context, userID int64, data WebhookCreate) (*CreatedWebhook, error)
+	Get(ctx context.Context, userID int64) ([]*Webhook, error)
+	Update(ctx context.Context, id, userID int64, data WebhookUpdate) (*Webhook, error)
+	Delete(ctx context.Context, id, userID int64) error
+	GetDeliveries(ctx context.Context, id, userID int64) ([]*Delivery, error)
+	Redeliver(ctx context.Context, id, deliveryID, userID int64) (*Delivery, error)
+
+	Emitter
+}
+
+// Emitter queues events for the user's subscribed webhooks. Emitting is best
+// effort: failures are logged and never fail the operation that raised the event.
+type Emitter interface {
+	Emit(ctx context.Context, userID int64, eventType string, data any)
+}
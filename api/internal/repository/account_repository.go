@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"callflow/internal/domain/campaign"
+	"callflow/internal/domain/device"
+	"callflow/internal/domain/landing"
+	"callflow/internal/domain/lead"
+	"callflow/internal/domain/sequence"
+	"callflow/internal/domain/shortlink"
+	"callflow/internal/domain/user"
+	db "callflow/internal/sql/db"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AccountRepository implements account.Repository
+type AccountRepository struct {
+	pool    *pgxpool.Pool
+	queries *db.Queries
+}
+
+// NewAccountRepository creates a new account repository
+func NewAccountRepository(pool *pgxpool.Pool) *AccountRepository {
+	return &AccountRepository{
+		pool:    pool,
+		queries: db.New(pool),
+	}
+}
+
+func (r *AccountRepository) ListCampaignMessages(ctx context.Context, userID int64) ([]*campaign.Recipient, error) {
+	rows, err := r.queries.ListCampaignRecipientsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return dbRecipientsToModels(rows), nil
+}
+
+func (r *AccountRepository) ListDeviceJobs(ctx context.Context, userID int64) ([]*device.Job, error) {
+	rows, err := r.queries.ListAllDeviceJobsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return dbDeviceJobsToModels(rows), nil
+}
+
+func (r *AccountRepository) ListSequenceEnrollments(ctx context.Context, userID int64) ([]*sequence.Enrollment, error) {
+	rows, err := r.queries.ListSequenceEnrollmentsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return dbEnrollmentsToModels(rows), nil
+}
+
+func (r *AccountRepository) ListLeads(ctx context.Context, userID int64) ([]*lead.Lead, error) {
+	rows, err := r.queries.ListAllLeadsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return dbLeadsToModels(rows), nil
+}
+
+func (r *AccountRepository) ListShortLinks(ctx context.Context, userID int64) ([]*shortlink.ShortLink, error) {
+	rows, err := r.queries.ListAllShortLinksByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	links := make([]*shortlink.ShortLink, len(rows))
+	for i, row := range rows {
+		links[i] = dbShortLinkToModel(row)
+	}
+	return links, nil
+}
+
+func (r *AccountRepository) ListShortLinkClicks(ctx context.Context, userID int64) ([]*shortlink.Click, error) {
+	rows, err := r.queries.ListShortLinkClicksByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	clicks := make([]*shortlink.Click, len(rows))
+	for i, row := range rows {
+		clicks[i] = &shortlink.Click{
+			ShortLinkID: row.ShortLinkID,
+			UserAgent:   row.UserAgent,
+			Device:      row.Device,
+			ClickedAt:   row.ClickedAt.Time,
+		}
+	}
+	return clicks, nil
+}
+
+func (r *AccountRepository) ListLandingSlugs(ctx context.Context, userID int64) ([]*landing.Slug, error) {
+	rows, err := r.queries.ListLandingSlugsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	slugs := make([]*landing.Slug, len(rows))
+	for i, row := range rows {
+		slugs[i] = dbLandingSlugToModel(row)
+	}
+	return slugs, nil
+}
+
+func (r *AccountRepository) ListLandingRevisions(ctx context.Context, userID int64) ([]*landing.Revision, error) {
+	rows, err := r.queries.ListAllLandingRevisionsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	revisions := make([]*landing.Revision, len(rows))
+	for i, row := range rows {
+		if revisions[i], err = dbLandingRevisionToModel(row); err != nil {
+			return nil, err
+		}
+	}
+	return revisions, nil
+}
+
+func (r *AccountRepository) SoftDelete(ctx context.Context, userID int64, purgeAfter time.Time) error {
+	return r.queries.SoftDeleteUser(ctx, db.SoftDeleteUserParams{
+		ID:         userID,
+		PurgeAfter: pgtype.Timestamptz{Time: purgeAfter, Valid: true},
+	})
+}
+
+func (r *AccountRepository) ListDueForPurge(ctx context.Context, limit int) ([]*user.User, error) {
+	rows, err := r.queries.ListUsersDueForPurge(ctx, int32(limit))
+	if err != nil {
+		return nil, err
+	}
+	users := make([]*user.User, len(rows))
+	for i, row := range rows {
+		users[i] = dbUserToModel(row)
+	}
+	return users, nil
+}
+
+func (r *AccountRepository) Purge(ctx context.Context, u *user.User) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	q := r.queries.WithTx(tx)
+	// Phone-keyed rows have no foreign key to the user.
+	if err := q.DeleteLoginAttemptsByPhone(ctx, u.Phone); err != nil {
+		return err
+	}
+	if err := q.DeleteOTPCodesByPhone(ctx, u.Phone); err != nil {
+		return err
+	}
+	// Everything else cascades from the user row.
+	if err := q.DeleteUser(ctx, u.ID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"callflow/internal/domain/apikey"
+	db "callflow/internal/sql/db"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// APIKeyRepository implements apikey.Repository
+type APIKeyRepository struct {
+	pool    *pgxpool.Pool
+	queries *db.Queries
+}
+
+// NewAPIKeyRepository creates a new API key repository
+func NewAPIKeyRepository(pool *pgxpool.Pool) *APIKeyRepository {
+	return &APIKeyRepository{
+		pool:    pool,
+		queries: db.New(pool),
+	}
+}
+
+func (r *APIKeyRepository) Create(ctx context.Context, userID int64, data apikey.KeyCreate, prefix, keyHash string) (*apikey.Key, error) {
+	var expiresAt pgtype.Timestamptz
+	if data.ExpiresAt != nil {
+		expiresAt = pgtype.Timestamptz{Time: *data.ExpiresAt, Valid: true}
+	}
+	row, err := r.queries.CreateAPIKey(ctx, db.CreateAPIKeyParams{
+		UserID:    userID,
+		Name:      data.Name,
+		Prefix:    prefix,
+		KeyHash:   keyHash,
+		Scopes:    data.Scopes,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dbAPIKeyToModel(row), nil
+}
+
+func (r *APIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*apikey.Key, error) {
+	row, err := r.queries.GetAPIKeyByHash(ctx, keyHash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apikey.ErrKeyNotFound
+		}
+		return nil, err
+	}
+	return dbAPIKeyToModel(row), nil
+}
+
+func (r *APIKeyRepository) GetByUserID(ctx context.Context, userID int64) ([]*apikey.Key, error) {
+	rows, err := r.queries.ListAPIKeysByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]*apikey.Key, len(rows))
+	for i, row := range rows {
+		keys[i] = dbAPIKeyToModel(row)
+	}
+	return keys, nil
+}
+
+func (r *APIKeyRepository) CountActive(ctx context.Context, userID int64) (int, error) {
+	n, err := r.queries.CountActiveAPIKeys(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	return int(n), nil
+}
+
+func (r *APIKeyRepository) Revoke(ctx context.Context, id, userID int64) error {
+	n, err := r.queries.RevokeAPIKey(ctx, db.RevokeAPIKeyParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return apikey.ErrKeyNotFound
+	}
+	return nil
+}
+
+func (r *APIKeyRepository) Touch(ctx context.Context, id int64) error {
+	return r.queries.TouchAPIKey(ctx, id)
+}
+
+func dbAPIKeyToModel(row db.ApiKey) *apikey.Key {
+	k := &apikey.Key{
+		ID:        row.ID,
+		UserID:    row.UserID,
+		Name:      row.Name,
+		Prefix:    row.Prefix,
+		KeyHash:   row.KeyHash,
+		Scopes:    row.Scopes,
+		CreatedAt: row.CreatedAt.Time,
+	}
+	if k.Scopes == nil {
+		k.Scopes = []string{}
+	}
+	if row.ExpiresAt.Valid {
+		t := row.ExpiresAt.Time
+		k.ExpiresAt = &t
+	}
+	if row.LastUsedAt.Valid {
+		t := row.LastUsedAt.Time
+		k.LastUsedAt = &t
+	}
+	if row.RevokedAt.Valid {
+		t := row.RevokedAt.Time
+		k.RevokedAt = &t
+	}
+	return k
+}
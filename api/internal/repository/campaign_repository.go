@@ -0,0 +1,354 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"callflow/internal/domain/campaign"
+	db "callflow/internal/sql/db"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// CampaignRepository implements campaign.Repository
+type CampaignRepository struct {
+	pool    *pgxpool.Pool
+	queries *db.Queries
+}
+
+// NewCampaignRepository creates a new campaign repository
+func NewCampaignRepository(pool *pgxpool.Pool) *CampaignRepository {
+	return &CampaignRepository{
+		pool:    pool,
+		queries: db.New(pool),
+	}
+}
+
+func (r *CampaignRepository) GetByUserID(ctx context.Context, userID int64) ([]*campaign.Campaign, error) {
+	rows, err := r.queries.ListCampaignsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return dbCampaignsToModels(rows)
+}
+
+func (r *CampaignRepository) GetByID(ctx context.Context, id int64, userID int64) (*campaign.Campaign, error) {
+	row, err := r.queries.GetCampaignByID(ctx, db.GetCampaignByIDParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, campaign.ErrCampaignNotFound
+		}
+		return nil, err
+	}
+	return dbCampaignToModel(row)
+}
+
+func (r *CampaignRepository) Create(ctx context.Context, userID int64, data campaign.CampaignCreate) (*campaign.Campaign, error) {
+	segment, err := json.Marshal(data.Segment)
+	if err != nil {
+		return nil, err
+	}
+	scheduledAt := time.Now()
+	if data.ScheduledAt != nil {
+		scheduledAt = *data.ScheduledAt
+	}
+	throttle := data.ThrottlePerMinute
+	if throttle <= 0 {
+		throttle = campaign.DefaultThrottlePerMinute
+	}
+	row, err := r.queries.CreateCampaign(ctx, db.CreateCampaignParams{
+		UserID:            userID,
+		Name:              data.Name,
+		TemplateID:        data.TemplateID,
+		Segment:           segment,
+		ScheduledAt:       pgtype.Timestamptz{Time: scheduledAt, Valid: true},
+		ThrottlePerMinute: int32(throttle),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dbCampaignToModel(row)
+}
+
+func (r *CampaignRepository) GetDueScheduled(ctx context.Context, userID int64) ([]*campaign.Campaign, error) {
+	rows, err := r.queries.ListDueScheduledCampaigns(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return dbCampaignsToModels(rows)
+}
+
+func (r *CampaignRepository) GetRunning(ctx context.Context, userID int64) ([]*campaign.Campaign, error) {
+	rows, err := r.queries.ListRunningCampaigns(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return dbCampaignsToModels(rows)
+}
+
+func (r *CampaignRepository) Start(ctx context.Context, c *campaign.Campaign, calledAfter *time.Time) (*campaign.Campaign, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	q := r.queries.WithTx(tx)
+	row, err := q.StartCampaign(ctx, c.ID)
+	if err != nil {
+		// Another pull already started the campaign or it was paused meanwhile.
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, campaign.ErrInvalidTransition
+		}
+		return nil, err
+	}
+	started, err := dbCampaignToModel(row)
+	if err != nil {
+		return nil, err
+	}
+	total, err := expandRecipients(ctx, q, started, calledAfter)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	started.TotalRecipients = total
+	return started, nil
+}
+
+func (r *CampaignRepository) Complete(ctx context.Context, id int64) error {
+	return r.queries.CompleteCampaign(ctx, id)
+}
+
+func (r *CampaignRepository) Pause(ctx context.Context, id int64, userID int64) (*campaign.Campaign, error) {
+	row, err := r.queries.PauseCampaign(ctx, db.PauseCampaignParams{
+		ID:     id,
+		UserID: userID,
+	})
+	return transitionedCampaign(row, err)
+}
+
+func (r *CampaignRepository) Resume(ctx context.Context, id int64, userID int64) (*campaign.Campaign, error) {
+	row, err := r.queries.ResumeCampaign(ctx, db.ResumeCampaignParams{
+		ID:     id,
+		UserID: userID,
+	})
+	return transitionedCampaign(row, err)
+}
+
+func (r *CampaignRepository) Cancel(ctx context.Context, id int64, userID int64) (*campaign.Campaign, error) {
+	row, err := r.queries.CancelCampaign(ctx, db.CancelCampaignParams{
+		ID:     id,
+		UserID: userID,
+	})
+	return transitionedCampaign(row, err)
+}
+
+func (r *CampaignRepository) GetRecipients(ctx context.Context, campaignID int64, limit int) ([]*campaign.Recipient, error) {
+	rows, err := r.queries.ListCampaignRecipients(ctx, db.ListCampaignRecipientsParams{
+		CampaignID: campaignID,
+		Limit:      int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dbRecipientsToModels(rows), nil
+}
+
+func (r *CampaignRepository) GetStats(ctx context.Context, campaignID int64) (*campaign.Stats, error) {
+	rows, err := r.queries.CountCampaignRecipientsByStatus(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	stats := &campaign.Stats{}
+	for _, row := range rows {
+		n := int(row.Count)
+		switch row.Status {
+		case campaign.RecipientPending:
+			stats.Pending = n
+		case campaign.RecipientDispatched:
+			stats.Dispatched = n
+		case campaign.RecipientSent:
+			stats.Sent = n
+		case campaign.RecipientFailed:
+			stats.Failed = n
+		case campaign.RecipientSkipped:
+			stats.Skipped = n
+		}
+	}
+	return stats, nil
+}
+
+func (r *CampaignRepository) CountRecentDispatches(ctx context.Context, campaignID int64, since time.Time) (int, error) {
+	n, err := r.queries.CountRecentCampaignDispatches(ctx, db.CountRecentCampaignDispatchesParams{
+		CampaignID: campaignID,
+		Since:      pgtype.Timestamptz{Time: since, Valid: true},
+	})
+	return int(n), err
+}
+
+func (r *CampaignRepository) CountOpenRecipients(ctx context.Context, campaignID int64) (int, error) {
+	n, err := r.queries.CountOpenCampaignRecipients(ctx, campaignID)
+	return int(n), err
+}
+
+func (r *CampaignRepository) ClaimRecipients(ctx context.Context, campaignID int64, limit int) ([]*campaign.Recipient, error) {
+	rows, err := r.queries.ClaimCampaignRecipients(ctx, db.ClaimCampaignRecipientsParams{
+		CampaignID: campaignID,
+		Limit:      int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dbRecipientsToModels(rows), nil
+}
+
+func (r *CampaignRepository) ReportRecipient(ctx context.Context, userID int64, result campaign.Result) (int64, error) {
+	campaignID, err := r.queries.ReportCampaignRecipient(ctx, db.ReportCampaignRecipientParams{
+		Status: result.Status,
+		Error:  pgtype.Text{String: result.Error, Valid: result.Error != ""},
+		ID:     result.RecipientID,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, campaign.ErrCampaignNotFound
+		}
+		return 0, err
+	}
+	return campaignID, nil
+}
+
+func (r *CampaignRepository) SkipOpenRecipients(ctx context.Context, campaignID int64) error {
+	return r.queries.SkipOpenCampaignRecipients(ctx, campaignID)
+}
+
+func (r *CampaignRepository) DeleteQueuedJobs(ctx context.Context, campaignID int64) error {
+	return r.queries.DeleteQueuedCampaignJobs(ctx, campaignID)
+}
+
+// transitionedCampaign maps a status update that matched no row to ErrInvalidTransition.
+func transitionedCampaign(row db.Campaign, err error) (*campaign.Campaign, error) {
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, campaign.ErrInvalidTransition
+		}
+		return nil, err
+	}
+	return dbCampaignToModel(row)
+}
+
+func dbCampaignsToModels(rows []db.Campaign) ([]*campaign.Campaign, error) {
+	campaigns := make([]*campaign.Campaign, len(rows))
+	for i, row := range rows {
+		c, err := dbCampaignToModel(row)
+		if err != nil {
+			return nil, err
+		}
+		campaigns[i] = c
+	}
+	return campaigns, nil
+}
+
+func dbCampaignToModel(row db.Campaign) (*campaign.Campaign, error) {
+	var segment campaign.Segment
+	if err := json.Unmarshal(row.Segment, &segment); err != nil {
+		return nil, fmt.Errorf("failed to decode segment for campaign %d: %w", row.ID, err)
+	}
+	c := &campaign.Campaign{
+		ID:                row.ID,
+		UserID:            row.UserID,
+		Name:              row.Name,
+		TemplateID:        row.TemplateID,
+		Segment:           segment,
+		ScheduledAt:       row.ScheduledAt.Time,
+		ThrottlePerMinute: int(row.ThrottlePerMinute),
+		Status:            row.Status,
+		TotalRecipients:   int(row.TotalRecipients),
+		CreatedAt:         row.CreatedAt.Time,
+		UpdatedAt:         row.UpdatedAt.Time,
+	}
+	if row.StartedAt.Valid {
+		t := row.StartedAt.Time
+		c.StartedAt = &t
+	}
+	if row.CompletedAt.Valid {
+		t := row.CompletedAt.Time
+		c.CompletedAt = &t
+	}
+	return c, nil
+}
+
+func dbRecipientsToModels(rows []db.CampaignRecipient) []*campaign.Recipient {
+	recipients := make([]*campaign.Recipient, len(rows))
+	for i, row := range rows {
+		recipients[i] = dbRecipientToModel(row)
+	}
+	return recipients
+}
+
+func dbRecipientToModel(row db.CampaignRecipient) *campaign.Recipient {
+	rc := &campaign.Recipient{
+		ID:         row.ID,
+		CampaignID: row.CampaignID,
+		Phone:      row.Phone,
+		Status:     row.Status,
+		Attempts:   int(row.Attempts),
+		CreatedAt:  row.CreatedAt.Time,
+	}
+	if row.ContactID.Valid {
+		id := row.ContactID.Int64
+		rc.ContactID = &id
+	}
+	if row.Error.Valid {
+		rc.Error = row.Error.String
+	}
+	if row.DispatchedAt.Valid {
+		t := row.DispatchedAt.Time
+		rc.DispatchedAt = &t
+	}
+	if row.CompletedAt.Valid {
+		t := row.CompletedAt.Time
+		rc.CompletedAt = &t
+	}
+	return rc
+}
+
+// expandRecipients adds the contacts in the campaign's segment as recipients
+func expandRecipients(ctx context.Context, q *db.Queries, c *campaign.Campaign, calledAfter *time.Time) (int, error) {
+	tags := c.Segment.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	mode := c.Segment.ContactFilter
+	if mode == "" {
+		mode = campaign.ContactFilterAll
+	}
+	added, err := q.ExpandCampaignRecipients(ctx, db.ExpandCampaignRecipientsParams{
+		CampaignID:    c.ID,
+		UserID:        c.UserID,
+		Tags:          tags,
+		CalledAfter:   nullableTimestamptz(calledAfter),
+		CallDirection: pgtype.Text{String: c.Segment.LastCallDirection, Valid: c.Segment.LastCallDirection != ""},
+		ContactMode:   mode,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err := q.SetCampaignTotalRecipients(ctx, db.SetCampaignTotalRecipientsParams{
+		ID:              c.ID,
+		TotalRecipients: int32(added),
+	}); err != nil {
+		return 0, err
+	}
+	return int(added), nil
+}
@@ -2,10 +2,13 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"callflow/internal/domain/contact"
 	db "callflow/internal/sql/db"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -39,42 +42,149 @@ func (r *ContactRepository) GetByUserID(ctx context.Context, userID int64) ([]*c
 	return contacts, nil
 }
 
-func (r *ContactRepository) Upsert(ctx context.Context, userID int64, data contact.ContactUpsert) (*contact.Contact, error) {
+func (r *ContactRepository) GetByPhone(ctx context.Context, userID int64, phone string) (*contact.Contact, error) {
+	row, err := r.queries.GetContactByPhone(ctx, db.GetContactByPhoneParams{
+		UserID: userID,
+		Phone:  phone,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, contact.ErrContactNotFound
+		}
+		return nil, err
+	}
+	return dbContactToModel(row), nil
+}
+
+func (r *ContactRepository) Upsert(ctx context.Context, userID int64, data contact.ContactUpsert) (*contact.Contact, bool, error) {
 	row, err := r.queries.UpsertContact(ctx, db.UpsertContactParams{
 		UserID: userID,
 		Phone:  data.Phone,
 		Name:   pgtype.Text{String: data.Name, Valid: data.Name != ""},
 	})
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
-	return dbContactToModel(row), nil
+	return dbUpsertedContactToModel(row), row.Created, nil
 }
 
-func (r *ContactRepository) UpsertBatch(ctx context.Context, userID int64, contacts []contact.ContactUpsert) error {
+func (r *ContactRepository) UpsertBatch(ctx context.Context, userID int64, contacts []contact.ContactUpsert) ([]*contact.Contact, error) {
+	// One statement cannot update a row twice, so the last entry for a phone wins.
+	index := make(map[string]int, len(contacts))
+	phones := make([]string, 0, len(contacts))
+	names := make([]string, 0, len(contacts))
 	for _, c := range contacts {
-		err := r.queries.UpsertContactBatch(ctx, db.UpsertContactBatchParams{
-			UserID: userID,
-			Phone:  c.Phone,
-			Name:   pgtype.Text{String: c.Name, Valid: c.Name != ""},
-		})
-		if err != nil {
-			return err
+		if i, ok := index[c.Phone]; ok {
+			names[i] = c.Name
+			continue
+		}
+		index[c.Phone] = len(phones)
+		phones = append(phones, c.Phone)
+		names = append(names, c.Name)
+	}
+
+	rows, err := r.queries.UpsertContactBatch(ctx, db.UpsertContactBatchParams{
+		UserID: userID,
+		Phones: phones,
+		Names:  names,
+	})
+	if err != nil {
+		return nil, err
+	}
+	created := make([]*contact.Contact, 0, len(rows))
+	for _, row := range rows {
+		if !row.Created {
+			continue
 		}
+		created = append(created, dbUpsertedContactToModel(db.UpsertContactRow(row)))
 	}
-	return nil
+	return created, nil
 }
 
-func dbContactToModel(row db.Contact) *contact.Contact {
-	var name string
-	if row.Name.Valid {
-		name = row.Name.String
+func (r *ContactRepository) RecordCall(ctx context.Context, userID int64, event contact.CallEvent) (*contact.Contact, bool, error) {
+	calledAt := time.Now()
+	if event.OccurredAt != nil {
+		calledAt = *event.OccurredAt
+	}
+	row, err := r.queries.RecordContactCall(ctx, db.RecordContactCallParams{
+		UserID:            userID,
+		Phone:             event.Phone,
+		Name:              pgtype.Text{String: event.Name, Valid: event.Name != ""},
+		LastCalledAt:      pgtype.Timestamptz{Time: calledAt, Valid: true},
+		LastCallDirection: pgtype.Text{String: event.Direction, Valid: true},
+	})
+	if err != nil {
+		return nil, false, err
 	}
-	return &contact.Contact{
+	return dbUpsertedContactToModel(db.UpsertContactRow(row)), row.Created, nil
+}
+
+func (r *ContactRepository) OptOut(ctx context.Context, userID int64, phone string) (*contact.Contact, bool, error) {
+	row, err := r.queries.OptOutContact(ctx, db.OptOutContactParams{
+		UserID: userID,
+		Phone:  phone,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return dbUpsertedContactToModel(db.UpsertContactRow(row)), row.Created, nil
+}
+
+func (r *ContactRepository) SetTags(ctx context.Context, id int64, userID int64, tags []string) (*contact.Contact, error) {
+	row, err := r.queries.SetContactTags(ctx, db.SetContactTagsParams{
+		ID:     id,
+		UserID: userID,
+		Tags:   tags,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, contact.ErrContactNotFound
+		}
+		return nil, err
+	}
+	return dbContactToModel(row), nil
+}
+
+func dbContactToModel(row db.Contact) *contact.Contact {
+	c := &contact.Contact{
 		ID:        row.ID,
 		UserID:    row.UserID,
 		Phone:     row.Phone,
-		Name:      name,
+		Tags:      row.Tags,
 		CreatedAt: row.CreatedAt.Time,
 	}
+	if c.Tags == nil {
+		c.Tags = []string{}
+	}
+	if row.Name.Valid {
+		c.Name = row.Name.String
+	}
+	if row.LastCalledAt.Valid {
+		t := row.LastCalledAt.Time
+		c.LastCalledAt = &t
+	}
+	if row.LastCallDirection.Valid {
+		c.LastCallDirection = row.LastCallDirection.String
+	}
+	if row.OptedOutAt.Valid {
+		t := row.OptedOutAt.Time
+		c.OptedOutAt = &t
+	}
+	return c
+}
+
+// dbUpsertedContactToModel converts a row returned by one of the contact
+// upserts, which all return the contact with its created flag.
+func dbUpsertedContactToModel(row db.UpsertContactRow) *contact.Contact {
+	return dbContactToModel(db.Contact{
+		ID:                row.ID,
+		UserID:            row.UserID,
+		Phone:             row.Phone,
+		Name:              row.Name,
+		CreatedAt:         row.CreatedAt,
+		LastCalledAt:      row.LastCalledAt,
+		LastCallDirection: row.LastCallDirection,
+		OptedOutAt:        row.OptedOutAt,
+		Tags:              row.Tags,
+	})
 }
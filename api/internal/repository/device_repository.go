@@ -0,0 +1,466 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"callflow/internal/domain/device"
+	db "callflow/internal/sql/db"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DeviceRepository implements device.Repository
+type DeviceRepository struct {
+	pool    *pgxpool.Pool
+	queries *db.Queries
+}
+
+// NewDeviceRepository creates a new device repository
+func NewDeviceRepository(pool *pgxpool.Pool) *DeviceRepository {
+	return &DeviceRepository{
+		pool:    pool,
+		queries: db.New(pool),
+	}
+}
+
+func (r *DeviceRepository) Upsert(ctx context.Context, userID int64, data device.Registration) (*device.Device, error) {
+	sims := data.SIMs
+	if sims == nil {
+		sims = []device.SIM{}
+	}
+	simInfo, err := json.Marshal(sims)
+	if err != nil {
+		return nil, err
+	}
+	row, err := r.queries.UpsertDevice(ctx, db.UpsertDeviceParams{
+		UserID:     userID,
+		DeviceID:   data.DeviceID,
+		Name:       pgtype.Text{String: data.Name, Valid: data.Name != ""},
+		Model:      pgtype.Text{String: data.Model, Valid: data.Model != ""},
+		AppVersion: pgtype.Text{String: data.AppVersion, Valid: data.AppVersion != ""},
+		SimInfo:    simInfo,
+		PushToken:  pgtype.Text{String: data.PushToken, Valid: data.PushToken != ""},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dbDeviceToModel(row)
+}
+
+func (r *DeviceRepository) GetByUserID(ctx context.Context, userID int64) ([]*device.Device, error) {
+	rows, err := r.queries.ListDevicesByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	devices := make([]*device.Device, len(rows))
+	for i, row := range rows {
+		d, err := dbDeviceToModel(row)
+		if err != nil {
+			return nil, err
+		}
+		devices[i] = d
+	}
+	return devices, nil
+}
+
+func (r *DeviceRepository) GetByID(ctx context.Context, id int64, userID int64) (*device.Device, error) {
+	row, err := r.queries.GetDeviceByID(ctx, db.GetDeviceByIDParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, device.ErrDeviceNotFound
+		}
+		return nil, err
+	}
+	return dbDeviceToModel(row)
+}
+
+func (r *DeviceRepository) GetByDeviceID(ctx context.Context, userID int64, deviceID string) (*device.Device, error) {
+	row, err := r.queries.GetDeviceByDeviceID(ctx, db.GetDeviceByDeviceIDParams{
+		UserID:   userID,
+		DeviceID: deviceID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, device.ErrDeviceNotFound
+		}
+		return nil, err
+	}
+	return dbDeviceToModel(row)
+}
+
+func (r *DeviceRepository) GetAssignedLines(ctx context.Context, userID int64) ([]string, error) {
+	return r.queries.ListAssignedSMSLines(ctx, userID)
+}
+
+func (r *DeviceRepository) SetSMSLine(ctx context.Context, id int64, userID int64, line string) (*device.Device, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	q := r.queries.WithTx(tx)
+	if line != "" {
+		if err := q.ClearDeviceSMSLine(ctx, db.ClearDeviceSMSLineParams{
+			UserID:  userID,
+			SmsLine: pgtype.Text{String: line, Valid: true},
+		}); err != nil {
+			return nil, err
+		}
+	}
+	row, err := q.SetDeviceSMSLine(ctx, db.SetDeviceSMSLineParams{
+		ID:      id,
+		UserID:  userID,
+		SmsLine: pgtype.Text{String: line, Valid: line != ""},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, device.ErrDeviceNotFound
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, device.ErrLineAssigned
+		}
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return dbDeviceToModel(row)
+}
+
+func (r *DeviceRepository) Revoke(ctx context.Context, id int64, userID int64) (*device.Device, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	q := r.queries.WithTx(tx)
+	row, err := q.RevokeDevice(ctx, db.RevokeDeviceParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, device.ErrDeviceNotFound
+		}
+		return nil, err
+	}
+	if err := q.RevokeDeviceTokens(ctx, pgtype.Int8{Int64: id, Valid: true}); err != nil {
+		return nil, err
+	}
+	// Legacy tokens carry no device, so signing a device out retires all of them.
+	if err := q.InvalidateUserTokens(ctx, userID); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return dbDeviceToModel(row)
+}
+
+func (r *DeviceRepository) RecordHeartbeat(ctx context.Context, id int64, hb device.Heartbeat, health device.Health) (*device.Device, error) {
+	permissions, err := json.Marshal(health.Permissions)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := json.Marshal(health)
+	if err != nil {
+		return nil, err
+	}
+	appVersion := pgtype.Text{String: hb.AppVersion, Valid: hb.AppVersion != ""}
+
+	if err := r.queries.CreateDeviceHeartbeat(ctx, db.CreateDeviceHeartbeatParams{
+		DeviceID:         id,
+		BatteryOptimized: hb.BatteryOptimized,
+		Permissions:      permissions,
+		AppVersion:       appVersion,
+		QueueDepth:       int32(hb.QueueDepth),
+		LastCallAt:       nullableTimestamptz(hb.LastCallAt),
+	}); err != nil {
+		return nil, err
+	}
+
+	row, err := r.queries.UpdateDeviceHealth(ctx, db.UpdateDeviceHealthParams{
+		ID:         id,
+		Health:     snapshot,
+		Healthy:    len(health.Problems) == 0,
+		AppVersion: appVersion,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, device.ErrDeviceNotFound
+		}
+		return nil, err
+	}
+	return dbDeviceToModel(row)
+}
+
+func (r *DeviceRepository) OpenAlert(ctx context.Context, d *device.Device, kind, message string) error {
+	return r.queries.OpenDeviceAlert(ctx, db.OpenDeviceAlertParams{
+		DeviceID: d.ID,
+		UserID:   d.UserID,
+		Kind:     kind,
+		Message:  message,
+	})
+}
+
+func (r *DeviceRepository) ResolveAlert(ctx context.Context, deviceID int64, kind string) error {
+	return r.queries.ResolveDeviceAlert(ctx, db.ResolveDeviceAlertParams{
+		DeviceID: deviceID,
+		Kind:     kind,
+	})
+}
+
+func (r *DeviceRepository) OpenSilentAlerts(ctx context.Context, silentBefore time.Time) (int64, error) {
+	return r.queries.OpenSilentDeviceAlerts(ctx, pgtype.Timestamptz{Time: silentBefore, Valid: true})
+}
+
+func (r *DeviceRepository) DeleteHeartbeatsBefore(ctx context.Context, before time.Time) (int64, error) {
+	return r.queries.DeleteDeviceHeartbeatsBefore(ctx, pgtype.Timestamptz{Time: before, Valid: true})
+}
+
+func (r *DeviceRepository) GetUnhealthy(ctx context.Context, silentBefore time.Time) ([]*device.Device, error) {
+	rows, err := r.queries.ListUnhealthyDevices(ctx, pgtype.Timestamptz{Time: silentBefore, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	devices := make([]*device.Device, len(rows))
+	for i, row := range rows {
+		d, err := dbDeviceToModel(row)
+		if err != nil {
+			return nil, err
+		}
+		devices[i] = d
+	}
+	return devices, nil
+}
+
+func (r *DeviceRepository) GetOpenAlerts(ctx context.Context, limit int) ([]*device.Alert, error) {
+	rows, err := r.queries.ListOpenDeviceAlerts(ctx, int32(limit))
+	if err != nil {
+		return nil, err
+	}
+	alerts := make([]*device.Alert, len(rows))
+	for i, row := range rows {
+		a := &device.Alert{
+			ID:        row.ID,
+			DeviceID:  row.DeviceID,
+			UserID:    row.UserID,
+			Kind:      row.Kind,
+			Message:   row.Message,
+			CreatedAt: row.CreatedAt.Time,
+		}
+		if row.ResolvedAt.Valid {
+			t := row.ResolvedAt.Time
+			a.ResolvedAt = &t
+		}
+		alerts[i] = a
+	}
+	return alerts, nil
+}
+
+func (r *DeviceRepository) CreateJob(ctx context.Context, userID int64, data device.JobCreate) (*device.Job, error) {
+	return createDeviceJob(ctx, r.queries, userID, data)
+}
+
+// createDeviceJob queues a job through q, so other repositories can queue one
+// inside their own transaction.
+func createDeviceJob(ctx context.Context, q *db.Queries, userID int64, data device.JobCreate) (*device.Job, error) {
+	runAfter := time.Now()
+	if data.RunAfter != nil {
+		runAfter = *data.RunAfter
+	}
+	maxAttempts := data.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = device.DefaultMaxAttempts
+	}
+	row, err := q.CreateDeviceJob(ctx, db.CreateDeviceJobParams{
+		UserID:      userID,
+		DeviceID:    pgtype.Text{String: data.DeviceID, Valid: data.DeviceID != ""},
+		JobType:     data.Type,
+		Payload:     data.Payload,
+		MaxAttempts: int32(maxAttempts),
+		RunAfter:    pgtype.Timestamptz{Time: runAfter, Valid: true},
+		Source:      pgtype.Text{String: data.Source, Valid: data.Source != ""},
+		RefID:       nullableInt8(data.RefID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dbDeviceJobToModel(row), nil
+}
+
+func (r *DeviceRepository) GetJobs(ctx context.Context, userID int64, limit int) ([]*device.Job, error) {
+	rows, err := r.queries.ListDeviceJobs(ctx, db.ListDeviceJobsParams{
+		UserID: userID,
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dbDeviceJobsToModels(rows), nil
+}
+
+func (r *DeviceRepository) FailExhaustedJobs(ctx context.Context, userID int64) ([]*device.Job, error) {
+	rows, err := r.queries.FailExhaustedDeviceJobs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return dbDeviceJobsToModels(rows), nil
+}
+
+func (r *DeviceRepository) LeaseJobs(ctx context.Context, userID int64, deviceID, leaseToken string, expiresAt time.Time, limit int) ([]*device.Job, error) {
+	rows, err := r.queries.LeaseDeviceJobs(ctx, db.LeaseDeviceJobsParams{
+		LeaseToken:     pgtype.Text{String: leaseToken, Valid: true},
+		DeviceID:       deviceID,
+		LeaseExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+		UserID:         userID,
+		MaxJobs:        int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dbDeviceJobsToModels(rows), nil
+}
+
+func (r *DeviceRepository) AckJob(ctx context.Context, userID int64, jobID int64, leaseToken string) (*device.Job, error) {
+	row, err := r.queries.AckDeviceJob(ctx, db.AckDeviceJobParams{
+		ID:         jobID,
+		UserID:     userID,
+		LeaseToken: pgtype.Text{String: leaseToken, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, device.ErrJobNotFound
+		}
+		return nil, err
+	}
+	return dbDeviceJobToModel(row), nil
+}
+
+func (r *DeviceRepository) FailJob(ctx context.Context, userID int64, data device.FailRequest) (*device.Job, error) {
+	row, err := r.queries.FailDeviceJob(ctx, db.FailDeviceJobParams{
+		Retryable:  boolOrDefault(data.Retryable, true),
+		LastError:  pgtype.Text{String: data.Error, Valid: true},
+		ID:         data.JobID,
+		UserID:     userID,
+		LeaseToken: pgtype.Text{String: data.LeaseToken, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, device.ErrJobNotFound
+		}
+		return nil, err
+	}
+	return dbDeviceJobToModel(row), nil
+}
+
+func dbDeviceToModel(row db.Device) (*device.Device, error) {
+	var sims []device.SIM
+	if err := json.Unmarshal(row.SimInfo, &sims); err != nil {
+		return nil, fmt.Errorf("failed to decode sim info for device %d: %w", row.ID, err)
+	}
+	d := &device.Device{
+		ID:        row.ID,
+		UserID:    row.UserID,
+		DeviceID:  row.DeviceID,
+		SIMs:      sims,
+		Healthy:   row.Healthy,
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+	if len(row.Health) > 0 {
+		var health device.Health
+		if err := json.Unmarshal(row.Health, &health); err != nil {
+			return nil, fmt.Errorf("failed to decode health for device %d: %w", row.ID, err)
+		}
+		d.Health = &health
+	}
+	if row.LastHeartbeatAt.Valid {
+		t := row.LastHeartbeatAt.Time
+		d.LastHeartbeatAt = &t
+	}
+	if row.Name.Valid {
+		d.Name = row.Name.String
+	}
+	if row.Model.Valid {
+		d.Model = row.Model.String
+	}
+	if row.AppVersion.Valid {
+		d.AppVersion = row.AppVersion.String
+	}
+	if row.PushToken.Valid {
+		d.PushToken = row.PushToken.String
+	}
+	if row.SmsLine.Valid {
+		d.SMSLine = row.SmsLine.String
+	}
+	if row.LastSeenAt.Valid {
+		t := row.LastSeenAt.Time
+		d.LastSeenAt = &t
+	}
+	return d, nil
+}
+
+func dbDeviceJobsToModels(rows []db.DeviceJob) []*device.Job {
+	jobs := make([]*device.Job, len(rows))
+	for i, row := range rows {
+		jobs[i] = dbDeviceJobToModel(row)
+	}
+	return jobs
+}
+
+func dbDeviceJobToModel(row db.DeviceJob) *device.Job {
+	j := &device.Job{
+		ID:          row.ID,
+		UserID:      row.UserID,
+		Type:        row.JobType,
+		Payload:     row.Payload,
+		Status:      row.Status,
+		Attempts:    int(row.Attempts),
+		MaxAttempts: int(row.MaxAttempts),
+		RunAfter:    row.RunAfter.Time,
+		CreatedAt:   row.CreatedAt.Time,
+		UpdatedAt:   row.UpdatedAt.Time,
+	}
+	if row.DeviceID.Valid {
+		j.DeviceID = row.DeviceID.String
+	}
+	if row.LeaseToken.Valid {
+		j.LeaseToken = row.LeaseToken.String
+	}
+	if row.LeasedBy.Valid {
+		j.LeasedBy = row.LeasedBy.String
+	}
+	if row.LeaseExpiresAt.Valid {
+		t := row.LeaseExpiresAt.Time
+		j.LeaseExpiresAt = &t
+	}
+	if row.Source.Valid {
+		j.Source = row.Source.String
+	}
+	if row.RefID.Valid {
+		v := row.RefID.Int64
+		j.RefID = &v
+	}
+	if row.LastError.Valid {
+		j.LastError = row.LastError.String
+	}
+	if row.CompletedAt.Valid {
+		t := row.CompletedAt.Time
+		j.CompletedAt = &t
+	}
+	return j
+}
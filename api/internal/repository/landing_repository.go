@@ -2,12 +2,16 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"time"
 
 	"callflow/internal/domain/landing"
 	db "callflow/internal/sql/db"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -35,34 +39,470 @@ func (r *LandingRepository) GetByUserID(ctx context.Context, userID int64) (*lan
 		}
 		return nil, err
 	}
-	return dbLandingToModel(row), nil
+	return dbLandingToModel(row)
 }
 
-func (r *LandingRepository) UpsertByUserID(ctx context.Context, userID int64, data landing.LandingUpsert) (*landing.Landing, error) {
-	row, err := r.queries.UpsertLandingByUserID(ctx, db.UpsertLandingByUserIDParams{
+func (r *LandingRepository) GetWithImages(ctx context.Context) ([]*landing.Landing, error) {
+	rows, err := r.queries.ListLandingsWithImage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	landings := make([]*landing.Landing, len(rows))
+	for i, row := range rows {
+		landings[i], err = dbLandingToModel(row)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return landings, nil
+}
+
+func (r *LandingRepository) ReplaceImage(ctx context.Context, userID int64, oldKey, imageURL, imageKey string) error {
+	n, err := r.queries.ReplaceLandingImage(ctx, db.ReplaceLandingImageParams{
+		ImageUrl:    pgtype.Text{String: imageURL, Valid: true},
+		ImageKey:    pgtype.Text{String: imageKey, Valid: true},
+		UserID:      userID,
+		OldImageKey: pgtype.Text{String: oldKey, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return landing.ErrLandingNotFound
+	}
+	return nil
+}
+
+func (r *LandingRepository) GetDraft(ctx context.Context, userID int64) (*landing.Draft, error) {
+	row, err := r.queries.GetLandingDraftByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, landing.ErrDraftNotFound
+		}
+		return nil, err
+	}
+	return dbLandingDraftToModel(row)
+}
+
+func (r *LandingRepository) SaveDraft(ctx context.Context, userID int64, previewToken string, data landing.LandingUpsert) (*landing.Draft, error) {
+	params, err := landingUpsertParams(userID, data)
+	if err != nil {
+		return nil, err
+	}
+	row, err := r.queries.UpsertLandingDraftByUserID(ctx, db.UpsertLandingDraftByUserIDParams{
+		UserID:          params.UserID,
+		Headline:        params.Headline,
+		Description:     params.Description,
+		ImageUrl:        params.ImageUrl,
+		ImageKey:        params.ImageKey,
+		WhatsappUrl:     params.WhatsappUrl,
+		FacebookUrl:     params.FacebookUrl,
+		InstagramUrl:    params.InstagramUrl,
+		YoutubeUrl:      params.YoutubeUrl,
+		Email:           params.Email,
+		WebsiteUrl:      params.WebsiteUrl,
+		MediaID:         params.MediaID,
+		Sections:        params.Sections,
+		SectionsVersion: params.SectionsVersion,
+		GalleryMediaIds: params.GalleryMediaIds,
+		PreviewToken:    previewToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dbLandingDraftToModel(row)
+}
+
+func (r *LandingRepository) SetPreviewToken(ctx context.Context, userID int64, previewToken string) (*landing.Draft, error) {
+	row, err := r.queries.SetLandingDraftPreviewToken(ctx, db.SetLandingDraftPreviewTokenParams{
+		UserID:       userID,
+		PreviewToken: previewToken,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, landing.ErrDraftNotFound
+		}
+		return nil, err
+	}
+	return dbLandingDraftToModel(row)
+}
+
+func (r *LandingRepository) DeleteDraft(ctx context.Context, userID int64) error {
+	n, err := r.queries.DeleteLandingDraft(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return landing.ErrDraftNotFound
+	}
+	return nil
+}
+
+func (r *LandingRepository) PublishDraft(ctx context.Context, userID int64, data landing.LandingUpsert) (*landing.Landing, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	q := r.queries.WithTx(tx)
+	l, err := publishLanding(ctx, q, userID, data)
+	if err != nil {
+		return nil, err
+	}
+	// A draft discarded or published in the meantime leaves nothing to publish.
+	n, err := q.DeleteLandingDraft(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, landing.ErrDraftNotFound
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (r *LandingRepository) GetRevisions(ctx context.Context, userID int64, limit int) ([]*landing.Revision, error) {
+	rows, err := r.queries.ListLandingRevisionsByUserID(ctx, db.ListLandingRevisionsByUserIDParams{
+		UserID: userID,
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	revisions := make([]*landing.Revision, len(rows))
+	for i, row := range rows {
+		rev := &landing.Revision{
+			ID:          row.ID,
+			Number:      int(row.Number),
+			PublishedAt: row.PublishedAt.Time,
+		}
+		if row.Headline.Valid {
+			rev.Headline = &row.Headline.String
+		}
+		revisions[i] = rev
+	}
+	return revisions, nil
+}
+
+func (r *LandingRepository) GetRevision(ctx context.Context, id, userID int64) (*landing.Revision, error) {
+	row, err := r.queries.GetLandingRevisionByID(ctx, db.GetLandingRevisionByIDParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, landing.ErrRevisionNotFound
+		}
+		return nil, err
+	}
+	return dbLandingRevisionToModel(row)
+}
+
+func (r *LandingRepository) GetSlug(ctx context.Context, slug string) (*landing.Slug, error) {
+	row, err := r.queries.GetLandingSlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, landing.ErrSlugNotFound
+		}
+		return nil, err
+	}
+	return dbLandingSlugToModel(row), nil
+}
+
+func (r *LandingRepository) GetCurrentSlug(ctx context.Context, userID int64) (*landing.Slug, error) {
+	row, err := r.queries.GetCurrentLandingSlug(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, landing.ErrSlugNotFound
+		}
+		return nil, err
+	}
+	return dbLandingSlugToModel(row), nil
+}
+
+func (r *LandingRepository) SetCurrentSlug(ctx context.Context, userID int64, slug string) (*landing.Slug, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	q := r.queries.WithTx(tx)
+	if err := q.ClearCurrentLandingSlug(ctx, userID); err != nil {
+		return nil, err
+	}
+	// The upsert only reclaims a slug from the user's own history, so no row
+	// comes back when another user owns it.
+	row, err := q.SetCurrentLandingSlug(ctx, db.SetCurrentLandingSlugParams{
+		Slug:   slug,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, landing.ErrSlugTaken
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, landing.ErrSlugTaken
+		}
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return dbLandingSlugToModel(row), nil
+}
+
+func (r *LandingRepository) CreateLink(ctx context.Context, userID int64, token string, data landing.LinkCreate) (*landing.Link, error) {
+	row, err := r.queries.CreateLandingLink(ctx, db.CreateLandingLinkParams{
+		UserID: userID,
+		Token:  token,
+		Source: data.Source,
+		RefID:  nullableInt8(data.RefID),
+		Phone:  nullableLandingText(data.Phone),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dbLandingLinkToModel(row), nil
+}
+
+func (r *LandingRepository) RecordEvent(ctx context.Context, userID int64, event, target, token string) (bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	q := r.queries.WithTx(tx)
+	attributed := false
+	if token != "" {
+		n, err := q.RecordLandingLinkVisit(ctx, db.RecordLandingLinkVisitParams{
+			Event:  event,
+			Token:  token,
+			UserID: userID,
+		})
+		if err != nil {
+			return false, err
+		}
+		attributed = n > 0
+	}
+	if err := q.IncrementLandingDailyStat(ctx, db.IncrementLandingDailyStatParams{
+		UserID:     userID,
+		Day:        pgtype.Date{Time: time.Now().UTC(), Valid: true},
+		Event:      event,
+		Target:     target,
+		Attributed: attributed,
+	}); err != nil {
+		return false, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return false, err
+	}
+	return attributed, nil
+}
+
+func (r *LandingRepository) GetDailyStats(ctx context.Context, userID int64, from, to time.Time) ([]*landing.DailyStat, error) {
+	rows, err := r.queries.ListLandingDailyStats(ctx, db.ListLandingDailyStatsParams{
+		UserID:  userID,
+		FromDay: pgtype.Date{Time: from, Valid: true},
+		ToDay:   pgtype.Date{Time: to, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	stats := make([]*landing.DailyStat, len(rows))
+	for i, row := range rows {
+		stats[i] = &landing.DailyStat{
+			Day:        row.Day.Time,
+			Event:      row.Event,
+			Target:     row.Target,
+			Attributed: row.Attributed,
+			Count:      int(row.Count),
+		}
+	}
+	return stats, nil
+}
+
+func (r *LandingRepository) GetVisitedLinks(ctx context.Context, userID int64, since time.Time, limit int) ([]*landing.Link, error) {
+	rows, err := r.queries.ListVisitedLandingLinks(ctx, db.ListVisitedLandingLinksParams{
+		UserID:       userID,
+		VisitedSince: pgtype.Timestamptz{Time: since, Valid: true},
+		MaxLinks:     int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	links := make([]*landing.Link, len(rows))
+	for i, row := range rows {
+		links[i] = dbLandingLinkToModel(row)
+	}
+	return links, nil
+}
+
+func dbLandingLinkToModel(row db.LandingLink) *landing.Link {
+	l := &landing.Link{
+		ID:        row.ID,
+		Token:     row.Token,
+		Source:    row.Source,
+		Views:     int(row.Views),
+		Clicks:    int(row.Clicks),
+		CreatedAt: row.CreatedAt.Time,
+	}
+	if row.RefID.Valid {
+		v := row.RefID.Int64
+		l.RefID = &v
+	}
+	if row.Phone.Valid {
+		v := row.Phone.String
+		l.Phone = &v
+	}
+	if row.FirstVisitAt.Valid {
+		t := row.FirstVisitAt.Time
+		l.FirstVisitAt = &t
+	}
+	if row.LastVisitAt.Valid {
+		t := row.LastVisitAt.Time
+		l.LastVisitAt = &t
+	}
+	return l
+}
+
+// dbLandingRevisionToModel converts a revision with its full page content
+func dbLandingRevisionToModel(row db.LandingRevision) (*landing.Revision, error) {
+	l, err := dbLandingToModel(db.LandingPage{
+		ID:              row.ID,
+		UserID:          row.UserID,
+		Headline:        row.Headline,
+		Description:     row.Description,
+		ImageUrl:        row.ImageUrl,
+		ImageKey:        row.ImageKey,
+		WhatsappUrl:     row.WhatsappUrl,
+		FacebookUrl:     row.FacebookUrl,
+		InstagramUrl:    row.InstagramUrl,
+		YoutubeUrl:      row.YoutubeUrl,
+		Email:           row.Email,
+		WebsiteUrl:      row.WebsiteUrl,
+		CreatedAt:       row.PublishedAt,
+		UpdatedAt:       row.PublishedAt,
+		MediaID:         row.MediaID,
+		Sections:        row.Sections,
+		SectionsVersion: row.SectionsVersion,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &landing.Revision{
+		ID:          row.ID,
+		Number:      int(row.Number),
+		Headline:    l.Headline,
+		PublishedAt: row.PublishedAt.Time,
+		Landing:     l,
+	}, nil
+}
+
+func dbLandingSlugToModel(row db.LandingSlug) *landing.Slug {
+	return &landing.Slug{
+		Slug:      row.Slug,
+		UserID:    row.UserID,
+		IsCurrent: row.IsCurrent,
+		CreatedAt: row.CreatedAt.Time,
+	}
+}
+
+// publishLanding upserts the live page and records it as the user's next
+// revision, pruning those beyond landing.MaxRevisions.
+func publishLanding(ctx context.Context, q *db.Queries, userID int64, data landing.LandingUpsert) (*landing.Landing, error) {
+	params, err := landingUpsertParams(userID, data)
+	if err != nil {
+		return nil, err
+	}
+	row, err := q.UpsertLandingByUserID(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	rev, err := q.CreateLandingRevision(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := q.PruneLandingRevisions(ctx, db.PruneLandingRevisionsParams{
 		UserID:       userID,
-		Headline:     nullableLandingText(data.Headline),
-		Description:  nullableLandingText(data.Description),
-		ImageUrl:     nullableLandingText(data.ImageURL),
-		ImageKey:     nullableLandingText(data.ImageKey),
-		WhatsappUrl:  nullableLandingText(data.WhatsappURL),
-		FacebookUrl:  nullableLandingText(data.FacebookURL),
-		InstagramUrl: nullableLandingText(data.InstagramURL),
-		YoutubeUrl:   nullableLandingText(data.YoutubeURL),
-		Email:        nullableLandingText(data.Email),
-		WebsiteUrl:   nullableLandingText(data.WebsiteURL),
+		NewestNumber: rev.Number,
+		Keep:         landing.MaxRevisions,
+	}); err != nil {
+		return nil, err
+	}
+	return dbLandingToModel(row)
+}
+
+func landingUpsertParams(userID int64, data landing.LandingUpsert) (db.UpsertLandingByUserIDParams, error) {
+	var sections []*landing.Section
+	if data.Sections != nil {
+		sections = *data.Sections
+	}
+	sectionsJSON, err := json.Marshal(sections)
+	if err != nil {
+		return db.UpsertLandingByUserIDParams{}, err
+	}
+	if sections == nil {
+		sectionsJSON = []byte("[]")
+	}
+
+	return db.UpsertLandingByUserIDParams{
+		UserID:          userID,
+		Headline:        nullableLandingText(data.Headline),
+		Description:     nullableLandingText(data.Description),
+		ImageUrl:        nullableLandingText(data.ImageURL),
+		ImageKey:        nullableLandingText(data.ImageKey),
+		MediaID:         nullableInt8(data.MediaID),
+		WhatsappUrl:     nullableLandingText(data.WhatsappURL),
+		FacebookUrl:     nullableLandingText(data.FacebookURL),
+		InstagramUrl:    nullableLandingText(data.InstagramURL),
+		YoutubeUrl:      nullableLandingText(data.YoutubeURL),
+		Email:           nullableLandingText(data.Email),
+		WebsiteUrl:      nullableLandingText(data.WebsiteURL),
+		Sections:        sectionsJSON,
+		SectionsVersion: landing.SectionsVersion,
+		GalleryMediaIds: galleryMediaIDs(sections),
+	}, nil
+}
+
+func dbLandingDraftToModel(row db.LandingDraft) (*landing.Draft, error) {
+	l, err := dbLandingToModel(db.LandingPage{
+		ID:              row.ID,
+		UserID:          row.UserID,
+		Headline:        row.Headline,
+		Description:     row.Description,
+		ImageUrl:        row.ImageUrl,
+		ImageKey:        row.ImageKey,
+		WhatsappUrl:     row.WhatsappUrl,
+		FacebookUrl:     row.FacebookUrl,
+		InstagramUrl:    row.InstagramUrl,
+		YoutubeUrl:      row.YoutubeUrl,
+		Email:           row.Email,
+		WebsiteUrl:      row.WebsiteUrl,
+		CreatedAt:       row.CreatedAt,
+		UpdatedAt:       row.UpdatedAt,
+		MediaID:         row.MediaID,
+		Sections:        row.Sections,
+		SectionsVersion: row.SectionsVersion,
+		GalleryMediaIds: row.GalleryMediaIds,
 	})
 	if err != nil {
 		return nil, err
 	}
-	return dbLandingToModel(row), nil
+	return &landing.Draft{Landing: l, PreviewToken: row.PreviewToken}, nil
 }
 
-func dbLandingToModel(row db.LandingPage) *landing.Landing {
+func dbLandingToModel(row db.LandingPage) (*landing.Landing, error) {
 	var headline *string
 	var description *string
 	var imageURL *string
 	var imageKey *string
+	var mediaID *int64
 	var whatsappURL *string
 	var facebookURL *string
 	var instagramURL *string
@@ -82,6 +522,9 @@ func dbLandingToModel(row db.LandingPage) *landing.Landing {
 	if row.ImageKey.Valid {
 		imageKey = &row.ImageKey.String
 	}
+	if row.MediaID.Valid {
+		mediaID = &row.MediaID.Int64
+	}
 	if row.WhatsappUrl.Valid {
 		whatsappURL = &row.WhatsappUrl.String
 	}
@@ -100,23 +543,58 @@ func dbLandingToModel(row db.LandingPage) *landing.Landing {
 	if row.WebsiteUrl.Valid {
 		websiteURL = &row.WebsiteUrl.String
 	}
+	sections, err := decodeLandingSections(int(row.SectionsVersion), row.Sections)
+	if err != nil {
+		return nil, err
+	}
 
 	return &landing.Landing{
-		ID:           row.ID,
-		UserID:       row.UserID,
-		Headline:     headline,
-		Description:  description,
-		ImageURL:     imageURL,
-		ImageKey:     imageKey,
-		WhatsappURL:  whatsappURL,
-		FacebookURL:  facebookURL,
-		InstagramURL: instagramURL,
-		YoutubeURL:   youtubeURL,
-		Email:        email,
-		WebsiteURL:   websiteURL,
-		CreatedAt:    row.CreatedAt.Time,
-		UpdatedAt:    row.UpdatedAt.Time,
+		ID:              row.ID,
+		UserID:          row.UserID,
+		Headline:        headline,
+		Description:     description,
+		ImageURL:        imageURL,
+		ImageKey:        imageKey,
+		MediaID:         mediaID,
+		WhatsappURL:     whatsappURL,
+		FacebookURL:     facebookURL,
+		InstagramURL:    instagramURL,
+		YoutubeURL:      youtubeURL,
+		Email:           email,
+		WebsiteURL:      websiteURL,
+		Sections:        sections,
+		SectionsVersion: landing.SectionsVersion,
+		CreatedAt:       row.CreatedAt.Time,
+		UpdatedAt:       row.UpdatedAt.Time,
+	}, nil
+}
+
+// decodeLandingSections reads sections stored with the given schema version
+// and upgrades them to landing.SectionsVersion.
+func decodeLandingSections(version int, raw []byte) ([]*landing.Section, error) {
+	switch version {
+	case 1:
+		sections := []*landing.Section{}
+		if len(raw) > 0 {
+			if err := json.Unmarshal(raw, &sections); err != nil {
+				return nil, err
+			}
+		}
+		return sections, nil
+	default:
+		return nil, fmt.Errorf("unknown landing sections version %d", version)
+	}
+}
+
+// galleryMediaIDs lists the media used by gallery sections
+func galleryMediaIDs(sections []*landing.Section) []int64 {
+	ids := []int64{}
+	for _, sec := range sections {
+		for _, img := range sec.Images {
+			ids = append(ids, img.MediaID)
+		}
 	}
+	return ids
 }
 
 func nullableLandingText(v *string) pgtype.Text {
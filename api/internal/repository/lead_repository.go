@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"callflow/internal/domain/lead"
+	db "callflow/internal/sql/db"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// LeadRepository implements lead.Repository
+type LeadRepository struct {
+	pool    *pgxpool.Pool
+	queries *db.Queries
+}
+
+// NewLeadRepository creates a new lead repository
+func NewLeadRepository(pool *pgxpool.Pool) *LeadRepository {
+	return &LeadRepository{
+		pool:    pool,
+		queries: db.New(pool),
+	}
+}
+
+func (r *LeadRepository) SaveEndpoint(ctx context.Context, userID int64, token, secret string) (*lead.Endpoint, error) {
+	row, err := r.queries.UpsertLeadEndpoint(ctx, db.UpsertLeadEndpointParams{
+		UserID: userID,
+		Token:  token,
+		Secret: secret,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dbLeadEndpointToModel(row), nil
+}
+
+func (r *LeadRepository) GetEndpoint(ctx context.Context, userID int64) (*lead.Endpoint, error) {
+	row, err := r.queries.GetLeadEndpointByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, lead.ErrEndpointNotFound
+		}
+		return nil, err
+	}
+	return dbLeadEndpointToModel(row), nil
+}
+
+func (r *LeadRepository) GetEndpointByToken(ctx context.Context, token string) (*lead.Endpoint, error) {
+	row, err := r.queries.GetLeadEndpointByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, lead.ErrEndpointNotFound
+		}
+		return nil, err
+	}
+	return dbLeadEndpointToModel(row), nil
+}
+
+func (r *LeadRepository) DeleteEndpoint(ctx context.Context, userID int64) error {
+	n, err := r.queries.DeleteLeadEndpoint(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return lead.ErrEndpointNotFound
+	}
+	return nil
+}
+
+func (r *LeadRepository) Create(ctx context.Context, userID int64, contactID int64, data lead.LeadCreate) (*lead.Lead, error) {
+	fields := data.Fields
+	if fields == nil {
+		fields = map[string]any{}
+	}
+	fieldsJSON, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	row, err := r.queries.CreateLead(ctx, db.CreateLeadParams{
+		UserID:    userID,
+		ContactID: pgtype.Int8{Int64: contactID, Valid: contactID != 0},
+		Phone:     data.Phone,
+		Name:      pgtype.Text{String: data.Name, Valid: data.Name != ""},
+		Source:    data.Source,
+		Fields:    fieldsJSON,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dbLeadToModel(row), nil
+}
+
+func (r *LeadRepository) SetJob(ctx context.Context, id, jobID int64) error {
+	return r.queries.SetLeadJob(ctx, db.SetLeadJobParams{
+		ID:    id,
+		JobID: pgtype.Int8{Int64: jobID, Valid: true},
+	})
+}
+
+func (r *LeadRepository) HasRecentReply(ctx context.Context, userID, contactID int64, source string, since time.Time) (bool, error) {
+	return r.queries.HasRecentLeadReply(ctx, db.HasRecentLeadReplyParams{
+		UserID:    userID,
+		ContactID: pgtype.Int8{Int64: contactID, Valid: true},
+		Source:    source,
+		Since:     pgtype.Timestamptz{Time: since, Valid: true},
+	})
+}
+
+func (r *LeadRepository) ReserveEnquiryReply(ctx context.Context, userID int64, maxPerDay int) (bool, error) {
+	_, err := r.queries.ReserveEnquiryReply(ctx, db.ReserveEnquiryReplyParams{
+		UserID:     userID,
+		Day:        pgtype.Date{Time: time.Now().UTC(), Valid: true},
+		MaxReplies: int32(maxPerDay),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *LeadRepository) GetByUserID(ctx context.Context, userID int64, limit int) ([]*lead.Lead, error) {
+	rows, err := r.queries.ListLeadsByUserID(ctx, db.ListLeadsByUserIDParams{
+		UserID: userID,
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dbLeadsToModels(rows), nil
+}
+
+func dbLeadEndpointToModel(row db.LeadEndpoint) *lead.Endpoint {
+	return &lead.Endpoint{
+		UserID:    row.UserID,
+		Token:     row.Token,
+		Secret:    row.Secret,
+		CreatedAt: row.CreatedAt.Time,
+	}
+}
+
+func dbLeadsToModels(rows []db.Lead) []*lead.Lead {
+	leads := make([]*lead.Lead, len(rows))
+	for i, row := range rows {
+		leads[i] = dbLeadToModel(row)
+	}
+	return leads
+}
+
+func dbLeadToModel(row db.Lead) *lead.Lead {
+	l := &lead.Lead{
+		ID:        row.ID,
+		UserID:    row.UserID,
+		Phone:     row.Phone,
+		Source:    row.Source,
+		Fields:    row.Fields,
+		CreatedAt: row.CreatedAt.Time,
+	}
+	if row.ContactID.Valid {
+		id := row.ContactID.Int64
+		l.ContactID = &id
+	}
+	if row.Name.Valid {
+		l.Name = row.Name.String
+	}
+	if row.JobID.Valid {
+		id := row.JobID.Int64
+		l.JobID = &id
+	}
+	return l
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"callflow/internal/domain/auth"
+	db "callflow/internal/sql/db"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// LoginAttemptRepository implements auth.Repository
+type LoginAttemptRepository struct {
+	pool    *pgxpool.Pool
+	queries *db.Queries
+}
+
+// NewLoginAttemptRepository creates a new login attempt repository
+func NewLoginAttemptRepository(pool *pgxpool.Pool) *LoginAttemptRepository {
+	return &LoginAttemptRepository{
+		pool:    pool,
+		queries: db.New(pool),
+	}
+}
+
+func (r *LoginAttemptRepository) GetAttempts(ctx context.Context, phone string) (*auth.LoginAttempts, error) {
+	row, err := r.queries.GetLoginAttempt(ctx, phone)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, auth.ErrAttemptsNotFound
+		}
+		return nil, err
+	}
+	return dbLoginAttemptToModel(row), nil
+}
+
+func (r *LoginAttemptRepository) RecordFailure(ctx context.Context, phone, ip string) (*auth.LoginAttempts, error) {
+	row, err := r.queries.RecordLoginFailure(ctx, db.RecordLoginFailureParams{
+		Phone:  phone,
+		LastIp: ip,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dbLoginAttemptToModel(row), nil
+}
+
+func (r *LoginAttemptRepository) Lock(ctx context.Context, phone string, until time.Time) error {
+	return r.queries.LockLoginAccount(ctx, db.LockLoginAccountParams{
+		Phone:       phone,
+		LockedUntil: pgtype.Timestamptz{Time: until, Valid: true},
+	})
+}
+
+func (r *LoginAttemptRepository) ResetAttempts(ctx context.Context, phone string) error {
+	return r.queries.ResetLoginAttempts(ctx, phone)
+}
+
+func (r *LoginAttemptRepository) ListLocked(ctx context.Context) ([]*auth.LoginAttempts, error) {
+	rows, err := r.queries.ListLockedLoginAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	attempts := make([]*auth.LoginAttempts, len(rows))
+	for i, row := range rows {
+		attempts[i] = dbLoginAttemptToModel(row)
+	}
+	return attempts, nil
+}
+
+func dbLoginAttemptToModel(row db.LoginAttempt) *auth.LoginAttempts {
+	a := &auth.LoginAttempts{
+		Phone:       row.Phone,
+		FailedCount: int(row.FailedCount),
+		Lockouts:    int(row.Lockouts),
+	}
+	if row.LockedUntil.Valid {
+		t := row.LockedUntil.Time
+		a.LockedUntil = &t
+	}
+	if row.LastFailedAt.Valid {
+		t := row.LastFailedAt.Time
+		a.LastFailedAt = &t
+	}
+	if row.LastIp.Valid {
+		a.LastIP = row.LastIp.String
+	}
+	return a
+}
@@ -0,0 +1,247 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"callflow/internal/domain/media"
+	db "callflow/internal/sql/db"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// MediaRepository implements media.Repository
+type MediaRepository struct {
+	pool    *pgxpool.Pool
+	queries *db.Queries
+}
+
+// NewMediaRepository creates a new media repository
+func NewMediaRepository(pool *pgxpool.Pool) *MediaRepository {
+	return &MediaRepository{
+		pool:    pool,
+		queries: db.New(pool),
+	}
+}
+
+func (r *MediaRepository) CreateVariant(ctx context.Context, v media.Variant) (*media.Variant, error) {
+	row, err := r.queries.CreateImageVariant(ctx, db.CreateImageVariantParams{
+		ImageKey:    v.ImageKey,
+		Variant:     v.Name,
+		Key:         v.Key,
+		Url:         v.URL,
+		ContentType: v.ContentType,
+		Width:       int32(v.Width),
+		Height:      int32(v.Height),
+		SizeBytes:   int32(v.SizeBytes),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dbImageVariantToModel(row), nil
+}
+
+func (r *MediaRepository) GetVariants(ctx context.Context, imageKey string) ([]*media.Variant, error) {
+	rows, err := r.queries.ListImageVariants(ctx, imageKey)
+	if err != nil {
+		return nil, err
+	}
+	variants := make([]*media.Variant, len(rows))
+	for i, row := range rows {
+		variants[i] = dbImageVariantToModel(row)
+	}
+	return variants, nil
+}
+
+func (r *MediaRepository) DeleteVariants(ctx context.Context, imageKey string) error {
+	return r.queries.DeleteImageVariants(ctx, imageKey)
+}
+
+func (r *MediaRepository) Create(ctx context.Context, userID int64, imageKey, url string) (*media.Media, error) {
+	row, err := r.queries.CreateMedia(ctx, db.CreateMediaParams{
+		UserID:   pgtype.Int8{Int64: userID, Valid: true},
+		ImageKey: imageKey,
+		Url:      url,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dbMediaToModel(row), nil
+}
+
+func (r *MediaRepository) GetByID(ctx context.Context, id, userID int64) (*media.Media, error) {
+	row, err := r.queries.GetMediaByID(ctx, db.GetMediaByIDParams{
+		ID:     id,
+		UserID: pgtype.Int8{Int64: userID, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, media.ErrMediaNotFound
+		}
+		return nil, err
+	}
+	return dbMediaToModel(row), nil
+}
+
+func (r *MediaRepository) GetByImageKey(ctx context.Context, userID int64, imageKey string) (*media.Media, error) {
+	row, err := r.queries.GetMediaByImageKey(ctx, db.GetMediaByImageKeyParams{
+		ImageKey: imageKey,
+		UserID:   pgtype.Int8{Int64: userID, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, media.ErrMediaNotFound
+		}
+		return nil, err
+	}
+	return dbMediaToModel(row), nil
+}
+
+func (r *MediaRepository) ListItems(ctx context.Context, userID int64) ([]*media.Item, error) {
+	rows, err := r.queries.ListMediaItemsByUserID(ctx, pgtype.Int8{Int64: userID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*media.Item, len(rows))
+	for i, row := range rows {
+		items[i] = dbMediaItemToModel(db.GetMediaItemRow(row))
+	}
+	return items, nil
+}
+
+func (r *MediaRepository) GetItem(ctx context.Context, id, userID int64) (*media.Item, error) {
+	row, err := r.queries.GetMediaItem(ctx, db.GetMediaItemParams{
+		ID:     id,
+		UserID: pgtype.Int8{Int64: userID, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, media.ErrMediaNotFound
+		}
+		return nil, err
+	}
+	return dbMediaItemToModel(row), nil
+}
+
+func (r *MediaRepository) MarkDeleting(ctx context.Context, imageKey string) error {
+	return r.queries.MarkMediaDeleting(ctx, imageKey)
+}
+
+func (r *MediaRepository) MarkUserDeleting(ctx context.Context, userID int64) error {
+	return r.queries.MarkUserMediaDeleting(ctx, pgtype.Int8{Int64: userID, Valid: true})
+}
+
+func (r *MediaRepository) Rename(ctx context.Context, imageKey, newImageKey, url string) error {
+	return r.queries.RenameMedia(ctx, db.RenameMediaParams{
+		NewImageKey: newImageKey,
+		Url:         url,
+		ImageKey:    imageKey,
+	})
+}
+
+func (r *MediaRepository) RefreshReferences(ctx context.Context) (int64, int64, error) {
+	attached, err := r.queries.MarkReferencedMedia(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	unattached, err := r.queries.MarkUnreferencedMedia(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	return attached, unattached, nil
+}
+
+func (r *MediaRepository) ExpireUnattached(ctx context.Context, before time.Time) (int64, error) {
+	return r.queries.ExpireUnattachedMedia(ctx, pgtype.Timestamptz{Time: before, Valid: true})
+}
+
+func (r *MediaRepository) ClaimDeleting(ctx context.Context, lockedUntil time.Time, limit int) ([]*media.Media, error) {
+	rows, err := r.queries.ClaimDeletingMedia(ctx, db.ClaimDeletingMediaParams{
+		LockedUntil: pgtype.Timestamptz{Time: lockedUntil, Valid: true},
+		MaxMedia:    int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*media.Media, len(rows))
+	for i, row := range rows {
+		items[i] = dbMediaToModel(row)
+	}
+	return items, nil
+}
+
+func (r *MediaRepository) Delete(ctx context.Context, id int64) error {
+	return r.queries.DeleteMedia(ctx, id)
+}
+
+func (r *MediaRepository) FailDeletion(ctx context.Context, id int64, lastError string) error {
+	return r.queries.FailMediaDeletion(ctx, db.FailMediaDeletionParams{
+		LastError: lastError,
+		ID:        id,
+	})
+}
+
+func dbMediaToModel(row db.Medium) *media.Media {
+	m := &media.Media{
+		ID:             row.ID,
+		ImageKey:       row.ImageKey,
+		URL:            row.Url,
+		Status:         row.Status,
+		DeleteAttempts: int(row.DeleteAttempts),
+		CreatedAt:      row.CreatedAt.Time,
+		UpdatedAt:      row.UpdatedAt.Time,
+	}
+	if row.UserID.Valid {
+		v := row.UserID.Int64
+		m.UserID = &v
+	}
+	if row.UnreferencedSince.Valid {
+		t := row.UnreferencedSince.Time
+		m.UnreferencedSince = &t
+	}
+	if row.NextAttemptAt.Valid {
+		t := row.NextAttemptAt.Time
+		m.NextAttemptAt = &t
+	}
+	if row.LastError.Valid {
+		v := row.LastError.String
+		m.LastError = &v
+	}
+	return m
+}
+
+func dbMediaItemToModel(row db.GetMediaItemRow) *media.Item {
+	item := &media.Item{
+		ID:          row.ID,
+		URL:         row.Url,
+		ContentType: row.ContentType,
+		Width:       int(row.Width),
+		Height:      int(row.Height),
+		SizeBytes:   int(row.SizeBytes),
+		UsageCount:  int(row.UsageCount),
+		CreatedAt:   row.CreatedAt.Time,
+	}
+	if row.ThumbnailUrl != "" {
+		v := row.ThumbnailUrl
+		item.ThumbnailURL = &v
+	}
+	return item
+}
+
+func dbImageVariantToModel(row db.ImageVariant) *media.Variant {
+	return &media.Variant{
+		ID:          row.ID,
+		ImageKey:    row.ImageKey,
+		Name:        row.Variant,
+		Key:         row.Key,
+		URL:         row.Url,
+		ContentType: row.ContentType,
+		Width:       int(row.Width),
+		Height:      int(row.Height),
+		SizeBytes:   int(row.SizeBytes),
+		CreatedAt:   row.CreatedAt.Time,
+	}
+}
@@ -0,0 +1,289 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"callflow/internal/domain/organization"
+	db "callflow/internal/sql/db"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// OrganizationRepository implements organization.Repository
+type OrganizationRepository struct {
+	pool    *pgxpool.Pool
+	queries *db.Queries
+}
+
+// NewOrganizationRepository creates a new organization repository
+func NewOrganizationRepository(pool *pgxpool.Pool) *OrganizationRepository {
+	return &OrganizationRepository{
+		pool:    pool,
+		queries: db.New(pool),
+	}
+}
+
+func (r *OrganizationRepository) Create(ctx context.Context, ownerID int64, name string) (*organization.Organization, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	q := r.queries.WithTx(tx)
+	row, err := q.CreateOrganization(ctx, db.CreateOrganizationParams{
+		OwnerID: ownerID,
+		Name:    name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := q.AddOrganizationMember(ctx, db.AddOrganizationMemberParams{
+		OrganizationID: row.ID,
+		UserID:         ownerID,
+		Role:           organization.RoleOwner,
+	}); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return dbOrganizationToModel(row), nil
+}
+
+func (r *OrganizationRepository) GetByID(ctx context.Context, id int64) (*organization.Organization, error) {
+	row, err := r.queries.GetOrganizationByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, organization.ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+	return dbOrganizationToModel(row), nil
+}
+
+func (r *OrganizationRepository) GetByOwnerID(ctx context.Context, ownerID int64) (*organization.Organization, error) {
+	row, err := r.queries.GetOrganizationByOwnerID(ctx, ownerID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, organization.ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+	return dbOrganizationToModel(row), nil
+}
+
+func (r *OrganizationRepository) UpdateName(ctx context.Context, id int64, name string) (*organization.Organization, error) {
+	row, err := r.queries.UpdateOrganizationName(ctx, db.UpdateOrganizationNameParams{
+		ID:   id,
+		Name: name,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, organization.ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+	return dbOrganizationToModel(row), nil
+}
+
+func (r *OrganizationRepository) AddMember(ctx context.Context, organizationID, userID int64, role string) error {
+	return r.queries.AddOrganizationMember(ctx, db.AddOrganizationMemberParams{
+		OrganizationID: organizationID,
+		UserID:         userID,
+		Role:           role,
+	})
+}
+
+func (r *OrganizationRepository) GetMember(ctx context.Context, organizationID, userID int64) (*organization.Member, error) {
+	row, err := r.queries.GetOrganizationMember(ctx, db.GetOrganizationMemberParams{
+		OrganizationID: organizationID,
+		UserID:         userID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, organization.ErrMemberNotFound
+		}
+		return nil, err
+	}
+	return dbMemberToModel(row), nil
+}
+
+func (r *OrganizationRepository) ListMembers(ctx context.Context, organizationID int64) ([]*organization.Member, error) {
+	rows, err := r.queries.ListOrganizationMembers(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	return dbMembersToModels(rows), nil
+}
+
+func (r *OrganizationRepository) ListMemberships(ctx context.Context, userID int64) ([]*organization.Member, error) {
+	rows, err := r.queries.ListOrganizationMembershipsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return dbMembersToModels(rows), nil
+}
+
+func (r *OrganizationRepository) UpdateMemberRole(ctx context.Context, organizationID, userID int64, role string) error {
+	n, err := r.queries.UpdateOrganizationMemberRole(ctx, db.UpdateOrganizationMemberRoleParams{
+		OrganizationID: organizationID,
+		UserID:         userID,
+		Role:           role,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return organization.ErrMemberNotFound
+	}
+	return nil
+}
+
+func (r *OrganizationRepository) RemoveMember(ctx context.Context, organizationID, userID int64) error {
+	n, err := r.queries.DeleteOrganizationMember(ctx, db.DeleteOrganizationMemberParams{
+		OrganizationID: organizationID,
+		UserID:         userID,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return organization.ErrMemberNotFound
+	}
+	return nil
+}
+
+func (r *OrganizationRepository) CreateInvitation(ctx context.Context, organizationID, invitedBy int64, data organization.InvitationCreate, expiresAt time.Time) (*organization.Invitation, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	q := r.queries.WithTx(tx)
+	// An expired invitation no longer blocks a new one for the same phone.
+	if err := q.ExpireOrganizationInvitations(ctx, db.ExpireOrganizationInvitationsParams{
+		OrganizationID: organizationID,
+		Phone:          data.Phone,
+	}); err != nil {
+		return nil, err
+	}
+	row, err := q.CreateOrganizationInvitation(ctx, db.CreateOrganizationInvitationParams{
+		OrganizationID: organizationID,
+		Phone:          data.Phone,
+		Role:           data.Role,
+		InvitedBy:      invitedBy,
+		ExpiresAt:      pgtype.Timestamptz{Time: expiresAt, Valid: true},
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, organization.ErrInvitationPending
+		}
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return dbInvitationToModel(row), nil
+}
+
+func (r *OrganizationRepository) GetInvitation(ctx context.Context, id int64) (*organization.Invitation, error) {
+	row, err := r.queries.GetOrganizationInvitationByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, organization.ErrInvitationNotFound
+		}
+		return nil, err
+	}
+	return dbInvitationToModel(row), nil
+}
+
+func (r *OrganizationRepository) ListInvitations(ctx context.Context, organizationID int64) ([]*organization.Invitation, error) {
+	rows, err := r.queries.ListOrganizationInvitations(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	return dbInvitationsToModels(rows), nil
+}
+
+func (r *OrganizationRepository) ListPendingInvitationsByPhone(ctx context.Context, phone string) ([]*organization.Invitation, error) {
+	rows, err := r.queries.ListPendingInvitationsByPhone(ctx, phone)
+	if err != nil {
+		return nil, err
+	}
+	return dbInvitationsToModels(rows), nil
+}
+
+func (r *OrganizationRepository) RespondInvitation(ctx context.Context, id int64, status string) error {
+	n, err := r.queries.RespondOrganizationInvitation(ctx, db.RespondOrganizationInvitationParams{
+		ID:     id,
+		Status: status,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return organization.ErrInvitationNotFound
+	}
+	return nil
+}
+
+func dbOrganizationToModel(row db.Organization) *organization.Organization {
+	return &organization.Organization{
+		ID:        row.ID,
+		OwnerID:   row.OwnerID,
+		Name:      row.Name,
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+}
+
+func dbMembersToModels(rows []db.OrganizationMember) []*organization.Member {
+	members := make([]*organization.Member, len(rows))
+	for i, row := range rows {
+		members[i] = dbMemberToModel(row)
+	}
+	return members
+}
+
+func dbMemberToModel(row db.OrganizationMember) *organization.Member {
+	return &organization.Member{
+		OrganizationID: row.OrganizationID,
+		UserID:         row.UserID,
+		Role:           row.Role,
+		CreatedAt:      row.CreatedAt.Time,
+	}
+}
+
+func dbInvitationsToModels(rows []db.OrganizationInvitation) []*organization.Invitation {
+	invitations := make([]*organization.Invitation, len(rows))
+	for i, row := range rows {
+		invitations[i] = dbInvitationToModel(row)
+	}
+	return invitations
+}
+
+func dbInvitationToModel(row db.OrganizationInvitation) *organization.Invitation {
+	inv := &organization.Invitation{
+		ID:             row.ID,
+		OrganizationID: row.OrganizationID,
+		Phone:          row.Phone,
+		Role:           row.Role,
+		InvitedBy:      row.InvitedBy,
+		Status:         row.Status,
+		ExpiresAt:      row.ExpiresAt.Time,
+		CreatedAt:      row.CreatedAt.Time,
+	}
+	if row.RespondedAt.Valid {
+		t := row.RespondedAt.Time
+		inv.RespondedAt = &t
+	}
+	return inv
+}
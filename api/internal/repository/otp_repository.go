@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"callflow/internal/domain/otp"
+	db "callflow/internal/sql/db"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// OTPRepository implements otp.Repository
+type OTPRepository struct {
+	pool    *pgxpool.Pool
+	queries *db.Queries
+}
+
+// NewOTPRepository creates a new one-time code repository
+func NewOTPRepository(pool *pgxpool.Pool) *OTPRepository {
+	return &OTPRepository{
+		pool:    pool,
+		queries: db.New(pool),
+	}
+}
+
+func (r *OTPRepository) Create(ctx context.Context, data otp.CodeCreate) (*otp.Code, error) {
+	row, err := r.queries.CreateOTPCode(ctx, db.CreateOTPCodeParams{
+		Phone:       data.Phone,
+		Purpose:     data.Purpose,
+		CodeHash:    data.CodeHash,
+		MaxAttempts: int32(data.MaxAttempts),
+		ExpiresAt:   pgtype.Timestamptz{Time: data.ExpiresAt, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dbOTPCodeToModel(row), nil
+}
+
+func (r *OTPRepository) GetActive(ctx context.Context, phone, purpose string) (*otp.Code, error) {
+	row, err := r.queries.GetActiveOTPCode(ctx, db.GetActiveOTPCodeParams{
+		Phone:   phone,
+		Purpose: purpose,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, otp.ErrCodeNotFound
+		}
+		return nil, err
+	}
+	return dbOTPCodeToModel(row), nil
+}
+
+func (r *OTPRepository) CountSince(ctx context.Context, phone, purpose string, since time.Time) (int, error) {
+	n, err := r.queries.CountRecentOTPCodes(ctx, db.CountRecentOTPCodesParams{
+		Phone:   phone,
+		Purpose: purpose,
+		Since:   pgtype.Timestamptz{Time: since, Valid: true},
+	})
+	return int(n), err
+}
+
+func (r *OTPRepository) UseAttempt(ctx context.Context, id int64) (bool, error) {
+	if _, err := r.queries.UseOTPAttempt(ctx, id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *OTPRepository) Consume(ctx context.Context, id int64) (bool, error) {
+	n, err := r.queries.ConsumeOTPCode(ctx, id)
+	return n > 0, err
+}
+
+func (r *OTPRepository) Invalidate(ctx context.Context, phone, purpose string) error {
+	return r.queries.InvalidateOTPCodes(ctx, db.InvalidateOTPCodesParams{
+		Phone:   phone,
+		Purpose: purpose,
+	})
+}
+
+func dbOTPCodeToModel(row db.OtpCode) *otp.Code {
+	c := &otp.Code{
+		ID:          row.ID,
+		Phone:       row.Phone,
+		Purpose:     row.Purpose,
+		CodeHash:    row.CodeHash,
+		Attempts:    int(row.Attempts),
+		MaxAttempts: int(row.MaxAttempts),
+		ExpiresAt:   row.ExpiresAt.Time,
+		CreatedAt:   row.CreatedAt.Time,
+	}
+	if row.ConsumedAt.Valid {
+		t := row.ConsumedAt.Time
+		c.ConsumedAt = &t
+	}
+	return c
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	db "callflow/internal/sql/db"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RateLimitRepository implements ratelimit.Repository
+type RateLimitRepository struct {
+	pool    *pgxpool.Pool
+	queries *db.Queries
+}
+
+// NewRateLimitRepository creates a new rate limit repository
+func NewRateLimitRepository(pool *pgxpool.Pool) *RateLimitRepository {
+	return &RateLimitRepository{
+		pool:    pool,
+		queries: db.New(pool),
+	}
+}
+
+func (r *RateLimitRepository) Hit(ctx context.Context, key string, windowStart, expiresAt time.Time, limit int) (int, bool, error) {
+	n, err := r.queries.HitRateLimitBucket(ctx, db.HitRateLimitBucketParams{
+		Key:         key,
+		WindowStart: pgtype.Timestamptz{Time: windowStart, Valid: true},
+		ExpiresAt:   pgtype.Timestamptz{Time: expiresAt, Valid: true},
+		MaxCount:    int32(limit),
+	})
+	if err != nil {
+		// The conflict update is skipped, and no row returned, at the limit.
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	return int(n), true, nil
+}
+
+func (r *RateLimitRepository) Count(ctx context.Context, key string, windowStart time.Time) (int, error) {
+	n, err := r.queries.GetRateLimitBucketCount(ctx, db.GetRateLimitBucketCountParams{
+		Key:         key,
+		WindowStart: pgtype.Timestamptz{Time: windowStart, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return int(n), nil
+}
+
+func (r *RateLimitRepository) DeleteExpired(ctx context.Context) error {
+	return r.queries.DeleteExpiredRateLimitBuckets(ctx)
+}
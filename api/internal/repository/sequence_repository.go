@@ -0,0 +1,288 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"callflow/internal/domain/device"
+	"callflow/internal/domain/sequence"
+	db "callflow/internal/sql/db"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SequenceRepository implements sequence.Repository
+type SequenceRepository struct {
+	pool    *pgxpool.Pool
+	queries *db.Queries
+}
+
+// NewSequenceRepository creates a new sequence repository
+func NewSequenceRepository(pool *pgxpool.Pool) *SequenceRepository {
+	return &SequenceRepository{
+		pool:    pool,
+		queries: db.New(pool),
+	}
+}
+
+func (r *SequenceRepository) GetByUserID(ctx context.Context, userID int64) ([]*sequence.Sequence, error) {
+	rows, err := r.queries.ListSequencesByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return dbSequencesToModels(rows)
+}
+
+func (r *SequenceRepository) GetByID(ctx context.Context, id int64, userID int64) (*sequence.Sequence, error) {
+	row, err := r.queries.GetSequenceByID(ctx, db.GetSequenceByIDParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, sequence.ErrSequenceNotFound
+		}
+		return nil, err
+	}
+	return dbSequenceToModel(row)
+}
+
+func (r *SequenceRepository) GetEnabledByTrigger(ctx context.Context, userID int64, trigger string) ([]*sequence.Sequence, error) {
+	rows, err := r.queries.ListEnabledSequencesByTrigger(ctx, db.ListEnabledSequencesByTriggerParams{
+		UserID:      userID,
+		TriggerType: trigger,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dbSequencesToModels(rows)
+}
+
+func (r *SequenceRepository) Create(ctx context.Context, userID int64, data sequence.SequenceCreate) (*sequence.Sequence, error) {
+	steps, err := json.Marshal(data.Steps)
+	if err != nil {
+		return nil, err
+	}
+	row, err := r.queries.CreateSequence(ctx, db.CreateSequenceParams{
+		UserID:         userID,
+		Name:           data.Name,
+		TriggerType:    data.Trigger,
+		Steps:          steps,
+		StopOnCallback: boolOrDefault(data.StopOnCallback, true),
+		StopOnOptOut:   boolOrDefault(data.StopOnOptOut, true),
+		Enabled:        boolOrDefault(data.Enabled, true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dbSequenceToModel(row)
+}
+
+func (r *SequenceRepository) Update(ctx context.Context, id int64, userID int64, data sequence.SequenceUpdate) (*sequence.Sequence, error) {
+	steps, err := json.Marshal(data.Steps)
+	if err != nil {
+		return nil, err
+	}
+	row, err := r.queries.UpdateSequence(ctx, db.UpdateSequenceParams{
+		ID:             id,
+		UserID:         userID,
+		Name:           data.Name,
+		TriggerType:    data.Trigger,
+		Steps:          steps,
+		StopOnCallback: boolOrDefault(data.StopOnCallback, true),
+		StopOnOptOut:   boolOrDefault(data.StopOnOptOut, true),
+		Enabled:        boolOrDefault(data.Enabled, true),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, sequence.ErrSequenceNotFound
+		}
+		return nil, err
+	}
+	return dbSequenceToModel(row)
+}
+
+func (r *SequenceRepository) Delete(ctx context.Context, id int64, userID int64) error {
+	return r.queries.DeleteSequence(ctx, db.DeleteSequenceParams{
+		ID:     id,
+		UserID: userID,
+	})
+}
+
+func (r *SequenceRepository) Enroll(ctx context.Context, sequenceID, userID int64, phone string, firstRunAt time.Time) (*sequence.Enrollment, error) {
+	row, err := r.queries.CreateSequenceEnrollment(ctx, db.CreateSequenceEnrollmentParams{
+		SequenceID: sequenceID,
+		UserID:     userID,
+		Phone:      phone,
+		NextRunAt:  pgtype.Timestamptz{Time: firstRunAt, Valid: true},
+	})
+	if err != nil {
+		// No row comes back when the phone is already enrolled or called back
+		// during an earlier enrollment in this sequence.
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, sequence.ErrAlreadyEnrolled
+		}
+		return nil, err
+	}
+	return dbEnrollmentToModel(row), nil
+}
+
+func (r *SequenceRepository) GetEnrollments(ctx context.Context, sequenceID, userID int64, limit int) ([]*sequence.Enrollment, error) {
+	rows, err := r.queries.ListSequenceEnrollments(ctx, db.ListSequenceEnrollmentsParams{
+		SequenceID: sequenceID,
+		UserID:     userID,
+		Limit:      int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dbEnrollmentsToModels(rows), nil
+}
+
+func (r *SequenceRepository) ClaimDueEnrollments(ctx context.Context, userID int64, limit int) ([]*sequence.Enrollment, error) {
+	rows, err := r.queries.ClaimDueSequenceEnrollments(ctx, db.ClaimDueSequenceEnrollmentsParams{
+		UserID: userID,
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dbEnrollmentsToModels(rows), nil
+}
+
+func (r *SequenceRepository) AdvanceEnrollment(ctx context.Context, id int64, data sequence.EnrollmentAdvance) error {
+	_, err := advanceEnrollment(ctx, r.queries, id, data)
+	return err
+}
+
+func (r *SequenceRepository) QueueStep(ctx context.Context, id int64, userID int64, job device.JobCreate, data sequence.EnrollmentAdvance) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	q := r.queries.WithTx(tx)
+	if _, err := createDeviceJob(ctx, q, userID, job); err != nil {
+		return err
+	}
+	advanced, err := advanceEnrollment(ctx, q, id, data)
+	if err != nil {
+		return err
+	}
+	// The enrollment was stopped since it was claimed; drop the job.
+	if advanced == 0 {
+		return nil
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *SequenceRepository) StopEnrollment(ctx context.Context, id int64, reason string) error {
+	return r.queries.StopSequenceEnrollment(ctx, db.StopSequenceEnrollmentParams{
+		ID:         id,
+		StopReason: pgtype.Text{String: reason, Valid: true},
+	})
+}
+
+func (r *SequenceRepository) StopOnCallback(ctx context.Context, userID int64, phone string) (int64, error) {
+	return r.queries.StopSequenceEnrollmentsOnCallback(ctx, db.StopSequenceEnrollmentsOnCallbackParams{
+		UserID: userID,
+		Phone:  phone,
+	})
+}
+
+func (r *SequenceRepository) StopOnOptOut(ctx context.Context, userID int64, phone string) (int64, error) {
+	return r.queries.StopSequenceEnrollmentsOnOptOut(ctx, db.StopSequenceEnrollmentsOnOptOutParams{
+		UserID: userID,
+		Phone:  phone,
+	})
+}
+
+func dbSequencesToModels(rows []db.Sequence) ([]*sequence.Sequence, error) {
+	sequences := make([]*sequence.Sequence, len(rows))
+	for i, row := range rows {
+		s, err := dbSequenceToModel(row)
+		if err != nil {
+			return nil, err
+		}
+		sequences[i] = s
+	}
+	return sequences, nil
+}
+
+func dbSequenceToModel(row db.Sequence) (*sequence.Sequence, error) {
+	var steps []sequence.Step
+	if err := json.Unmarshal(row.Steps, &steps); err != nil {
+		return nil, fmt.Errorf("failed to decode steps for sequence %d: %w", row.ID, err)
+	}
+	return &sequence.Sequence{
+		ID:             row.ID,
+		UserID:         row.UserID,
+		Name:           row.Name,
+		Trigger:        row.TriggerType,
+		Steps:          steps,
+		StopOnCallback: row.StopOnCallback,
+		StopOnOptOut:   row.StopOnOptOut,
+		Enabled:        row.Enabled,
+		CreatedAt:      row.CreatedAt.Time,
+		UpdatedAt:      row.UpdatedAt.Time,
+	}, nil
+}
+
+func dbEnrollmentsToModels(rows []db.SequenceEnrollment) []*sequence.Enrollment {
+	enrollments := make([]*sequence.Enrollment, len(rows))
+	for i, row := range rows {
+		enrollments[i] = dbEnrollmentToModel(row)
+	}
+	return enrollments
+}
+
+func dbEnrollmentToModel(row db.SequenceEnrollment) *sequence.Enrollment {
+	e := &sequence.Enrollment{
+		ID:         row.ID,
+		SequenceID: row.SequenceID,
+		UserID:     row.UserID,
+		Phone:      row.Phone,
+		Status:     row.Status,
+		NextStep:   int(row.NextStep),
+		StartedAt:  row.StartedAt.Time,
+		UpdatedAt:  row.UpdatedAt.Time,
+	}
+	if row.NextRunAt.Valid {
+		t := row.NextRunAt.Time
+		e.NextRunAt = &t
+	}
+	if row.StopReason.Valid {
+		e.StopReason = row.StopReason.String
+	}
+	return e
+}
+
+func boolOrDefault(v *bool, def bool) bool {
+	if v == nil {
+		return def
+	}
+	return *v
+}
+
+func nullableTimestamptz(t *time.Time) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{Valid: false}
+	}
+	return pgtype.Timestamptz{Time: *t, Valid: true}
+}
+
+// advanceEnrollment moves an active enrollment on and reports whether it was still active
+func advanceEnrollment(ctx context.Context, q *db.Queries, id int64, data sequence.EnrollmentAdvance) (int64, error) {
+	return q.AdvanceSequenceEnrollment(ctx, db.AdvanceSequenceEnrollmentParams{
+		ID:        id,
+		NextStep:  int32(data.NextStep),
+		NextRunAt: nullableTimestamptz(data.NextRunAt),
+		Status:    data.Status,
+	})
+}
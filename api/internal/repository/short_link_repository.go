@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"callflow/internal/domain/shortlink"
+	db "callflow/internal/sql/db"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ShortLinkRepository implements shortlink.Repository
+type ShortLinkRepository struct {
+	pool    *pgxpool.Pool
+	queries *db.Queries
+}
+
+// NewShortLinkRepository creates a new short link repository
+func NewShortLinkRepository(pool *pgxpool.Pool) *ShortLinkRepository {
+	return &ShortLinkRepository{
+		pool:    pool,
+		queries: db.New(pool),
+	}
+}
+
+func (r *ShortLinkRepository) Create(ctx context.Context, userID int64, code string, data shortlink.ShortLinkCreate) (*shortlink.ShortLink, error) {
+	row, err := r.queries.CreateShortLink(ctx, db.CreateShortLinkParams{
+		UserID: userID,
+		Code:   code,
+		Url:    data.URL,
+		Source: data.Source,
+		RefID:  nullableInt8(data.RefID),
+		Phone:  nullableText(data.Phone),
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, shortlink.ErrCodeTaken
+		}
+		return nil, err
+	}
+	return dbShortLinkToModel(row), nil
+}
+
+func (r *ShortLinkRepository) GetByCode(ctx context.Context, code string) (*shortlink.ShortLink, error) {
+	row, err := r.queries.GetShortLinkByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, shortlink.ErrShortLinkNotFound
+		}
+		return nil, err
+	}
+	return dbShortLinkToModel(row), nil
+}
+
+func (r *ShortLinkRepository) GetByID(ctx context.Context, id, userID int64) (*shortlink.ShortLink, error) {
+	row, err := r.queries.GetShortLinkByID(ctx, db.GetShortLinkByIDParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, shortlink.ErrShortLinkNotFound
+		}
+		return nil, err
+	}
+	return dbShortLinkToModel(row), nil
+}
+
+func (r *ShortLinkRepository) GetByRef(ctx context.Context, userID int64, source string, refID int64) (*shortlink.ShortLink, error) {
+	row, err := r.queries.GetShortLinkByRef(ctx, db.GetShortLinkByRefParams{
+		Source: source,
+		RefID:  nullableInt8(&refID),
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, shortlink.ErrShortLinkNotFound
+		}
+		return nil, err
+	}
+	return dbShortLinkToModel(row), nil
+}
+
+func (r *ShortLinkRepository) GetByUserID(ctx context.Context, userID int64, limit int) ([]*shortlink.ShortLink, error) {
+	rows, err := r.queries.ListShortLinksByUserID(ctx, db.ListShortLinksByUserIDParams{
+		UserID: userID,
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	links := make([]*shortlink.ShortLink, len(rows))
+	for i, row := range rows {
+		links[i] = dbShortLinkToModel(row)
+	}
+	return links, nil
+}
+
+func (r *ShortLinkRepository) RecordClick(ctx context.Context, id int64, userAgent, device string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	q := r.queries.WithTx(tx)
+	if err := q.CreateShortLinkClick(ctx, db.CreateShortLinkClickParams{
+		ShortLinkID: id,
+		UserAgent:   userAgent,
+		Device:      device,
+	}); err != nil {
+		return err
+	}
+	if device != shortlink.DeviceBot {
+		if err := q.IncrementShortLinkClicks(ctx, id); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *ShortLinkRepository) GetClicks(ctx context.Context, id int64, limit int) ([]*shortlink.Click, error) {
+	rows, err := r.queries.ListShortLinkClicks(ctx, db.ListShortLinkClicksParams{
+		ShortLinkID: id,
+		Limit:       int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	clicks := make([]*shortlink.Click, len(rows))
+	for i, row := range rows {
+		clicks[i] = &shortlink.Click{
+			UserAgent: row.UserAgent,
+			Device:    row.Device,
+			ClickedAt: row.ClickedAt.Time,
+		}
+	}
+	return clicks, nil
+}
+
+func dbShortLinkToModel(row db.ShortLink) *shortlink.ShortLink {
+	l := &shortlink.ShortLink{
+		ID:        row.ID,
+		UserID:    row.UserID,
+		Code:      row.Code,
+		URL:       row.Url,
+		Source:    row.Source,
+		Clicks:    int(row.Clicks),
+		CreatedAt: row.CreatedAt.Time,
+	}
+	if row.RefID.Valid {
+		v := row.RefID.Int64
+		l.RefID = &v
+	}
+	if row.Phone.Valid {
+		v := row.Phone.String
+		l.Phone = &v
+	}
+	if row.LastClickAt.Valid {
+		t := row.LastClickAt.Time
+		l.LastClickAt = &t
+	}
+	return l
+}
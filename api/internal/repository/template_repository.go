@@ -69,6 +69,7 @@ func (r *TemplateRepository) Create(ctx context.Context, userID int64, data temp
 		Channel:   data.Channel,
 		ImageUrl:  nullableText(data.ImageURL),
 		ImageKey:  nullableText(data.ImageKey),
+		MediaID:   nullableInt8(data.MediaID),
 		Language:  pgtype.Text{String: lang, Valid: true},
 		IsDefault: data.IsDefault,
 	})
@@ -93,6 +94,7 @@ func (r *TemplateRepository) Update(ctx context.Context, id int64, userID int64,
 		Channel:   data.Channel,
 		ImageUrl:  nullableText(data.ImageURL),
 		ImageKey:  nullableText(data.ImageKey),
+		MediaID:   nullableInt8(data.MediaID),
 		Language:  pgtype.Text{String: lang, Valid: true},
 		IsDefault: data.IsDefault,
 	})
@@ -112,10 +114,39 @@ func (r *TemplateRepository) Delete(ctx context.Context, id int64, userID int64)
 	})
 }
 
+func (r *TemplateRepository) GetWithImages(ctx context.Context) ([]*template.Template, error) {
+	rows, err := r.queries.ListTemplatesWithImage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	templates := make([]*template.Template, len(rows))
+	for i, row := range rows {
+		templates[i] = dbTemplateToModel(row)
+	}
+	return templates, nil
+}
+
+func (r *TemplateRepository) ReplaceImage(ctx context.Context, id int64, oldKey, imageURL, imageKey string) error {
+	n, err := r.queries.ReplaceTemplateImage(ctx, db.ReplaceTemplateImageParams{
+		ImageUrl:    pgtype.Text{String: imageURL, Valid: true},
+		ImageKey:    pgtype.Text{String: imageKey, Valid: true},
+		ID:          id,
+		OldImageKey: pgtype.Text{String: oldKey, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return template.ErrTemplateNotFound
+	}
+	return nil
+}
+
 func dbTemplateToModel(row db.Template) *template.Template {
 	var lang string
 	var imageURL *string
 	var imageKey *string
+	var mediaID *int64
 	if row.Language.Valid {
 		lang = row.Language.String
 	}
@@ -125,6 +156,9 @@ func dbTemplateToModel(row db.Template) *template.Template {
 	if row.ImageKey.Valid {
 		imageKey = &row.ImageKey.String
 	}
+	if row.MediaID.Valid {
+		mediaID = &row.MediaID.Int64
+	}
 	return &template.Template{
 		ID:        row.ID,
 		UserID:    row.UserID,
@@ -134,6 +168,7 @@ func dbTemplateToModel(row db.Template) *template.Template {
 		Channel:   row.Channel,
 		ImageURL:  imageURL,
 		ImageKey:  imageKey,
+		MediaID:   mediaID,
 		Language:  lang,
 		IsDefault: row.IsDefault,
 		CreatedAt: row.CreatedAt.Time,
@@ -147,3 +182,10 @@ func nullableText(v *string) pgtype.Text {
 	}
 	return pgtype.Text{String: *v, Valid: true}
 }
+
+func nullableInt8(v *int64) pgtype.Int8 {
+	if v == nil {
+		return pgtype.Int8{Valid: false}
+	}
+	return pgtype.Int8{Int64: *v, Valid: true}
+}
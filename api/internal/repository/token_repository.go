@@ -72,6 +72,13 @@ func (r *TokenRepository) RevokeAllUserTokens(ctx context.Context, userID int64)
 	return r.queries.RevokeAllUserTokens(ctx, userID)
 }
 
+func (r *TokenRepository) RevokeOtherUserTokens(ctx context.Context, userID int64, keepTokenID string) error {
+	return r.queries.RevokeOtherUserTokens(ctx, db.RevokeOtherUserTokensParams{
+		UserID: userID,
+		Token:  keepTokenID,
+	})
+}
+
 func (r *TokenRepository) UpdateTokenLastUsed(ctx context.Context, id int64) error {
 	return r.queries.UpdateTokenLastUsed(ctx, id)
 }
@@ -90,6 +97,20 @@ func (r *TokenRepository) StoreTokenID(ctx context.Context, tokenID string, user
 	return err
 }
 
+func (r *TokenRepository) StoreDeviceTokenID(ctx context.Context, tokenID string, userID int64, deviceID *int64, expiresAt time.Time) error {
+	d := pgtype.Int8{Valid: false}
+	if deviceID != nil {
+		d = pgtype.Int8{Int64: *deviceID, Valid: true}
+	}
+	return r.queries.CreateDeviceToken(ctx, db.CreateDeviceTokenParams{
+		UserID:    userID,
+		Token:     tokenID,
+		ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+		TokenType: token.TypeAccess,
+		DeviceID:  d,
+	})
+}
+
 func (r *TokenRepository) IsTokenRevoked(ctx context.Context, tokenID string) (bool, error) {
 	row, err := r.queries.GetTokenByToken(ctx, tokenID)
 	if err != nil {
@@ -115,6 +115,21 @@ func (r *UserRepository) UpdateStatus(ctx context.Context, id int64, status stri
 	})
 }
 
+func (r *UserRepository) MarkPhoneVerified(ctx context.Context, phone string) error {
+	return r.queries.MarkUserPhoneVerified(ctx, phone)
+}
+
+func (r *UserRepository) UpdatePassword(ctx context.Context, id int64, passwordHash string) error {
+	return r.queries.UpdateUserPassword(ctx, db.UpdateUserPasswordParams{
+		ID:           id,
+		PasswordHash: passwordHash,
+	})
+}
+
+func (r *UserRepository) InvalidateTokens(ctx context.Context, id int64) error {
+	return r.queries.InvalidateUserTokens(ctx, id)
+}
+
 func (r *UserRepository) ListAll(ctx context.Context) ([]*user.User, error) {
 	rows, err := r.queries.ListAllUsers(ctx)
 	if err != nil {
@@ -159,6 +174,14 @@ func dbUserToModel(row db.User) *user.User {
 			u.PlanStartedAt = &t
 		}
 	}
+	if row.PurgeAfter.Valid {
+		t := row.PurgeAfter.Time
+		u.PurgeAfter = &t
+	}
+	if row.TokensValidAfter.Valid {
+		t := row.TokensValidAfter.Time
+		u.TokensValidAfter = &t
+	}
 	if row.PlanExpiresAt.Valid {
 		t := row.PlanExpiresAt.Time
 		if t.Year() > 0 && t.Year() <= 9999 {
@@ -0,0 +1,247 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"callflow/internal/domain/webhook"
+	db "callflow/internal/sql/db"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// WebhookRepository implements webhook.Repository
+type WebhookRepository struct {
+	pool    *pgxpool.Pool
+	queries *db.Queries
+}
+
+// NewWebhookRepository creates a new webhook repository
+func NewWebhookRepository(pool *pgxpool.Pool) *WebhookRepository {
+	return &WebhookRepository{
+		pool:    pool,
+		queries: db.New(pool),
+	}
+}
+
+func (r *WebhookRepository) Create(ctx context.Context, userID int64, data webhook.WebhookCreate) (*webhook.Webhook, error) {
+	row, err := r.queries.CreateWebhook(ctx, db.CreateWebhookParams{
+		UserID: userID,
+		Url:    data.URL,
+		Events: data.Events,
+		Secret: data.Secret,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dbWebhookToModel(row), nil
+}
+
+func (r *WebhookRepository) GetByID(ctx context.Context, id, userID int64) (*webhook.Webhook, error) {
+	row, err := r.queries.GetWebhookByID(ctx, db.GetWebhookByIDParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, webhook.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	return dbWebhookToModel(row), nil
+}
+
+func (r *WebhookRepository) GetByUserID(ctx context.Context, userID int64) ([]*webhook.Webhook, error) {
+	rows, err := r.queries.ListWebhooksByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return dbWebhooksToModels(rows), nil
+}
+
+func (r *WebhookRepository) GetForEvent(ctx context.Context, userID int64, eventType string) ([]*webhook.Webhook, error) {
+	rows, err := r.queries.ListActiveWebhooksForEvent(ctx, db.ListActiveWebhooksForEventParams{
+		UserID:    userID,
+		EventType: eventType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dbWebhooksToModels(rows), nil
+}
+
+func (r *WebhookRepository) Update(ctx context.Context, id, userID int64, data webhook.WebhookUpdate) (*webhook.Webhook, error) {
+	row, err := r.queries.UpdateWebhook(ctx, db.UpdateWebhookParams{
+		ID:     id,
+		UserID: userID,
+		Url:    data.URL,
+		Events: data.Events,
+		Active: data.Active,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, webhook.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	return dbWebhookToModel(row), nil
+}
+
+func (r *WebhookRepository) Delete(ctx context.Context, id, userID int64) error {
+	n, err := r.queries.DeleteWebhook(ctx, db.DeleteWebhookParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return webhook.ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (r *WebhookRepository) CreateDelivery(ctx context.Context, webhookID int64, eventID, eventType string, payload []byte) (*webhook.Delivery, error) {
+	row, err := r.queries.CreateWebhookDelivery(ctx, db.CreateWebhookDeliveryParams{
+		WebhookID: webhookID,
+		EventID:   eventID,
+		EventType: eventType,
+		Payload:   payload,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dbDeliveryToModel(row), nil
+}
+
+func (r *WebhookRepository) GetDelivery(ctx context.Context, id, webhookID int64) (*webhook.Delivery, error) {
+	row, err := r.queries.GetWebhookDeliveryByID(ctx, db.GetWebhookDeliveryByIDParams{
+		ID:        id,
+		WebhookID: webhookID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, webhook.ErrDeliveryNotFound
+		}
+		return nil, err
+	}
+	return dbDeliveryToModel(row), nil
+}
+
+func (r *WebhookRepository) GetDeliveries(ctx context.Context, webhookID int64, limit int) ([]*webhook.Delivery, error) {
+	rows, err := r.queries.ListWebhookDeliveries(ctx, db.ListWebhookDeliveriesParams{
+		WebhookID: webhookID,
+		Limit:     int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dbDeliveriesToModels(rows), nil
+}
+
+func (r *WebhookRepository) GetForDelivery(ctx context.Context, id int64) (*webhook.Webhook, error) {
+	row, err := r.queries.GetWebhookForDelivery(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, webhook.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	return dbWebhookToModel(row), nil
+}
+
+func (r *WebhookRepository) ClaimDeliveries(ctx context.Context, lockedUntil time.Time, limit int) ([]*webhook.Delivery, error) {
+	rows, err := r.queries.ClaimWebhookDeliveries(ctx, db.ClaimWebhookDeliveriesParams{
+		LockedUntil:   pgtype.Timestamptz{Time: lockedUntil, Valid: true},
+		MaxDeliveries: int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dbDeliveriesToModels(rows), nil
+}
+
+func (r *WebhookRepository) CompleteDelivery(ctx context.Context, id int64, attempt webhook.Attempt) error {
+	return r.queries.CompleteWebhookDelivery(ctx, db.CompleteWebhookDeliveryParams{
+		ResponseStatus: int32(attempt.ResponseStatus),
+		DurationMs:     int32(attempt.Duration.Milliseconds()),
+		ID:             id,
+	})
+}
+
+func (r *WebhookRepository) FailDelivery(ctx context.Context, id int64, attempt webhook.Attempt) error {
+	return r.queries.FailWebhookDelivery(ctx, db.FailWebhookDeliveryParams{
+		Retryable:      attempt.Retryable,
+		ResponseStatus: pgtype.Int4{Int32: int32(attempt.ResponseStatus), Valid: attempt.ResponseStatus != 0},
+		LastError:      attempt.Error,
+		DurationMs:     int32(attempt.Duration.Milliseconds()),
+		ID:             id,
+	})
+}
+
+func dbWebhooksToModels(rows []db.Webhook) []*webhook.Webhook {
+	webhooks := make([]*webhook.Webhook, len(rows))
+	for i, row := range rows {
+		webhooks[i] = dbWebhookToModel(row)
+	}
+	return webhooks
+}
+
+func dbWebhookToModel(row db.Webhook) *webhook.Webhook {
+	w := &webhook.Webhook{
+		ID:        row.ID,
+		UserID:    row.UserID,
+		URL:       row.Url,
+		Events:    row.Events,
+		Secret:    row.Secret,
+		Active:    row.Active,
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+	if w.Events == nil {
+		w.Events = []string{}
+	}
+	return w
+}
+
+func dbDeliveriesToModels(rows []db.WebhookDelivery) []*webhook.Delivery {
+	deliveries := make([]*webhook.Delivery, len(rows))
+	for i, row := range rows {
+		deliveries[i] = dbDeliveryToModel(row)
+	}
+	return deliveries
+}
+
+func dbDeliveryToModel(row db.WebhookDelivery) *webhook.Delivery {
+	d := &webhook.Delivery{
+		ID:            row.ID,
+		WebhookID:     row.WebhookID,
+		EventID:       row.EventID,
+		EventType:     row.EventType,
+		Payload:       row.Payload,
+		Status:        row.Status,
+		Attempts:      int(row.Attempts),
+		MaxAttempts:   int(row.MaxAttempts),
+		NextAttemptAt: row.NextAttemptAt.Time,
+		CreatedAt:     row.CreatedAt.Time,
+	}
+	if row.ResponseStatus.Valid {
+		v := int(row.ResponseStatus.Int32)
+		d.ResponseStatus = &v
+	}
+	if row.LastError.Valid {
+		d.LastError = row.LastError.String
+	}
+	if row.DurationMs.Valid {
+		v := int(row.DurationMs.Int32)
+		d.DurationMs = &v
+	}
+	if row.DeliveredAt.Valid {
+		t := row.DeliveredAt.Time
+		d.DeliveredAt = &t
+	}
+	return d
+}
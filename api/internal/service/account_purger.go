@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"callflow/internal/domain/account"
+	"callflow/internal/domain/media"
+	"callflow/internal/domain/user"
+)
+
+const accountPurgerInterval = time.Hour
+
+// AccountPurger permanently deletes accounts whose deletion grace period has passed
+type AccountPurger struct {
+	accountRepo  account.Repository
+	mediaService media.Service
+	stopCh       chan struct{}
+}
+
+// NewAccountPurger creates a new account purger
+func NewAccountPurger(accountRepo account.Repository, mediaService media.Service) *AccountPurger {
+	return &AccountPurger{
+		accountRepo:  accountRepo,
+		mediaService: mediaService,
+		stopCh:       make(chan struct{}),
+	}
+}
+
+// Start runs the purge loop in a background goroutine
+func (p *AccountPurger) Start() {
+	go p.loop()
+}
+
+// Stop stops the purge loop
+func (p *AccountPurger) Stop() {
+	close(p.stopCh)
+}
+
+func (p *AccountPurger) loop() {
+	ticker := time.NewTicker(accountPurgerInterval)
+	defer ticker.Stop()
+
+	p.purgeDue()
+	for {
+		select {
+		case <-ticker.C:
+			p.purgeDue()
+		case <-p.stopCh:
+			return
+		}
+	}
+}
+
+func (p *AccountPurger) purgeDue() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	users, err := p.accountRepo.ListDueForPurge(ctx, account.PurgeBatchSize)
+	if err != nil {
+		log.Printf("account purger: failed to list accounts: %v", err)
+		return
+	}
+	for _, u := range users {
+		if err := p.purge(ctx, u); err != nil {
+			log.Printf("account purger: failed to purge user %d: %v", u.ID, err)
+			continue
+		}
+		log.Printf("account purger: purged user %d", u.ID)
+	}
+}
+
+// purge queues the user's stored images for deletion by the media reconciler
+// and then deletes every row it owns. Media rows survive the account, so the
+// images are still cleaned up if their deletion fails.
+func (p *AccountPurger) purge(ctx context.Context, u *user.User) error {
+	if err := p.mediaService.ReleaseAll(ctx, u.ID); err != nil {
+		return err
+	}
+	return p.accountRepo.Purge(ctx, u)
+}
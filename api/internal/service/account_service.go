@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"callflow/internal/domain/account"
+	"callflow/internal/domain/apikey"
+	"callflow/internal/domain/campaign"
+	"callflow/internal/domain/contact"
+	"callflow/internal/domain/landing"
+	"callflow/internal/domain/media"
+	"callflow/internal/domain/rule"
+	"callflow/internal/domain/sequence"
+	"callflow/internal/domain/template"
+	"callflow/internal/domain/token"
+	"callflow/internal/domain/user"
+	"callflow/internal/domain/webhook"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// AccountService provides account export and deletion
+type AccountService struct {
+	accountRepo  account.Repository
+	userRepo     user.Repository
+	tokenRepo    token.Repository
+	templateRepo template.Repository
+	ruleRepo     rule.Repository
+	contactRepo  contact.Repository
+	landingRepo  landing.Repository
+	sequenceRepo sequence.Repository
+	campaignRepo campaign.Repository
+	mediaRepo    media.Repository
+	webhookRepo  webhook.Repository
+	apiKeyRepo   apikey.Repository
+	purgeAfter   time.Duration
+}
+
+// NewAccountService creates a new account service instance.
+// ACCOUNT_PURGE_AFTER_DAYS sets how long a deleted account is kept before it is purged.
+func NewAccountService(
+	accountRepo account.Repository,
+	userRepo user.Repository,
+	tokenRepo token.Repository,
+	templateRepo template.Repository,
+	ruleRepo rule.Repository,
+	contactRepo contact.Repository,
+	landingRepo landing.Repository,
+	sequenceRepo sequence.Repository,
+	campaignRepo campaign.Repository,
+	mediaRepo media.Repository,
+	webhookRepo webhook.Repository,
+	apiKeyRepo apikey.Repository,
+) *AccountService {
+	return &AccountService{
+		accountRepo:  accountRepo,
+		userRepo:     userRepo,
+		tokenRepo:    tokenRepo,
+		templateRepo: templateRepo,
+		ruleRepo:     ruleRepo,
+		contactRepo:  contactRepo,
+		landingRepo:  landingRepo,
+		sequenceRepo: sequenceRepo,
+		campaignRepo: campaignRepo,
+		mediaRepo:    mediaRepo,
+		webhookRepo:  webhookRepo,
+		apiKeyRepo:   apiKeyRepo,
+		purgeAfter:   accountPurgeAfterFromEnv(),
+	}
+}
+
+func (s *AccountService) Export(ctx context.Context, userID int64) (*account.Export, error) {
+	u, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	export := &account.Export{
+		ExportedAt: time.Now(),
+		Profile:    u,
+	}
+
+	export.Templates, err = s.templateRepo.GetByUserID(ctx, userID)
+	if errors.Is(err, template.ErrTemplateNotFound) {
+		export.Templates, err = []*template.Template{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to export templates: %w", err)
+	}
+	if export.Rule, err = s.ruleRepo.Get(ctx, userID); err != nil && !errors.Is(err, rule.ErrRuleNotFound) {
+		return nil, fmt.Errorf("failed to export rule: %w", err)
+	}
+	if export.Contacts, err = s.contactRepo.GetByUserID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to export contacts: %w", err)
+	}
+	if export.Landing, err = s.landingRepo.GetByUserID(ctx, userID); err != nil && !errors.Is(err, landing.ErrLandingNotFound) {
+		return nil, fmt.Errorf("failed to export landing page: %w", err)
+	}
+	if export.LandingDraft, err = s.landingRepo.GetDraft(ctx, userID); err != nil && !errors.Is(err, landing.ErrDraftNotFound) {
+		return nil, fmt.Errorf("failed to export landing draft: %w", err)
+	}
+	if export.LandingRevisions, err = s.accountRepo.ListLandingRevisions(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to export landing revisions: %w", err)
+	}
+	if export.LandingSlugs, err = s.accountRepo.ListLandingSlugs(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to export landing slugs: %w", err)
+	}
+	if export.Sequences, err = s.sequenceRepo.GetByUserID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to export sequences: %w", err)
+	}
+	if export.SequenceEnrollments, err = s.accountRepo.ListSequenceEnrollments(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to export sequence enrollments: %w", err)
+	}
+	if export.Campaigns, err = s.campaignRepo.GetByUserID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to export campaigns: %w", err)
+	}
+	if export.CampaignMessages, err = s.accountRepo.ListCampaignMessages(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to export campaign messages: %w", err)
+	}
+	if export.DeviceJobs, err = s.accountRepo.ListDeviceJobs(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to export device jobs: %w", err)
+	}
+	if export.Leads, err = s.accountRepo.ListLeads(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to export leads: %w", err)
+	}
+	if export.Media, err = s.mediaRepo.ListItems(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to export media: %w", err)
+	}
+	if export.Webhooks, err = s.webhookRepo.GetByUserID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to export webhooks: %w", err)
+	}
+	if export.APIKeys, err = s.apiKeyRepo.GetByUserID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to export api keys: %w", err)
+	}
+	if export.ShortLinks, err = s.accountRepo.ListShortLinks(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to export short links: %w", err)
+	}
+	if export.ShortLinkClicks, err = s.accountRepo.ListShortLinkClicks(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to export short link clicks: %w", err)
+	}
+	return export, nil
+}
+
+func (s *AccountService) Delete(ctx context.Context, userID int64, req account.DeleteRequest) error {
+	u, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(req.Password)); err != nil {
+		return account.ErrInvalidPassword
+	}
+
+	if err := s.accountRepo.SoftDelete(ctx, userID, time.Now().Add(s.purgeAfter)); err != nil {
+		return fmt.Errorf("failed to delete account: %w", err)
+	}
+	if err := s.userRepo.InvalidateTokens(ctx, userID); err != nil {
+		return fmt.Errorf("failed to invalidate legacy tokens: %w", err)
+	}
+	return s.tokenRepo.RevokeAllUserTokens(ctx, userID)
+}
+
+// accountPurgeAfterFromEnv reads ACCOUNT_PURGE_AFTER_DAYS, the grace period
+// between deleting an account and purging its data.
+func accountPurgeAfterFromEnv() time.Duration {
+	days, err := strconv.Atoi(os.Getenv("ACCOUNT_PURGE_AFTER_DAYS"))
+	if err != nil || days < 0 {
+		return account.DefaultPurgeAfter
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"callflow/internal/domain/apikey"
+	"callflow/internal/domain/user"
+)
+
+// APIKeyService provides API key management and authentication
+type APIKeyService struct {
+	keyRepo  apikey.Repository
+	userRepo user.Repository
+}
+
+// NewAPIKeyService creates a new API key service instance
+func NewAPIKeyService(keyRepo apikey.Repository, userRepo user.Repository) *APIKeyService {
+	return &APIKeyService{
+		keyRepo:  keyRepo,
+		userRepo: userRepo,
+	}
+}
+
+func (s *APIKeyService) Create(ctx context.Context, userID int64, data apikey.KeyCreate) (*apikey.CreatedKey, error) {
+	scopes := make([]string, 0, len(data.Scopes))
+	seen := make(map[string]bool, len(data.Scopes))
+	for _, scope := range data.Scopes {
+		if !apikey.IsValidScope(scope) {
+			return nil, apikey.ErrInvalidScope
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	data.Scopes = scopes
+	if data.ExpiresAt != nil && !data.ExpiresAt.After(time.Now()) {
+		return nil, apikey.ErrInvalidExpiry
+	}
+
+	active, err := s.keyRepo.CountActive(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if active >= apikey.MaxActiveKeys {
+		return nil, apikey.ErrTooManyKeys
+	}
+
+	token, err := randomToken(24)
+	if err != nil {
+		return nil, err
+	}
+	secret := apikey.KeyPrefix + token
+	key, err := s.keyRepo.Create(ctx, userID, data, displayPrefix(secret), hashAPIKey(secret))
+	if err != nil {
+		return nil, err
+	}
+	return &apikey.CreatedKey{Key: key, Secret: secret}, nil
+}
+
+func (s *APIKeyService) GetByUserID(ctx context.Context, userID int64) ([]*apikey.Key, error) {
+	return s.keyRepo.GetByUserID(ctx, userID)
+}
+
+func (s *APIKeyService) Revoke(ctx context.Context, id, userID int64) error {
+	return s.keyRepo.Revoke(ctx, id, userID)
+}
+
+func (s *APIKeyService) Authenticate(ctx context.Context, secret string) (*apikey.Principal, error) {
+	if !strings.HasPrefix(secret, apikey.KeyPrefix) {
+		return nil, apikey.ErrInvalidKey
+	}
+	key, err := s.keyRepo.GetByHash(ctx, hashAPIKey(secret))
+	if err != nil {
+		if errors.Is(err, apikey.ErrKeyNotFound) {
+			return nil, apikey.ErrInvalidKey
+		}
+		return nil, err
+	}
+	if key.RevokedAt != nil {
+		return nil, apikey.ErrRevokedKey
+	}
+	if key.ExpiresAt != nil && time.Now().After(*key.ExpiresAt) {
+		return nil, apikey.ErrExpiredKey
+	}
+
+	u, err := s.userRepo.GetByID(ctx, key.UserID)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return nil, apikey.ErrInvalidKey
+		}
+		return nil, err
+	}
+	if u.Status != user.StatusActive {
+		return nil, apikey.ErrUserInactive
+	}
+
+	// Last-used tracking is best effort and must not fail the request.
+	if err := s.keyRepo.Touch(ctx, key.ID); err != nil {
+		log.Printf("Failed to record use of API key %d: %v", key.ID, err)
+	}
+
+	return &apikey.Principal{
+		KeyID:  key.ID,
+		UserID: u.ID,
+		Phone:  u.Phone,
+		Plan:   u.Plan,
+		Scopes: key.Scopes,
+	}, nil
+}
+
+// hashAPIKey returns the stored form of a key. Keys carry 192 random bits, so
+// a fast unsalted hash is enough and allows lookup by hash.
+func hashAPIKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// displayPrefix returns the leading characters shown to identify a key in listings
+func displayPrefix(secret string) string {
+	return secret[:len(apikey.KeyPrefix)+8]
+}
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"callflow/internal/domain/auth"
+	"callflow/internal/domain/device"
+	"callflow/internal/domain/token"
 	"callflow/internal/domain/user"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -17,15 +19,21 @@ const accessTokenExpiry = 365 * 24 * time.Hour // 1 year
 
 // AuthService provides authentication functionality
 type AuthService struct {
-	userRepo  user.Repository
-	jwtSecret []byte
+	userRepo    user.Repository
+	deviceRepo  device.Repository
+	tokenRepo   token.Repository
+	attemptRepo auth.Repository
+	keyring     *JWTKeyring
 }
 
 // NewAuthService creates a new auth service instance
-func NewAuthService(userRepo user.Repository, jwtSecret string) *AuthService {
+func NewAuthService(userRepo user.Repository, deviceRepo device.Repository, tokenRepo token.Repository, attemptRepo auth.Repository, keyring *JWTKeyring) *AuthService {
 	return &AuthService{
-		userRepo:  userRepo,
-		jwtSecret: []byte(jwtSecret),
+		userRepo:    userRepo,
+		deviceRepo:  deviceRepo,
+		tokenRepo:   tokenRepo,
+		attemptRepo: attemptRepo,
+		keyring:     keyring,
 	}
 }
 
@@ -56,15 +64,23 @@ func (s *AuthService) Register(ctx context.Context, req auth.RegisterRequest) (*
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
-	return s.tokenResponseForUser(u)
+	return s.tokenResponseForUser(ctx, u, req.Device)
 }
 
 // Login authenticates a user with phone and password
 func (s *AuthService) Login(ctx context.Context, req auth.LoginRequest) (*auth.TokenResponse, error) {
+	attempts, err := s.attemptRepo.GetAttempts(ctx, req.Phone)
+	if err != nil && !errors.Is(err, auth.ErrAttemptsNotFound) {
+		return nil, fmt.Errorf("failed to lookup login attempts: %w", err)
+	}
+	if attempts != nil && attempts.IsLocked(time.Now()) {
+		return nil, auth.ErrAccountLocked
+	}
+
 	u, err := s.userRepo.GetByPhone(ctx, req.Phone)
 	if err != nil {
 		if errors.Is(err, user.ErrUserNotFound) {
-			return nil, auth.ErrInvalidCredentials
+			return nil, s.recordLoginFailure(ctx, req.Phone, req.ClientIP)
 		}
 		return nil, fmt.Errorf("failed to lookup user: %w", err)
 	}
@@ -74,20 +90,75 @@ func (s *AuthService) Login(ctx context.Context, req auth.LoginRequest) (*auth.T
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(req.Password)); err != nil {
-		return nil, auth.ErrInvalidCredentials
+		return nil, s.recordLoginFailure(ctx, req.Phone, req.ClientIP)
+	}
+
+	if attempts != nil {
+		if err := s.attemptRepo.ResetAttempts(ctx, req.Phone); err != nil {
+			return nil, fmt.Errorf("failed to reset login attempts: %w", err)
+		}
+	}
+
+	return s.tokenResponseForUser(ctx, u, req.Device)
+}
+
+// GetLockedAccounts returns the phone numbers that are currently locked
+func (s *AuthService) GetLockedAccounts(ctx context.Context) ([]*auth.LoginAttempts, error) {
+	return s.attemptRepo.ListLocked(ctx)
+}
+
+// Unlock clears the failed-login state of a user's phone number
+func (s *AuthService) Unlock(ctx context.Context, userID int64) error {
+	u, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return s.attemptRepo.ResetAttempts(ctx, u.Phone)
+}
+
+// ResetPassword sets a new password for the phone's account and signs out every session
+func (s *AuthService) ResetPassword(ctx context.Context, phone, newPassword string) error {
+	u, err := s.userRepo.GetByPhone(ctx, phone)
+	if err != nil {
+		return fmt.Errorf("failed to lookup user: %w", err)
 	}
 
-	return s.tokenResponseForUser(u)
+	if err := s.setPassword(ctx, u.ID, newPassword); err != nil {
+		return err
+	}
+	// Proving ownership of the phone also lifts any login lockout.
+	if err := s.attemptRepo.ResetAttempts(ctx, phone); err != nil {
+		return fmt.Errorf("failed to reset login attempts: %w", err)
+	}
+	if err := s.userRepo.InvalidateTokens(ctx, u.ID); err != nil {
+		return fmt.Errorf("failed to invalidate legacy tokens: %w", err)
+	}
+	return s.tokenRepo.RevokeAllUserTokens(ctx, u.ID)
+}
+
+// ChangePassword sets a new password and signs out every other session
+func (s *AuthService) ChangePassword(ctx context.Context, userID int64, tokenID string, req auth.ChangePasswordRequest) error {
+	u, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to lookup user: %w", err)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(req.CurrentPassword)); err != nil {
+		return auth.ErrInvalidCredentials
+	}
+
+	if err := s.setPassword(ctx, u.ID, req.NewPassword); err != nil {
+		return err
+	}
+	if err := s.userRepo.InvalidateTokens(ctx, u.ID); err != nil {
+		return fmt.Errorf("failed to invalidate legacy tokens: %w", err)
+	}
+	return s.tokenRepo.RevokeOtherUserTokens(ctx, u.ID, tokenID)
 }
 
 // VerifyToken verifies a JWT token and returns the claims
-func (s *AuthService) VerifyToken(_ context.Context, tokenString string) (*auth.AuthClaims, error) {
-	t, err := jwt.ParseWithClaims(tokenString, &auth.AuthClaims{}, func(t *jwt.Token) (interface{}, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
-		}
-		return s.jwtSecret, nil
-	})
+func (s *AuthService) VerifyToken(ctx context.Context, tokenString string) (*auth.AuthClaims, error) {
+	t, err := jwt.ParseWithClaims(tokenString, &auth.AuthClaims{}, s.keyring.Keyfunc)
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
@@ -105,26 +176,115 @@ func (s *AuthService) VerifyToken(_ context.Context, tokenString string) (*auth.
 		return nil, auth.ErrInvalidToken
 	}
 
+	// A deleted or deactivated account loses its sessions even when its
+	// tokens have not been revoked.
+	u, err := s.userRepo.GetByID(ctx, claims.UserID)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return nil, auth.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to lookup user: %w", err)
+	}
+	if u.Status != user.StatusActive {
+		return nil, auth.ErrUserInactive
+	}
+
+	// Tokens issued before token IDs were introduced carry no jti, so they are
+	// revoked together by the user's tokens_valid_after instead.
+	if claims.ID == "" {
+		if !legacyTokenValid(claims, u) {
+			return nil, auth.ErrRevokedToken
+		}
+		return claims, nil
+	}
+
+	revoked, err := s.tokenRepo.IsTokenRevoked(ctx, claims.ID)
+	if err != nil {
+		if errors.Is(err, token.ErrTokenNotFound) {
+			return nil, auth.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to check token revocation: %w", err)
+	}
+	if revoked {
+		return nil, auth.ErrRevokedToken
+	}
+
 	return claims, nil
 }
 
-func (s *AuthService) tokenResponseForUser(u *user.User) (*auth.TokenResponse, error) {
+// GetJWKS returns the public keys that verify issued tokens
+func (s *AuthService) GetJWKS(ctx context.Context) (*auth.JWKSet, error) {
+	return s.keyring.JWKS(), nil
+}
+
+// recordLoginFailure counts a failed login for the phone and locks it once the
+// limit is reached. Unknown phones are counted too so that probing for
+// registered numbers is throttled the same way.
+func (s *AuthService) recordLoginFailure(ctx context.Context, phone, ip string) error {
+	attempts, err := s.attemptRepo.RecordFailure(ctx, phone, ip)
+	if err != nil {
+		return fmt.Errorf("failed to record login attempt: %w", err)
+	}
+	if attempts.FailedCount < auth.MaxFailedLogins {
+		return auth.ErrInvalidCredentials
+	}
+
+	if err := s.attemptRepo.Lock(ctx, phone, time.Now().Add(lockoutDuration(attempts.Lockouts))); err != nil {
+		return fmt.Errorf("failed to lock account: %w", err)
+	}
+	return auth.ErrAccountLocked
+}
+
+func (s *AuthService) setPassword(ctx context.Context, userID int64, password string) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	if err := s.userRepo.UpdatePassword(ctx, userID, string(hash)); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+	return nil
+}
+
+func (s *AuthService) tokenResponseForUser(ctx context.Context, u *user.User, reg *device.Registration) (*auth.TokenResponse, error) {
 	expiresAt := time.Now().Add(accessTokenExpiry)
 
+	var d *device.Device
+	var deviceID *int64
+	if reg != nil {
+		var err error
+		d, err = s.deviceRepo.Upsert(ctx, u.ID, *reg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to register device: %w", err)
+		}
+		deviceID = &d.ID
+	}
+
+	jti, err := randomToken(16)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token id: %w", err)
+	}
+	if err := s.tokenRepo.StoreDeviceTokenID(ctx, jti, u.ID, deviceID, expiresAt); err != nil {
+		return nil, fmt.Errorf("failed to store token: %w", err)
+	}
+
 	claims := &auth.AuthClaims{
 		UserID: u.ID,
 		Phone:  u.Phone,
 		Plan:   u.Plan,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			Issuer:    "callflow-api",
 			Subject:   fmt.Sprintf("%d", u.ID),
 		},
 	}
+	if deviceID != nil {
+		claims.DeviceID = *deviceID
+	}
 
-	t := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := t.SignedString(s.jwtSecret)
+	tokenString, err := s.keyring.Sign(claims)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign token: %w", err)
 	}
@@ -133,9 +293,11 @@ func (s *AuthService) tokenResponseForUser(u *user.User) (*auth.TokenResponse, e
 		AccessToken: tokenString,
 		TokenType:   "Bearer",
 		ExpiresAt:   expiresAt,
+		Device:      d,
 		User: &auth.UserInfo{
 			ID:            u.ID,
 			Phone:         u.Phone,
+			PhoneVerified: u.PhoneVerified,
 			Name:          u.Name,
 			BusinessName:  u.BusinessName,
 			City:          u.City,
@@ -148,3 +310,28 @@ func (s *AuthService) tokenResponseForUser(u *user.User) (*auth.TokenResponse, e
 		},
 	}, nil
 }
+
+// legacyTokenValid reports whether a jti-less token was issued after the
+// user's sessions were last revoked. IssuedAt has second precision, so a token
+// from the same second as the revocation is rejected.
+func legacyTokenValid(claims *auth.AuthClaims, u *user.User) bool {
+	if u.TokensValidAfter == nil {
+		return true
+	}
+	if claims.IssuedAt == nil {
+		return false
+	}
+	return claims.IssuedAt.Time.After(u.TokensValidAfter.Truncate(time.Second))
+}
+
+// lockoutDuration doubles the base lockout for every earlier lockout, up to the maximum.
+func lockoutDuration(previous int) time.Duration {
+	d := auth.BaseLockout
+	for i := 0; i < previous && d < auth.MaxLockout; i++ {
+		d *= 2
+	}
+	if d > auth.MaxLockout {
+		return auth.MaxLockout
+	}
+	return d
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"callflow/internal/domain/auth"
+	"callflow/internal/domain/user"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestLockoutDuration(t *testing.T) {
+	tests := []struct {
+		previous int
+		want     time.Duration
+	}{
+		{0, auth.BaseLockout},
+		{1, 2 * auth.BaseLockout},
+		{2, 4 * auth.BaseLockout},
+		{5, 32 * auth.BaseLockout},
+		{10, 1024 * auth.BaseLockout},
+		{11, auth.MaxLockout},
+		{100, auth.MaxLockout},
+		{-1, auth.BaseLockout},
+	}
+
+	for _, tt := range tests {
+		if got := lockoutDuration(tt.previous); got != tt.want {
+			t.Errorf("lockoutDuration(%d) = %v, want %v", tt.previous, got, tt.want)
+		}
+	}
+}
+
+func TestLegacyTokenValid(t *testing.T) {
+	cutoff := time.Date(2026, 3, 1, 12, 0, 0, 500*int(time.Millisecond), time.UTC)
+
+	tests := []struct {
+		name        string
+		validAfter  *time.Time
+		issuedAt    *time.Time
+		wantAllowed bool
+	}{
+		{"no cutoff", nil, ptr(cutoff.Add(-time.Hour)), true},
+		{"no cutoff and no iat", nil, nil, true},
+		{"issued before the cutoff", &cutoff, ptr(cutoff.Add(-time.Hour)), false},
+		{"issued in the cutoff second", &cutoff, ptr(cutoff.Truncate(time.Second)), false},
+		{"issued after the cutoff", &cutoff, ptr(cutoff.Add(time.Second)), true},
+		{"no iat with a cutoff", &cutoff, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := &auth.AuthClaims{}
+			if tt.issuedAt != nil {
+				claims.IssuedAt = jwt.NewNumericDate(*tt.issuedAt)
+			}
+			u := &user.User{TokensValidAfter: tt.validAfter}
+			if got := legacyTokenValid(claims, u); got != tt.wantAllowed {
+				t.Errorf("legacyTokenValid() = %v, want %v", got, tt.wantAllowed)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"callflow/internal/domain/campaign"
+	"callflow/internal/domain/device"
+	"callflow/internal/domain/template"
+	"callflow/internal/domain/webhook"
+)
+
+const maxListedRecipients = 500
+
+// CampaignService provides broadcast campaign business logic
+type CampaignService struct {
+	campaignRepo campaign.Repository
+	templateRepo template.Repository
+	deviceRepo   device.Repository
+	events       webhook.Emitter
+}
+
+// NewCampaignService creates a new campaign service instance
+func NewCampaignService(campaignRepo campaign.Repository, templateRepo template.Repository, deviceRepo device.Repository, events webhook.Emitter) *CampaignService {
+	return &CampaignService{
+		campaignRepo: campaignRepo,
+		templateRepo: templateRepo,
+		deviceRepo:   deviceRepo,
+		events:       events,
+	}
+}
+
+func (s *CampaignService) Get(ctx context.Context, userID int64) ([]*campaign.Campaign, error) {
+	return s.campaignRepo.GetByUserID(ctx, userID)
+}
+
+func (s *CampaignService) GetByID(ctx context.Context, id int64, userID int64) (*campaign.Campaign, error) {
+	c, err := s.campaignRepo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	c.Stats, err = s.campaignRepo.GetStats(ctx, c.ID)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (s *CampaignService) Create(ctx context.Context, userID int64, data campaign.CampaignCreate) (*campaign.Campaign, error) {
+	if _, err := s.templateRepo.GetByID(ctx, data.TemplateID, userID); err != nil {
+		if errors.Is(err, template.ErrTemplateNotFound) {
+			return nil, campaign.ErrInvalidTemplate
+		}
+		return nil, err
+	}
+	data.Segment.Tags = normalizeTags(data.Segment.Tags)
+	return s.campaignRepo.Create(ctx, userID, data)
+}
+
+func (s *CampaignService) GetRecipients(ctx context.Context, id int64, userID int64) ([]*campaign.Recipient, error) {
+	if _, err := s.campaignRepo.GetByID(ctx, id, userID); err != nil {
+		return nil, err
+	}
+	return s.campaignRepo.GetRecipients(ctx, id, maxListedRecipients)
+}
+
+func (s *CampaignService) Pause(ctx context.Context, id int64, userID int64) (*campaign.Campaign, error) {
+	if _, err := s.campaignRepo.GetByID(ctx, id, userID); err != nil {
+		return nil, err
+	}
+	c, err := s.campaignRepo.Pause(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	// Recipients whose job is dropped are queued again after resuming, once
+	// their dispatch goes stale.
+	if err := s.campaignRepo.DeleteQueuedJobs(ctx, id); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (s *CampaignService) Resume(ctx context.Context, id int64, userID int64) (*campaign.Campaign, error) {
+	if _, err := s.campaignRepo.GetByID(ctx, id, userID); err != nil {
+		return nil, err
+	}
+	return s.campaignRepo.Resume(ctx, id, userID)
+}
+
+func (s *CampaignService) Cancel(ctx context.Context, id int64, userID int64) (*campaign.Campaign, error) {
+	if _, err := s.campaignRepo.GetByID(ctx, id, userID); err != nil {
+		return nil, err
+	}
+	c, err := s.campaignRepo.Cancel(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.campaignRepo.DeleteQueuedJobs(ctx, id); err != nil {
+		return nil, err
+	}
+	if err := s.campaignRepo.SkipOpenRecipients(ctx, id); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (s *CampaignService) JobSource() string {
+	return device.JobSourceCampaign
+}
+
+func (s *CampaignService) QueueDue(ctx context.Context, userID int64, limit int) error {
+	if err := s.startDueCampaigns(ctx, userID); err != nil {
+		return err
+	}
+
+	running, err := s.campaignRepo.GetRunning(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	queued := 0
+	for _, c := range running {
+		if queued >= limit {
+			break
+		}
+
+		// The throttle is a sliding one-minute window over dispatched recipients.
+		recent, err := s.campaignRepo.CountRecentDispatches(ctx, c.ID, now.Add(-time.Minute))
+		if err != nil {
+			return err
+		}
+		quota := min(c.ThrottlePerMinute-recent, limit-queued)
+		if quota <= 0 {
+			continue
+		}
+
+		// Recipients claimed without a job, because queueing failed, are
+		// claimed again once their dispatch goes stale.
+		recipients, err := s.campaignRepo.ClaimRecipients(ctx, c.ID, quota)
+		if err != nil {
+			return err
+		}
+		if len(recipients) == 0 {
+			if err := s.completeIfDone(ctx, c.ID); err != nil {
+				return err
+			}
+			continue
+		}
+
+		for _, rc := range recipients {
+			payload, err := json.Marshal(device.SMSPayload{Phone: rc.Phone, TemplateID: &c.TemplateID})
+			if err != nil {
+				return err
+			}
+			if _, err := s.deviceRepo.CreateJob(ctx, userID, device.JobCreate{
+				Type:        device.JobTypeSMS,
+				Payload:     payload,
+				MaxAttempts: campaign.MaxAttempts,
+				Source:      device.JobSourceCampaign,
+				RefID:       &rc.ID,
+			}); err != nil {
+				return err
+			}
+			queued++
+		}
+	}
+	return nil
+}
+
+func (s *CampaignService) JobFinished(ctx context.Context, job *device.Job) error {
+	if job.RefID == nil {
+		return nil
+	}
+	result := campaign.Result{RecipientID: *job.RefID, Status: campaign.RecipientSent}
+	eventType := webhook.EventMessageSent
+	if job.Status == device.JobStatusFailed {
+		result.Status = campaign.RecipientFailed
+		result.Error = job.LastError
+		eventType = webhook.EventMessageFailed
+	}
+
+	campaignID, err := s.campaignRepo.ReportRecipient(ctx, job.UserID, result)
+	if err != nil {
+		// Recipients skipped since the job was queued keep their status.
+		if errors.Is(err, campaign.ErrCampaignNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	var payload device.SMSPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return err
+	}
+	s.events.Emit(ctx, job.UserID, eventType, webhook.MessageData{
+		Source:      webhook.SourceCampaign,
+		JobID:       job.ID,
+		CampaignID:  campaignID,
+		RecipientID: result.RecipientID,
+		Phone:       payload.Phone,
+		TemplateID:  payload.TemplateID,
+		Error:       result.Error,
+	})
+	return s.completeIfDone(ctx, campaignID)
+}
+
+// startDueCampaigns moves scheduled campaigns whose time has come to running
+// and expands their segment into recipients.
+func (s *CampaignService) startDueCampaigns(ctx context.Context, userID int64) error {
+	due, err := s.campaignRepo.GetDueScheduled(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range due {
+		var calledAfter *time.Time
+		if days := c.Segment.LastCalledWithinDays; days > 0 {
+			t := time.Now().AddDate(0, 0, -days)
+			calledAfter = &t
+		}
+
+		// The campaign only starts together with its recipients, so a failed
+		// expansion leaves it scheduled for the next pull.
+		started, err := s.campaignRepo.Start(ctx, c, calledAfter)
+		if err != nil {
+			if errors.Is(err, campaign.ErrInvalidTransition) {
+				continue
+			}
+			return err
+		}
+		if started.TotalRecipients == 0 {
+			if err := s.campaignRepo.Complete(ctx, started.ID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *CampaignService) completeIfDone(ctx context.Context, campaignID int64) error {
+	open, err := s.campaignRepo.CountOpenRecipients(ctx, campaignID)
+	if err != nil {
+		return err
+	}
+	if open > 0 {
+		return nil
+	}
+	return s.campaignRepo.Complete(ctx, campaignID)
+}
@@ -2,18 +2,24 @@ package service
 
 import (
 	"context"
+	"strings"
 
 	"callflow/internal/domain/contact"
+	"callflow/internal/domain/webhook"
 )
 
 // ContactService provides contact business logic
 type ContactService struct {
 	contactRepo contact.Repository
+	events      webhook.Emitter
 }
 
 // NewContactService creates a new contact service instance
-func NewContactService(contactRepo contact.Repository) *ContactService {
-	return &ContactService{contactRepo: contactRepo}
+func NewContactService(contactRepo contact.Repository, events webhook.Emitter) *ContactService {
+	return &ContactService{
+		contactRepo: contactRepo,
+		events:      events,
+	}
 }
 
 func (s *ContactService) Get(ctx context.Context, userID int64) ([]*contact.Contact, error) {
@@ -21,9 +27,67 @@ func (s *ContactService) Get(ctx context.Context, userID int64) ([]*contact.Cont
 }
 
 func (s *ContactService) Upsert(ctx context.Context, userID int64, data contact.ContactUpsert) (*contact.Contact, error) {
-	return s.contactRepo.Upsert(ctx, userID, data)
+	ct, created, err := s.contactRepo.Upsert(ctx, userID, data)
+	if err != nil {
+		return nil, err
+	}
+	if created {
+		s.events.Emit(ctx, userID, webhook.EventContactCreated, ct)
+	}
+	return ct, nil
 }
 
 func (s *ContactService) UpsertBatch(ctx context.Context, userID int64, contacts []contact.ContactUpsert) error {
-	return s.contactRepo.UpsertBatch(ctx, userID, contacts)
+	created, err := s.contactRepo.UpsertBatch(ctx, userID, contacts)
+	if err != nil {
+		return err
+	}
+	for _, ct := range created {
+		s.events.Emit(ctx, userID, webhook.EventContactCreated, ct)
+	}
+	return nil
+}
+
+func (s *ContactService) RecordCall(ctx context.Context, userID int64, event contact.CallEvent) (*contact.Contact, error) {
+	ct, created, err := s.contactRepo.RecordCall(ctx, userID, event)
+	if err != nil {
+		return nil, err
+	}
+	if created {
+		s.events.Emit(ctx, userID, webhook.EventContactCreated, ct)
+	}
+	if event.Direction == contact.DirectionMissed {
+		s.events.Emit(ctx, userID, webhook.EventCallMissed, ct)
+	}
+	return ct, nil
+}
+
+func (s *ContactService) OptOut(ctx context.Context, userID int64, phone string) (*contact.Contact, error) {
+	ct, created, err := s.contactRepo.OptOut(ctx, userID, phone)
+	if err != nil {
+		return nil, err
+	}
+	if created {
+		s.events.Emit(ctx, userID, webhook.EventContactCreated, ct)
+	}
+	return ct, nil
+}
+
+func (s *ContactService) SetTags(ctx context.Context, id int64, userID int64, tags []string) (*contact.Contact, error) {
+	return s.contactRepo.SetTags(ctx, id, userID, normalizeTags(tags))
+}
+
+// normalizeTags lowercases and trims tags and drops empty or duplicate entries.
+func normalizeTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, t := range tags {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		normalized = append(normalized, t)
+	}
+	return normalized
 }
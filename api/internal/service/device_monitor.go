@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"callflow/internal/domain/device"
+)
+
+const (
+	defaultDeviceSilentAfter = 30 * time.Minute
+	deviceMonitorInterval    = time.Minute
+	heartbeatRetention       = 30 * 24 * time.Hour
+)
+
+// DeviceMonitor periodically raises alerts for devices that stopped sending heartbeats
+type DeviceMonitor struct {
+	deviceRepo  device.Repository
+	silentAfter time.Duration
+	stopCh      chan struct{}
+}
+
+// NewDeviceMonitor creates a new device monitor
+func NewDeviceMonitor(deviceRepo device.Repository) *DeviceMonitor {
+	return &DeviceMonitor{
+		deviceRepo:  deviceRepo,
+		silentAfter: deviceSilentAfterFromEnv(),
+		stopCh:      make(chan struct{}),
+	}
+}
+
+// Start runs the monitor loop in a background goroutine
+func (m *DeviceMonitor) Start() {
+	go m.loop()
+}
+
+// Stop stops the monitor loop
+func (m *DeviceMonitor) Stop() {
+	close(m.stopCh)
+}
+
+func (m *DeviceMonitor) loop() {
+	ticker := time.NewTicker(deviceMonitorInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.check()
+		case <-m.stopCh:
+			return
+		}
+	}
+}
+
+func (m *DeviceMonitor) check() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	opened, err := m.deviceRepo.OpenSilentAlerts(ctx, now.Add(-m.silentAfter))
+	if err != nil {
+		log.Printf("device monitor: failed to open silent alerts: %v", err)
+	} else if opened > 0 {
+		log.Printf("device monitor: %d device(s) went silent", opened)
+	}
+
+	if _, err := m.deviceRepo.DeleteHeartbeatsBefore(ctx, now.Add(-heartbeatRetention)); err != nil {
+		log.Printf("device monitor: failed to prune heartbeats: %v", err)
+	}
+}
+
+// deviceSilentAfterFromEnv reads DEVICE_SILENT_AFTER_MINUTES, the time without a
+// heartbeat after which a device is considered silent.
+func deviceSilentAfterFromEnv() time.Duration {
+	minutes, err := strconv.Atoi(os.Getenv("DEVICE_SILENT_AFTER_MINUTES"))
+	if err != nil || minutes <= 0 {
+		return defaultDeviceSilentAfter
+	}
+	return time.Duration(minutes) * time.Minute
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// fakeMonitorRepo records the cutoffs the monitor passes to the repository
+type fakeMonitorRepo struct {
+	fakeDeviceRepo
+	silentBefore time.Time
+	pruneBefore  time.Time
+}
+
+func (r *fakeMonitorRepo) OpenSilentAlerts(_ context.Context, silentBefore time.Time) (int64, error) {
+	r.silentBefore = silentBefore
+	return 0, nil
+}
+
+func (r *fakeMonitorRepo) DeleteHeartbeatsBefore(_ context.Context, before time.Time) (int64, error) {
+	r.pruneBefore = before
+	return 0, nil
+}
+
+func TestDeviceSilentAfterFromEnv(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", defaultDeviceSilentAfter},
+		{"15", 15 * time.Minute},
+		{"0", defaultDeviceSilentAfter},
+		{"-5", defaultDeviceSilentAfter},
+		{"soon", defaultDeviceSilentAfter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("DEVICE_SILENT_AFTER_MINUTES", tt.value)
+			if got := deviceSilentAfterFromEnv(); got != tt.want {
+				t.Errorf("deviceSilentAfterFromEnv() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeviceMonitorCheck(t *testing.T) {
+	repo := &fakeMonitorRepo{}
+	m := &DeviceMonitor{deviceRepo: repo, silentAfter: 20 * time.Minute}
+
+	before := time.Now()
+	m.check()
+	after := time.Now()
+
+	tests := []struct {
+		name string
+		got  time.Time
+		age  time.Duration
+	}{
+		{"silent cutoff", repo.silentBefore, 20 * time.Minute},
+		{"heartbeat retention cutoff", repo.pruneBefore, heartbeatRetention},
+	}
+	for _, tt := range tests {
+		if tt.got.Before(before.Add(-tt.age)) || tt.got.After(after.Add(-tt.age)) {
+			t.Errorf("%s = %v, want %v before now", tt.name, tt.got, tt.age)
+		}
+	}
+}
@@ -0,0 +1,353 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"callflow/internal/domain/device"
+	"callflow/internal/domain/landing"
+	"callflow/internal/domain/shortlink"
+	"callflow/internal/domain/template"
+	"callflow/internal/domain/webhook"
+)
+
+// DeviceService provides device job queue business logic
+type DeviceService struct {
+	deviceRepo   device.Repository
+	templateRepo template.Repository
+	landing      landing.Service
+	shortLinks   shortlink.Service
+	events       webhook.Emitter
+	sources      map[string]device.JobSource
+	silentAfter  time.Duration
+}
+
+// NewDeviceService creates a new device service instance. Sources are the
+// features whose messages are sent through the job queue.
+func NewDeviceService(deviceRepo device.Repository, templateRepo template.Repository, landing landing.Service, shortLinks shortlink.Service, events webhook.Emitter, sources ...device.JobSource) *DeviceService {
+	bySource := make(map[string]device.JobSource, len(sources))
+	for _, src := range sources {
+		bySource[src.JobSource()] = src
+	}
+	return &DeviceService{
+		deviceRepo:   deviceRepo,
+		templateRepo: templateRepo,
+		landing:      landing,
+		shortLinks:   shortLinks,
+		events:       events,
+		sources:      bySource,
+		silentAfter:  deviceSilentAfterFromEnv(),
+	}
+}
+
+func (s *DeviceService) Get(ctx context.Context, userID int64) ([]*device.Device, error) {
+	return s.deviceRepo.GetByUserID(ctx, userID)
+}
+
+func (s *DeviceService) Revoke(ctx context.Context, id int64, userID int64) error {
+	_, err := s.deviceRepo.Revoke(ctx, id, userID)
+	return err
+}
+
+func (s *DeviceService) SetSMSLine(ctx context.Context, id int64, userID int64, line string) (*device.Device, error) {
+	return s.deviceRepo.SetSMSLine(ctx, id, userID, strings.TrimSpace(line))
+}
+
+func (s *DeviceService) GetSMSAssignment(ctx context.Context, userID, actorID, deviceID int64) (*device.SMSAssignment, error) {
+	lines, err := s.deviceRepo.GetAssignedLines(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	assignment := &device.SMSAssignment{AssignedLines: lines}
+	if deviceID == 0 {
+		return assignment, nil
+	}
+
+	d, err := s.deviceRepo.GetByID(ctx, deviceID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	assignment.DeviceID = d.ID
+	assignment.SMSLine = d.SMSLine
+	return assignment, nil
+}
+
+func (s *DeviceService) Heartbeat(ctx context.Context, userID int64, deviceID int64, hb device.Heartbeat) (*device.Device, error) {
+	var d *device.Device
+	var err error
+	switch {
+	case deviceID != 0:
+		d, err = s.deviceRepo.GetByID(ctx, deviceID, userID)
+	case hb.DeviceID != "":
+		d, err = s.deviceRepo.GetByDeviceID(ctx, userID, hb.DeviceID)
+	default:
+		err = device.ErrDeviceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	health := device.Health{
+		BatteryOptimized: hb.BatteryOptimized,
+		Permissions:      hb.Permissions,
+		AppVersion:       hb.AppVersion,
+		QueueDepth:       hb.QueueDepth,
+		LastCallAt:       hb.LastCallAt,
+		Problems:         healthProblems(hb),
+		ReportedAt:       time.Now(),
+	}
+	if health.Permissions == nil {
+		health.Permissions = map[string]bool{}
+	}
+
+	d, err = s.deviceRepo.RecordHeartbeat(ctx, d.ID, hb, health)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.deviceRepo.ResolveAlert(ctx, d.ID, device.AlertSilent); err != nil {
+		return nil, err
+	}
+	if len(health.Problems) == 0 {
+		err = s.deviceRepo.ResolveAlert(ctx, d.ID, device.AlertUnhealthy)
+	} else {
+		err = s.deviceRepo.OpenAlert(ctx, d, device.AlertUnhealthy, "Device reported: "+strings.Join(health.Problems, ", "))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (s *DeviceService) GetUnhealthy(ctx context.Context) ([]*device.Device, error) {
+	return s.deviceRepo.GetUnhealthy(ctx, time.Now().Add(-s.silentAfter))
+}
+
+func (s *DeviceService) GetOpenAlerts(ctx context.Context) ([]*device.Alert, error) {
+	return s.deviceRepo.GetOpenAlerts(ctx, device.MaxListedAlerts)
+}
+
+func (s *DeviceService) Enqueue(ctx context.Context, userID int64, data device.JobCreate) (*device.Job, error) {
+	if err := s.validatePayload(ctx, userID, data); err != nil {
+		return nil, err
+	}
+	return s.deviceRepo.CreateJob(ctx, userID, data)
+}
+
+func (s *DeviceService) GetJobs(ctx context.Context, userID int64) ([]*device.Job, error) {
+	return s.deviceRepo.GetJobs(ctx, userID, device.MaxListedJobs)
+}
+
+func (s *DeviceService) Lease(ctx context.Context, userID, actorID, deviceID int64, req device.LeaseRequest) (*device.Lease, error) {
+	if deviceID == 0 {
+		return nil, device.ErrDeviceNotFound
+	}
+	d, err := s.deviceRepo.GetByID(ctx, deviceID, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = device.DefaultLeaseLimit
+	}
+	visibility := req.VisibilitySeconds
+	if visibility <= 0 {
+		visibility = device.DefaultVisibilitySeconds
+	}
+
+	// Jobs whose last lease ran out on their final attempt are not handed out again.
+	exhausted, err := s.deviceRepo.FailExhaustedJobs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range exhausted {
+		s.finish(ctx, job, webhook.EventMessageFailed)
+	}
+
+	// A feature that fails to queue its messages does not hold up other jobs.
+	for name, src := range s.sources {
+		if err := src.QueueDue(ctx, userID, limit); err != nil {
+			log.Printf("failed to queue %s jobs for user %d: %v", name, userID, err)
+		}
+	}
+
+	token, err := randomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := time.Now().Add(time.Duration(visibility) * time.Second)
+
+	jobs, err := s.deviceRepo.LeaseJobs(ctx, userID, d.DeviceID, token, expiresAt, limit)
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range jobs {
+		s.attachLandingURL(ctx, job)
+	}
+	return &device.Lease{
+		LeaseToken: token,
+		ExpiresAt:  expiresAt,
+		Jobs:       jobs,
+	}, nil
+}
+
+func (s *DeviceService) Ack(ctx context.Context, userID int64, req device.AckRequest) (*device.Job, error) {
+	job, err := s.deviceRepo.AckJob(ctx, userID, req.JobID, req.LeaseToken)
+	if err != nil {
+		return nil, err
+	}
+	s.finish(ctx, job, webhook.EventMessageSent)
+	return job, nil
+}
+
+func (s *DeviceService) Fail(ctx context.Context, userID int64, req device.FailRequest) (*device.Job, error) {
+	job, err := s.deviceRepo.FailJob(ctx, userID, req)
+	if err != nil {
+		return nil, err
+	}
+	// Jobs queued for a retry have not failed yet.
+	if job.Status == device.JobStatusFailed {
+		s.finish(ctx, job, webhook.EventMessageFailed)
+	}
+	return job, nil
+}
+
+// finish hands a job that is done or failed for good to the feature that
+// queued it, which reports it on its own, or raises the message event.
+func (s *DeviceService) finish(ctx context.Context, job *device.Job, eventType string) {
+	src, ok := s.sources[job.Source]
+	if !ok {
+		s.emitMessageEvent(ctx, job, eventType)
+		return
+	}
+	if err := src.JobFinished(ctx, job); err != nil {
+		log.Printf("failed to report %s job %d: %v", job.Source, job.ID, err)
+	}
+}
+
+// attachLandingURL gives an SMS job the short landing link that attributes
+// visits to it, or to the campaign recipient it was queued for. A job leased
+// again keeps the link issued for it before. The job is still sent without one
+// if issuing fails, and with the long link if only shortening fails.
+func (s *DeviceService) attachLandingURL(ctx context.Context, job *device.Job) {
+	if job.Type != device.JobTypeSMS {
+		return
+	}
+	var payload device.SMSPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return
+	}
+	linkSource, shortSource, refID := landing.LinkSourceJob, shortlink.SourceJob, &job.ID
+	if job.Source == device.JobSourceCampaign && job.RefID != nil {
+		linkSource, shortSource, refID = landing.LinkSourceCampaign, shortlink.SourceCampaign, job.RefID
+	}
+	short, err := s.shortLinks.GetByRef(ctx, job.UserID, shortSource, *refID)
+	if err == nil {
+		job.LandingURL = short.ShortURL
+		return
+	}
+	if !errors.Is(err, shortlink.ErrShortLinkNotFound) {
+		log.Printf("failed to look up landing link for job %d: %v", job.ID, err)
+		return
+	}
+	link, err := s.landing.CreateLink(ctx, job.UserID, landing.LinkCreate{
+		Source: linkSource,
+		RefID:  refID,
+		Phone:  &payload.Phone,
+	})
+	if err != nil {
+		log.Printf("failed to issue landing link for job %d: %v", job.ID, err)
+		return
+	}
+	job.LandingURL = link.URL
+	short, err = s.shortLinks.Create(ctx, job.UserID, shortlink.ShortLinkCreate{
+		URL:    link.URL,
+		Phone:  &payload.Phone,
+		Source: shortSource,
+		RefID:  refID,
+	})
+	if err != nil {
+		log.Printf("failed to shorten landing link for job %d: %v", job.ID, err)
+		return
+	}
+	job.LandingURL = short.ShortURL
+}
+
+// emitMessageEvent raises a message event for a finished SMS job
+func (s *DeviceService) emitMessageEvent(ctx context.Context, job *device.Job, eventType string) {
+	if job.Type != device.JobTypeSMS {
+		return
+	}
+	var payload device.SMSPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return
+	}
+	s.events.Emit(ctx, job.UserID, eventType, webhook.MessageData{
+		Source:     webhook.SourceDeviceJob,
+		JobID:      job.ID,
+		Phone:      payload.Phone,
+		TemplateID: payload.TemplateID,
+		Error:      job.LastError,
+	})
+}
+
+func (s *DeviceService) validatePayload(ctx context.Context, userID int64, data device.JobCreate) error {
+	switch data.Type {
+	case device.JobTypeSMS:
+		var payload device.SMSPayload
+		if err := json.Unmarshal(data.Payload, &payload); err != nil {
+			return device.ErrInvalidPayload
+		}
+		if payload.Phone == "" || (payload.TemplateID == nil && payload.Body == "") {
+			return device.ErrInvalidPayload
+		}
+		if payload.TemplateID != nil {
+			if _, err := s.templateRepo.GetByID(ctx, *payload.TemplateID, userID); err != nil {
+				if errors.Is(err, template.ErrTemplateNotFound) {
+					return device.ErrInvalidTemplate
+				}
+				return err
+			}
+		}
+		return nil
+	default:
+		return device.ErrInvalidPayload
+	}
+}
+
+// healthProblems lists what keeps the app from reliably handling calls.
+func healthProblems(hb device.Heartbeat) []string {
+	problems := []string{}
+	if hb.BatteryOptimized {
+		problems = append(problems, device.ProblemBatteryOptimized)
+	}
+	missing := make([]string, 0, len(hb.Permissions))
+	for name, granted := range hb.Permissions {
+		if !granted {
+			missing = append(missing, device.ProblemPermissionMissing+":"+name)
+		}
+	}
+	sort.Strings(missing)
+	problems = append(problems, missing...)
+	if hb.QueueDepth > device.QueueBacklogThreshold {
+		problems = append(problems, device.ProblemQueueBacklog)
+	}
+	return problems
+}
+
+// randomToken returns n random bytes encoded as hex.
+func randomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"callflow/internal/domain/device"
+)
+
+// fakeDeviceRepo keeps one user's devices and their open alerts in memory. It
+// embeds device.Repository so tests only implement the methods they use.
+type fakeDeviceRepo struct {
+	device.Repository
+	devices    map[int64]*device.Device
+	alerts     map[string]string
+	heartbeats []device.Heartbeat
+}
+
+func newFakeDeviceRepo(devices ...*device.Device) *fakeDeviceRepo {
+	r := &fakeDeviceRepo{devices: map[int64]*device.Device{}, alerts: map[string]string{}}
+	for _, d := range devices {
+		r.devices[d.ID] = d
+	}
+	return r
+}
+
+func (r *fakeDeviceRepo) GetByID(_ context.Context, id int64, userID int64) (*device.Device, error) {
+	if d, ok := r.devices[id]; ok && d.UserID == userID {
+		return d, nil
+	}
+	return nil, device.ErrDeviceNotFound
+}
+
+func (r *fakeDeviceRepo) GetByDeviceID(_ context.Context, userID int64, deviceID string) (*device.Device, error) {
+	for _, d := range r.devices {
+		if d.UserID == userID && d.DeviceID == deviceID {
+			return d, nil
+		}
+	}
+	return nil, device.ErrDeviceNotFound
+}
+
+func (r *fakeDeviceRepo) RecordHeartbeat(_ context.Context, id int64, hb device.Heartbeat, health device.Health) (*device.Device, error) {
+	r.heartbeats = append(r.heartbeats, hb)
+	d := r.devices[id]
+	d.Health = &health
+	d.Healthy = len(health.Problems) == 0
+	return d, nil
+}
+
+func (r *fakeDeviceRepo) OpenAlert(_ context.Context, _ *device.Device, kind, message string) error {
+	r.alerts[kind] = message
+	return nil
+}
+
+func (r *fakeDeviceRepo) ResolveAlert(_ context.Context, _ int64, kind string) error {
+	delete(r.alerts, kind)
+	return nil
+}
+
+func TestHealthProblems(t *testing.T) {
+	tests := []struct {
+		name string
+		hb   device.Heartbeat
+		want []string
+	}{
+		{"healthy", device.Heartbeat{Permissions: map[string]bool{"phone": true}}, []string{}},
+		{"no permissions reported", device.Heartbeat{}, []string{}},
+		{"battery optimized", device.Heartbeat{BatteryOptimized: true}, []string{device.ProblemBatteryOptimized}},
+		{
+			"missing permissions are sorted",
+			device.Heartbeat{Permissions: map[string]bool{"sms": false, "call_log": false, "phone": true}},
+			[]string{device.ProblemPermissionMissing + ":call_log", device.ProblemPermissionMissing + ":sms"},
+		},
+		{"queue at the threshold", device.Heartbeat{QueueDepth: device.QueueBacklogThreshold}, []string{}},
+		{"queue over the threshold", device.Heartbeat{QueueDepth: device.QueueBacklogThreshold + 1}, []string{device.ProblemQueueBacklog}},
+		{
+			"all problems",
+			device.Heartbeat{BatteryOptimized: true, Permissions: map[string]bool{"sms": false}, QueueDepth: 100},
+			[]string{device.ProblemBatteryOptimized, device.ProblemPermissionMissing + ":sms", device.ProblemQueueBacklog},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := healthProblems(tt.hb); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("healthProblems() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeviceHeartbeat(t *testing.T) {
+	const userID = 7
+
+	tests := []struct {
+		name        string
+		deviceID    int64
+		hb          device.Heartbeat
+		openAlerts  []string
+		wantErr     error
+		wantHealthy bool
+		wantAlerts  []string
+	}{
+		{"healthy heartbeat by token device", 1, device.Heartbeat{}, nil, nil, true, nil},
+		{"healthy heartbeat by device_id", 0, device.Heartbeat{DeviceID: "pixel-1"}, nil, nil, true, nil},
+		{"unknown device_id", 0, device.Heartbeat{DeviceID: "other"}, nil, device.ErrDeviceNotFound, false, nil},
+		{"no device", 0, device.Heartbeat{}, nil, device.ErrDeviceNotFound, false, nil},
+		{"another user's device", 2, device.Heartbeat{}, nil, device.ErrDeviceNotFound, false, nil},
+		{"heartbeat resolves silent and unhealthy alerts", 1, device.Heartbeat{}, []string{device.AlertSilent, device.AlertUnhealthy}, nil, true, nil},
+		{"problems open an unhealthy alert", 1, device.Heartbeat{BatteryOptimized: true}, []string{device.AlertSilent}, nil, false, []string{device.AlertUnhealthy}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeDeviceRepo(
+				&device.Device{ID: 1, UserID: userID, DeviceID: "pixel-1"},
+				&device.Device{ID: 2, UserID: userID + 1, DeviceID: "pixel-2"},
+			)
+			for _, kind := range tt.openAlerts {
+				repo.alerts[kind] = "open"
+			}
+			s := &DeviceService{deviceRepo: repo}
+
+			d, err := s.Heartbeat(context.Background(), userID, tt.deviceID, tt.hb)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Heartbeat() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if len(repo.heartbeats) != 0 {
+					t.Errorf("recorded %d heartbeat(s) for a rejected request", len(repo.heartbeats))
+				}
+				return
+			}
+			if d.Healthy != tt.wantHealthy {
+				t.Errorf("healthy = %v, want %v", d.Healthy, tt.wantHealthy)
+			}
+			if d.Health.Permissions == nil {
+				t.Error("health permissions = nil, want an empty map")
+			}
+			var open []string
+			for kind := range repo.alerts {
+				open = append(open, kind)
+			}
+			if !reflect.DeepEqual(open, tt.wantAlerts) {
+				t.Errorf("open alerts = %v, want %v", open, tt.wantAlerts)
+			}
+		})
+	}
+}
+
+func TestDeviceUnhealthyAlertMessage(t *testing.T) {
+	repo := newFakeDeviceRepo(&device.Device{ID: 1, UserID: 7})
+	s := &DeviceService{deviceRepo: repo}
+
+	hb := device.Heartbeat{BatteryOptimized: true, Permissions: map[string]bool{"sms": false}}
+	if _, err := s.Heartbeat(context.Background(), 7, 1, hb); err != nil {
+		t.Fatal(err)
+	}
+	want := "Device reported: " + device.ProblemBatteryOptimized + ", " + device.ProblemPermissionMissing + ":sms"
+	if got := repo.alerts[device.AlertUnhealthy]; got != want {
+		t.Errorf("alert message = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"callflow/internal/domain/landing"
+	"callflow/internal/domain/media"
+	"callflow/internal/domain/template"
+)
+
+// ImageMigrationResult counts the images handled by an ImageMigrator run
+type ImageMigrationResult struct {
+	Moved   int
+	Skipped int
+	Failed  int
+}
+
+// ImageMigrator copies template and landing images, with their variants, from
+// one ImageStore to another and points the rows at the new keys.
+type ImageMigrator struct {
+	templateRepo template.Repository
+	landingRepo  landing.Repository
+	mediaRepo    media.Repository
+	from         ImageStore
+	to           ImageStore
+	dryRun       bool
+	deleteSource bool
+}
+
+// NewImageMigrator creates a migrator. With dryRun nothing is copied or
+// updated; with deleteSource the source object is removed once its row points
+// at the copy.
+func NewImageMigrator(templateRepo template.Repository, landingRepo landing.Repository, mediaRepo media.Repository, from, to ImageStore, dryRun, deleteSource bool) *ImageMigrator {
+	return &ImageMigrator{
+		templateRepo: templateRepo,
+		landingRepo:  landingRepo,
+		mediaRepo:    mediaRepo,
+		from:         from,
+		to:           to,
+		dryRun:       dryRun,
+		deleteSource: deleteSource,
+	}
+}
+
+// Run migrates every stored image. Images missing from the source, typically
+// because an earlier run already moved them, are skipped, so it is safe to
+// run again after a partial failure.
+func (m *ImageMigrator) Run(ctx context.Context) (*ImageMigrationResult, error) {
+	result := &ImageMigrationResult{}
+
+	templates, err := m.templateRepo.GetWithImages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range templates {
+		label := fmt.Sprintf("template %d", t.ID)
+		m.migrate(ctx, result, label, *t.ImageKey, func(stored *StoredImage) error {
+			err := m.templateRepo.ReplaceImage(ctx, t.ID, *t.ImageKey, stored.URL, stored.Key)
+			if errors.Is(err, template.ErrTemplateNotFound) {
+				return errImageChanged
+			}
+			return err
+		})
+	}
+
+	landings, err := m.landingRepo.GetWithImages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range landings {
+		label := fmt.Sprintf("landing page of user %d", l.UserID)
+		m.migrate(ctx, result, label, *l.ImageKey, func(stored *StoredImage) error {
+			err := m.landingRepo.ReplaceImage(ctx, l.UserID, *l.ImageKey, stored.URL, stored.Key)
+			if errors.Is(err, landing.ErrLandingNotFound) {
+				return errImageChanged
+			}
+			return err
+		})
+	}
+
+	return result, nil
+}
+
+// errImageChanged reports that a row's image was replaced while it was being copied
+var errImageChanged = errors.New("image changed during migration")
+
+// migrate copies one image and its variants, and saves the new location with
+// replace
+func (m *ImageMigrator) migrate(ctx context.Context, result *ImageMigrationResult, label, key string, replace func(*StoredImage) error) {
+	variants, err := m.mediaRepo.GetVariants(ctx, key)
+	if err != nil {
+		log.Printf("fail %s: load variants of %s: %v", label, key, err)
+		result.Failed++
+		return
+	}
+
+	stored, err := m.copy(ctx, key)
+	if errors.Is(err, ErrImageNotFound) {
+		log.Printf("skip %s: %s not found in source store", label, key)
+		result.Skipped++
+		return
+	}
+	if err != nil {
+		log.Printf("fail %s: copy %s: %v", label, key, err)
+		result.Failed++
+		return
+	}
+	if m.dryRun {
+		log.Printf("would move %s: %s and %d variants", label, key, len(variants))
+		result.Moved++
+		return
+	}
+
+	copies := []*StoredImage{stored}
+	moved := make([]media.Variant, 0, len(variants))
+	for _, v := range variants {
+		next := *v
+		next.ImageKey = stored.Key
+		if v.Key == key {
+			next.Key, next.URL = stored.Key, stored.URL
+		} else {
+			c, err := m.copy(ctx, v.Key)
+			if err != nil {
+				log.Printf("fail %s: copy variant %s: %v", label, v.Key, err)
+				m.deleteCopies(copies)
+				result.Failed++
+				return
+			}
+			copies = append(copies, c)
+			next.Key, next.URL = c.Key, c.URL
+		}
+		moved = append(moved, next)
+	}
+
+	if err := replace(stored); err != nil {
+		m.deleteCopies(copies)
+		if errors.Is(err, errImageChanged) {
+			log.Printf("skip %s: image changed during migration", label)
+			result.Skipped++
+			return
+		}
+		log.Printf("fail %s: update row: %v", label, err)
+		result.Failed++
+		return
+	}
+	if err := m.mediaRepo.Rename(ctx, key, stored.Key, stored.URL); err != nil {
+		log.Printf("failed to rename media %s: %v", key, err)
+	}
+	if err := m.mediaRepo.DeleteVariants(ctx, key); err != nil {
+		log.Printf("failed to delete variant rows of %s: %v", key, err)
+	}
+	for _, v := range moved {
+		if _, err := m.mediaRepo.CreateVariant(ctx, v); err != nil {
+			log.Printf("failed to save variant %s of %s: %v", v.Name, stored.Key, err)
+		}
+	}
+	log.Printf("moved %s: %s -> %s", label, key, stored.Key)
+	result.Moved++
+
+	if m.deleteSource {
+		keys := []string{key}
+		for _, v := range variants {
+			if v.Key != key {
+				keys = append(keys, v.Key)
+			}
+		}
+		for _, k := range keys {
+			if err := m.from.Delete(ctx, k); err != nil {
+				log.Printf("failed to delete source image %s: %v", k, err)
+			}
+		}
+	}
+}
+
+// copy reads key from the source store and writes it to the target store. In
+// a dry run it only checks that the source exists.
+func (m *ImageMigrator) copy(ctx context.Context, key string) (*StoredImage, error) {
+	data, contentType, err := m.from.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if m.dryRun {
+		return &StoredImage{Key: key}, nil
+	}
+	return m.to.Put(ctx, imageFileName(key), contentType, data)
+}
+
+// deleteCopies removes the target objects of a migration that did not complete
+func (m *ImageMigrator) deleteCopies(copies []*StoredImage) {
+	for _, c := range copies {
+		if err := m.to.Delete(context.Background(), c.Key); err != nil {
+			log.Printf("failed to delete orphaned copy %s: %v", c.Key, err)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+
+	"callflow/internal/domain/media"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// processedImage is an encoded rendition ready to store
+type processedImage struct {
+	name        string
+	data        []byte
+	contentType string
+	width       int
+	height      int
+}
+
+// processImage decodes an uploaded JPEG, PNG or WebP, turns it upright and
+// re-encodes it as the original, thumbnail and MMS variants. Re-encoding drops
+// all metadata, EXIF and GPS included.
+func processImage(file []byte) ([]*processedImage, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(file))
+	if err != nil {
+		return nil, media.ErrInvalidImage
+	}
+	if cfg.Width*cfg.Height > media.MaxPixels {
+		return nil, media.ErrImageTooLarge
+	}
+	decoded, _, err := image.Decode(bytes.NewReader(file))
+	if err != nil {
+		return nil, media.ErrInvalidImage
+	}
+	img := orient(toNRGBA(decoded), jpegOrientation(file))
+
+	original, err := encodeOriginal(fit(img, media.MaxDimension))
+	if err != nil {
+		return nil, err
+	}
+	thumbnail, err := encodeJPEG(media.VariantThumbnail, fit(img, media.ThumbnailDimension), 75)
+	if err != nil {
+		return nil, err
+	}
+	mms, err := encodeMMS(img)
+	if err != nil {
+		return nil, err
+	}
+	return []*processedImage{original, thumbnail, mms}, nil
+}
+
+// encodeOriginal keeps transparency as PNG and stores opaque images as JPEG
+func encodeOriginal(img *image.NRGBA) (*processedImage, error) {
+	if img.Opaque() {
+		return encodeJPEG(media.VariantOriginal, img, 85)
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	b := img.Bounds()
+	return &processedImage{
+		name:        media.VariantOriginal,
+		data:        buf.Bytes(),
+		contentType: "image/png",
+		width:       b.Dx(),
+		height:      b.Dy(),
+	}, nil
+}
+
+// encodeMMS lowers the quality, then the size, until the JPEG fits
+// media.MMSMaxBytes. The smallest attempt is kept if nothing fits.
+func encodeMMS(img *image.NRGBA) (*processedImage, error) {
+	var smallest *processedImage
+	for dim := media.MMSDimension; dim >= 160; dim = dim * 3 / 4 {
+		scaled := fit(img, dim)
+		for quality := 80; quality >= 40; quality -= 10 {
+			out, err := encodeJPEG(media.VariantMMS, scaled, quality)
+			if err != nil {
+				return nil, err
+			}
+			if len(out.data) <= media.MMSMaxBytes {
+				return out, nil
+			}
+			if smallest == nil || len(out.data) < len(smallest.data) {
+				smallest = out
+			}
+		}
+	}
+	return smallest, nil
+}
+
+// encodeJPEG flattens transparency onto white, as JPEG has no alpha
+func encodeJPEG(name string, img *image.NRGBA, quality int) (*processedImage, error) {
+	b := img.Bounds()
+	canvas := image.NewRGBA(b)
+	draw.Draw(canvas, b, image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(canvas, b, img, b.Min, draw.Over)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, canvas, &jpeg.Options{Quality: quality}); err != nil {
+		return nil, err
+	}
+	return &processedImage{
+		name:        name,
+		data:        buf.Bytes(),
+		contentType: "image/jpeg",
+		width:       b.Dx(),
+		height:      b.Dy(),
+	}, nil
+}
+
+// fit scales img down to fit within limit x limit, keeping its aspect ratio.
+// It never scales up.
+func fit(img *image.NRGBA, limit int) *image.NRGBA {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w <= limit && h <= limit {
+		return img
+	}
+	if w >= h {
+		h = limit * h / w
+		w = limit
+	} else {
+		w = limit * w / h
+		h = limit
+	}
+	if w < 1 {
+		w = 1
+	}
+	if h < 1 {
+		h = 1
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, b, draw.Src, nil)
+	return dst
+}
+
+func toNRGBA(img image.Image) *image.NRGBA {
+	if n, ok := img.(*image.NRGBA); ok && n.Rect.Min == (image.Point{}) {
+		return n
+	}
+	b := img.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Bounds(), img, b.Min, draw.Src)
+	return dst
+}
+
+// orient applies an EXIF orientation (1-8) so the image displays upright
+func orient(img *image.NRGBA, orientation int) *image.NRGBA {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+	w, h := img.Rect.Dx(), img.Rect.Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2: // mirrored
+				sx, sy = w-1-x, y
+			case 3: // rotated 180°
+				sx, sy = w-1-x, h-1-y
+			case 4: // mirrored vertically
+				sx, sy = x, h-1-y
+			case 5: // transposed
+				sx, sy = y, x
+			case 6: // rotated 90° clockwise
+				sx, sy = y, h-1-x
+			case 7: // transversed
+				sx, sy = w-1-y, h-1-x
+			case 8: // rotated 90° counter-clockwise
+				sx, sy = w-1-y, x
+			}
+			copy(dst.Pix[dst.PixOffset(x, y):dst.PixOffset(x, y)+4], img.Pix[img.PixOffset(sx, sy):img.PixOffset(sx, sy)+4])
+		}
+	}
+	return dst
+}
+
+// jpegOrientation returns the EXIF orientation tag of a JPEG, or 1 when the
+// file is not a JPEG or has none.
+func jpegOrientation(file []byte) int {
+	if len(file) < 4 || file[0] != 0xFF || file[1] != 0xD8 {
+		return 1
+	}
+	for i := 2; i+4 <= len(file); {
+		if file[i] != 0xFF {
+			return 1
+		}
+		marker := file[i+1]
+		if marker == 0xDA || marker == 0xD9 { // start of scan, end of image
+			return 1
+		}
+		size := int(binary.BigEndian.Uint16(file[i+2:]))
+		if size < 2 || i+2+size > len(file) {
+			return 1
+		}
+		segment := file[i+4 : i+2+size]
+		if marker == 0xE1 && len(segment) > 6 && string(segment[:6]) == "Exif\x00\x00" {
+			return exifOrientation(segment[6:])
+		}
+		i += 2 + size
+	}
+	return 1
+}
+
+// exifOrientation reads tag 0x0112 from the first IFD of a TIFF structure
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	offset := int(order.Uint32(tiff[4:]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 1
+	}
+	count := int(order.Uint16(tiff[offset:]))
+	for n := 0; n < count; n++ {
+		entry := offset + 2 + n*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			return int(order.Uint16(tiff[entry+8:]))
+		}
+	}
+	return 1
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrImageNotFound is returned by ImageStore.Get when the key does not exist
+var ErrImageNotFound = errors.New("image not found")
+
+// StoredImage is an image saved in an ImageStore
+type StoredImage struct {
+	URL string
+	Key string
+}
+
+// ImageStore keeps uploaded template and landing images. Keys are opaque and
+// only meaningful to the backend that issued them.
+type ImageStore interface {
+	Put(ctx context.Context, filename, contentType string, file []byte) (*StoredImage, error)
+	Get(ctx context.Context, key string) ([]byte, string, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// Image store backends selectable through IMAGE_STORE
+const (
+	ImageStoreUploadThing = "uploadthing"
+	ImageStoreLocal       = "local"
+	ImageStoreS3          = "s3"
+)
+
+// NewImageStoreFromEnv returns the backend selected by IMAGE_STORE. When it is
+// unset, UploadThing is used if UPLOADTHING_TOKEN is set; otherwise uploads are
+// disabled and the returned store is nil.
+func NewImageStoreFromEnv() (ImageStore, error) {
+	backend := strings.TrimSpace(os.Getenv("IMAGE_STORE"))
+	if backend == "" {
+		if strings.TrimSpace(os.Getenv(uploadThingTokenEnv)) == "" {
+			return nil, nil
+		}
+		backend = ImageStoreUploadThing
+	}
+	return NewImageStore(backend)
+}
+
+// NewImageStore returns the named backend configured from its environment variables
+func NewImageStore(backend string) (ImageStore, error) {
+	// Each case returns explicitly so a failed constructor never yields a
+	// non-nil interface holding a nil pointer.
+	switch backend {
+	case ImageStoreUploadThing:
+		store, err := NewUploadThingImageStoreFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		return store, nil
+	case ImageStoreLocal:
+		store, err := NewLocalImageStoreFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		return store, nil
+	case ImageStoreS3:
+		store, err := NewS3ImageStoreFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		return store, nil
+	default:
+		return nil, fmt.Errorf("unknown IMAGE_STORE %q", backend)
+	}
+}
+
+// newImageKey builds a unique key that keeps the (sanitized) file name and an
+// extension matching the content type, so the object is served with it.
+func newImageKey(filename, contentType string) (string, error) {
+	token, err := randomToken(16)
+	if err != nil {
+		return "", err
+	}
+	name := sanitizeFileName(filename)
+	if name == "" {
+		name = "image"
+	}
+	if filepath.Ext(name) == "" {
+		name += imageExtension(contentType)
+	}
+	return token + "-" + name, nil
+}
+
+// imageExtension returns the usual file extension for an image content type
+func imageExtension(contentType string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "image/jpeg":
+		return ".jpg"
+	case "image/png":
+		return ".png"
+	case "image/gif":
+		return ".gif"
+	case "image/webp":
+		return ".webp"
+	}
+	if exts, _ := mime.ExtensionsByType(mediaType); len(exts) > 0 {
+		return exts[0]
+	}
+	return ""
+}
+
+// imageFileName recovers the file name from a key made by newImageKey; other
+// keys are returned unchanged.
+func imageFileName(key string) string {
+	token, name, ok := strings.Cut(key, "-")
+	if !ok || len(token) != 32 || name == "" {
+		return key
+	}
+	if _, err := hex.DecodeString(token); err != nil {
+		return key
+	}
+	return name
+}
+
+// isAllowedImageURL accepts https URLs, and plain http for loopback hosts so a
+// local image store works during development.
+func isAllowedImageURL(parsed *url.URL) bool {
+	if parsed.Host == "" {
+		return false
+	}
+	switch parsed.Scheme {
+	case "https":
+		return true
+	case "http":
+		host := parsed.Hostname()
+		if host == "localhost" {
+			return true
+		}
+		ip := net.ParseIP(host)
+		return ip != nil && ip.IsLoopback()
+	default:
+		return false
+	}
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"callflow/internal/domain/auth"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// jwtKey is one key of the keyring. Retired keys have no private key and are
+// only used to verify tokens issued before a rotation.
+type jwtKey struct {
+	id      string
+	method  jwt.SigningMethod
+	private crypto.Signer
+	public  crypto.PublicKey
+}
+
+// JWTKeyring signs tokens with the active key and verifies them with any known
+// key, selected by the kid header. Tokens without a kid were signed with the
+// legacy HS256 JWT_SECRET and keep verifying as long as it is configured.
+type JWTKeyring struct {
+	keys   map[string]*jwtKey
+	active *jwtKey
+	legacy []byte
+}
+
+// NewJWTKeyring creates a keyring that signs with the legacy HS256 secret
+func NewJWTKeyring(legacySecret string) *JWTKeyring {
+	return &JWTKeyring{
+		keys:   make(map[string]*jwtKey),
+		legacy: []byte(legacySecret),
+	}
+}
+
+// NewJWTKeyringFromEnv loads every PEM key in JWT_KEYS_DIR and signs with
+// JWT_ACTIVE_KID. Without JWT_KEYS_DIR tokens keep being signed with JWT_SECRET.
+func NewJWTKeyringFromEnv() (*JWTKeyring, error) {
+	kr := NewJWTKeyring(os.Getenv("JWT_SECRET"))
+
+	dir := os.Getenv("JWT_KEYS_DIR")
+	if dir == "" {
+		if len(kr.legacy) == 0 {
+			return nil, errors.New("JWT_SECRET or JWT_KEYS_DIR is required")
+		}
+		return kr, nil
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key %s: %w", path, err)
+		}
+		kid := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(path), ".pem"), ".pub")
+		if err := kr.AddPEM(kid, data); err != nil {
+			return nil, fmt.Errorf("failed to load key %s: %w", path, err)
+		}
+	}
+
+	activeKID := os.Getenv("JWT_ACTIVE_KID")
+	if activeKID == "" {
+		return nil, errors.New("JWT_ACTIVE_KID is required when JWT_KEYS_DIR is set")
+	}
+	if err := kr.SetActive(activeKID); err != nil {
+		return nil, err
+	}
+	return kr, nil
+}
+
+// AddPEM adds a PKCS#8 private key or a PKIX public key under kid. RSA keys
+// sign with RS256 and Ed25519 keys with EdDSA.
+func (kr *JWTKeyring) AddPEM(kid string, data []byte) error {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return errors.New("no PEM block found")
+	}
+
+	key := &jwtKey{id: kid}
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return err
+		}
+		signer, ok := parsed.(crypto.Signer)
+		if !ok {
+			return errors.New("unsupported private key")
+		}
+		key.private = signer
+		key.public = signer.Public()
+	case "RSA PRIVATE KEY":
+		parsed, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return err
+		}
+		key.private = parsed
+		key.public = parsed.Public()
+	case "PUBLIC KEY":
+		parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return err
+		}
+		key.public = parsed
+	default:
+		return fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+
+	switch key.public.(type) {
+	case *rsa.PublicKey:
+		key.method = jwt.SigningMethodRS256
+	case ed25519.PublicKey:
+		key.method = jwt.SigningMethodEdDSA
+	default:
+		return errors.New("only RSA and Ed25519 keys are supported")
+	}
+
+	if existing, ok := kr.keys[kid]; ok && existing.private != nil && key.private == nil {
+		// A private key already provides the public half.
+		return nil
+	}
+	kr.keys[kid] = key
+	return nil
+}
+
+// SetActive selects the key used to sign new tokens
+func (kr *JWTKeyring) SetActive(kid string) error {
+	key, ok := kr.keys[kid]
+	if !ok {
+		return fmt.Errorf("unknown signing key %q", kid)
+	}
+	if key.private == nil {
+		return fmt.Errorf("signing key %q has no private key", kid)
+	}
+	kr.active = key
+	return nil
+}
+
+// Sign signs the claims with the active key
+func (kr *JWTKeyring) Sign(claims jwt.Claims) (string, error) {
+	if kr.active == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(kr.legacy)
+	}
+	t := jwt.NewWithClaims(kr.active.method, claims)
+	t.Header["kid"] = kr.active.id
+	return t.SignedString(kr.active.private)
+}
+
+// Keyfunc returns the verification key for a parsed token
+func (kr *JWTKeyring) Keyfunc(t *jwt.Token) (interface{}, error) {
+	kid, _ := t.Header["kid"].(string)
+	if kid == "" {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok || len(kr.legacy) == 0 {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+		return kr.legacy, nil
+	}
+
+	key, ok := kr.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	if t.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+	}
+	return key.public, nil
+}
+
+// JWKS returns the public keys in JSON Web Key Set format
+func (kr *JWTKeyring) JWKS() *auth.JWKSet {
+	set := &auth.JWKSet{Keys: make([]auth.JWK, 0, len(kr.keys))}
+	for _, key := range kr.keys {
+		jwk := auth.JWK{
+			KeyID:     key.id,
+			Use:       "sig",
+			Algorithm: key.method.Alg(),
+		}
+		switch pub := key.public.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].KeyID < set.Keys[j].KeyID })
+	return set
+}
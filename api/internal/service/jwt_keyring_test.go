@@ -0,0 +1,240 @@
+package service
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"callflow/internal/domain/auth"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func ed25519PEM(t *testing.T) (private, public []byte) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return encodePEM(t, "PRIVATE KEY", priv), encodePEM(t, "PUBLIC KEY", pub)
+}
+
+func rsaPEM(t *testing.T) []byte {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
+
+func encodePEM(t *testing.T, blockType string, key any) []byte {
+	t.Helper()
+	var der []byte
+	var err error
+	if blockType == "PRIVATE KEY" {
+		der, err = x509.MarshalPKCS8PrivateKey(key)
+	} else {
+		der, err = x509.MarshalPKIXPublicKey(key)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+}
+
+func testClaims() *auth.AuthClaims {
+	return &auth.AuthClaims{
+		UserID: 42,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+}
+
+func parseWith(kr *JWTKeyring, token string) (*auth.AuthClaims, error) {
+	claims := &auth.AuthClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, kr.Keyfunc)
+	return claims, err
+}
+
+func TestJWTKeyringVerification(t *testing.T) {
+	edPrivate, edPublic := ed25519PEM(t)
+	otherPrivate, _ := ed25519PEM(t)
+	rsaPrivate := rsaPEM(t)
+
+	// signer issues a token the way a keyring configured with the given keys would.
+	type signer func(t *testing.T) string
+	legacy := func(secret string) signer {
+		return func(t *testing.T) string {
+			token, err := NewJWTKeyring(secret).Sign(testClaims())
+			if err != nil {
+				t.Fatal(err)
+			}
+			return token
+		}
+	}
+	withKey := func(kid string, data []byte) signer {
+		return func(t *testing.T) string {
+			kr := NewJWTKeyring("")
+			if err := kr.AddPEM(kid, data); err != nil {
+				t.Fatal(err)
+			}
+			if err := kr.SetActive(kid); err != nil {
+				t.Fatal(err)
+			}
+			token, err := kr.Sign(testClaims())
+			if err != nil {
+				t.Fatal(err)
+			}
+			return token
+		}
+	}
+	hs256WithKID := func(kid string) signer {
+		return func(t *testing.T) string {
+			tok := jwt.NewWithClaims(jwt.SigningMethodHS256, testClaims())
+			tok.Header["kid"] = kid
+			token, err := tok.SignedString(edPublic)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return token
+		}
+	}
+
+	tests := []struct {
+		name      string
+		legacy    string
+		keys      map[string][]byte
+		sign      signer
+		wantValid bool
+	}{
+		{"legacy token with the legacy secret", "old-secret", nil, legacy("old-secret"), true},
+		{"legacy token after rotating to keys", "old-secret", map[string][]byte{"k1": edPublic}, legacy("old-secret"), true},
+		{"legacy token with another secret", "old-secret", nil, legacy("other-secret"), false},
+		{"legacy token once the secret is removed", "", map[string][]byte{"k1": edPublic}, legacy("old-secret"), false},
+		{"ed25519 token with its public key", "", map[string][]byte{"k1": edPublic}, withKey("k1", edPrivate), true},
+		{"ed25519 token with its private key", "", map[string][]byte{"k1": edPrivate}, withKey("k1", edPrivate), true},
+		{"rsa token", "", map[string][]byte{"r1": rsaPrivate}, withKey("r1", rsaPrivate), true},
+		{"token of a retired kid", "", map[string][]byte{"k2": edPublic}, withKey("k1", edPrivate), false},
+		{"token signed by another key under the same kid", "", map[string][]byte{"k1": edPublic}, withKey("k1", otherPrivate), false},
+		{"hs256 token claiming an asymmetric kid", "old-secret", map[string][]byte{"k1": edPublic}, hs256WithKID("k1"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kr := NewJWTKeyring(tt.legacy)
+			for kid, data := range tt.keys {
+				if err := kr.AddPEM(kid, data); err != nil {
+					t.Fatalf("AddPEM(%s) error = %v", kid, err)
+				}
+			}
+
+			claims, err := parseWith(kr, tt.sign(t))
+			if (err == nil) != tt.wantValid {
+				t.Fatalf("parse error = %v, wantValid %v", err, tt.wantValid)
+			}
+			if tt.wantValid && claims.UserID != 42 {
+				t.Errorf("user_id = %d, want 42", claims.UserID)
+			}
+		})
+	}
+}
+
+func TestJWTKeyringSign(t *testing.T) {
+	edPrivate, edPublic := ed25519PEM(t)
+
+	tests := []struct {
+		name       string
+		legacy     string
+		keys       map[string][]byte
+		active     string
+		wantActive bool
+		wantAlg    string
+		wantKID    string
+	}{
+		{"legacy secret only", "old-secret", nil, "", true, "HS256", ""},
+		{"active ed25519 key", "old-secret", map[string][]byte{"k1": edPrivate}, "k1", true, "EdDSA", "k1"},
+		{"unknown active kid", "old-secret", map[string][]byte{"k1": edPrivate}, "k2", false, "", ""},
+		{"public key cannot sign", "old-secret", map[string][]byte{"k1": edPublic}, "k1", false, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kr := NewJWTKeyring(tt.legacy)
+			for kid, data := range tt.keys {
+				if err := kr.AddPEM(kid, data); err != nil {
+					t.Fatalf("AddPEM(%s) error = %v", kid, err)
+				}
+			}
+			if tt.active != "" {
+				err := kr.SetActive(tt.active)
+				if (err == nil) != tt.wantActive {
+					t.Fatalf("SetActive() error = %v, wantActive %v", err, tt.wantActive)
+				}
+				if err != nil {
+					return
+				}
+			}
+
+			token, err := kr.Sign(testClaims())
+			if err != nil {
+				t.Fatalf("Sign() error = %v", err)
+			}
+			parsed, err := jwt.ParseWithClaims(token, &auth.AuthClaims{}, kr.Keyfunc)
+			if err != nil {
+				t.Fatalf("parse error = %v", err)
+			}
+			if alg := parsed.Method.Alg(); alg != tt.wantAlg {
+				t.Errorf("alg = %s, want %s", alg, tt.wantAlg)
+			}
+			if kid, _ := parsed.Header["kid"].(string); kid != tt.wantKID {
+				t.Errorf("kid = %q, want %q", kid, tt.wantKID)
+			}
+		})
+	}
+}
+
+func TestJWTKeyringAddPEM(t *testing.T) {
+	edPrivate, edPublic := ed25519PEM(t)
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr bool
+	}{
+		{"pkcs8 private key", edPrivate, false},
+		{"pkix public key", edPublic, false},
+		{"pkcs1 rsa key", rsaPEM(t), false},
+		{"not pem", []byte("not a key"), true},
+		{"unsupported block", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte{1}}), true},
+		{"corrupt key", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte{1, 2, 3}}), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewJWTKeyring("").AddPEM("k1", tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AddPEM() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJWTKeyringKeepsPrivateKey(t *testing.T) {
+	edPrivate, edPublic := ed25519PEM(t)
+	kr := NewJWTKeyring("")
+	for _, data := range [][]byte{edPrivate, edPublic} {
+		if err := kr.AddPEM("k1", data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := kr.SetActive("k1"); err != nil {
+		t.Errorf("SetActive() after adding the public half = %v, want nil", err)
+	}
+}
@@ -2,43 +2,382 @@ package service
 
 import (
 	"context"
+	"crypto/subtle"
 	"errors"
-	"log"
+	"fmt"
 	"net/url"
+	"os"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
 
 	"callflow/internal/domain/landing"
+	"callflow/internal/domain/media"
+	"callflow/internal/domain/user"
+
+	"golang.org/x/text/unicode/norm"
 )
 
-type LandingImageStore interface {
-	UploadLandingImage(ctx context.Context, filename, contentType string, file []byte) (*landing.UploadedImage, error)
-	DeleteLandingImage(ctx context.Context, imageKey string) error
-}
+const defaultLandingBaseURL = "https://adflowapp.vercel.app"
+
+var (
+	slugPattern    = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	slugSeparators = regexp.MustCompile(`[^a-z0-9]+`)
+)
 
 // LandingService provides landing page business logic.
 type LandingService struct {
-	landingRepo landing.Repository
-	imageStore  LandingImageStore
+	landingRepo  landing.Repository
+	userRepo     user.Repository
+	mediaService media.Service
+	baseURL      string
 }
 
 // NewLandingService creates a new landing service instance.
-func NewLandingService(landingRepo landing.Repository, imageStore LandingImageStore) *LandingService {
+func NewLandingService(landingRepo landing.Repository, userRepo user.Repository, mediaService media.Service) *LandingService {
 	return &LandingService{
-		landingRepo: landingRepo,
-		imageStore:  imageStore,
+		landingRepo:  landingRepo,
+		userRepo:     userRepo,
+		mediaService: mediaService,
+		baseURL:      landingBaseURLFromEnv(),
 	}
 }
 
 func (s *LandingService) GetByUserID(ctx context.Context, userID int64) (*landing.Landing, error) {
-	return s.landingRepo.GetByUserID(ctx, userID)
+	l, err := s.landingRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.resolveGallery(ctx, userID, l.Sections); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (s *LandingService) GetDraft(ctx context.Context, userID int64) (*landing.Draft, error) {
+	d, err := s.landingRepo.GetDraft(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.resolveDraft(ctx, userID, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (s *LandingService) SaveDraft(ctx context.Context, userID int64, data landing.LandingUpsert) (*landing.Draft, error) {
+	base, err := s.draftBase(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.prepareUpsert(ctx, userID, &data, base); err != nil {
+		return nil, err
+	}
+
+	token, err := randomToken(landing.PreviewTokenBytes)
+	if err != nil {
+		return nil, err
+	}
+	d, err := s.landingRepo.SaveDraft(ctx, userID, token, data)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.resolveDraft(ctx, userID, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (s *LandingService) DiscardDraft(ctx context.Context, userID int64) error {
+	return s.landingRepo.DeleteDraft(ctx, userID)
+}
+
+func (s *LandingService) Publish(ctx context.Context, userID int64) (*landing.Landing, error) {
+	d, err := s.landingRepo.GetDraft(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.resolveHero(ctx, userID, d.Landing); err != nil {
+		return nil, err
+	}
+
+	l, err := s.landingRepo.PublishDraft(ctx, userID, landingToUpsert(d.Landing))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.resolveGallery(ctx, userID, l.Sections); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (s *LandingService) RotatePreviewToken(ctx context.Context, userID int64) (*landing.Draft, error) {
+	token, err := randomToken(landing.PreviewTokenBytes)
+	if err != nil {
+		return nil, err
+	}
+	d, err := s.landingRepo.SetPreviewToken(ctx, userID, token)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.resolveDraft(ctx, userID, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (s *LandingService) GetPreview(ctx context.Context, userID int64, token string) (*landing.Landing, error) {
+	d, err := s.landingRepo.GetDraft(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(d.PreviewToken), []byte(token)) != 1 {
+		return nil, landing.ErrDraftNotFound
+	}
+	if err := s.resolveHero(ctx, userID, d.Landing); err != nil {
+		return nil, err
+	}
+	if err := s.resolveGallery(ctx, userID, d.Sections); err != nil {
+		return nil, err
+	}
+	return d.Landing, nil
+}
+
+func (s *LandingService) GetRevisions(ctx context.Context, userID int64) ([]*landing.Revision, error) {
+	return s.landingRepo.GetRevisions(ctx, userID, landing.MaxRevisions)
+}
+
+func (s *LandingService) GetRevision(ctx context.Context, id, userID int64) (*landing.Revision, error) {
+	rev, err := s.landingRepo.GetRevision(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.resolveHero(ctx, userID, rev.Landing); err != nil {
+		return nil, err
+	}
+	if err := s.resolveGallery(ctx, userID, rev.Landing.Sections); err != nil {
+		return nil, err
+	}
+	return rev, nil
+}
+
+func (s *LandingService) RestoreRevision(ctx context.Context, id, userID int64) (*landing.Draft, error) {
+	rev, err := s.GetRevision(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := landingToUpsert(rev.Landing)
+	sections := make([]*landing.Section, 0, len(*data.Sections))
+	for _, sec := range *data.Sections {
+		// A gallery whose images have all been deleted cannot be saved.
+		if sec.Type == landing.SectionGallery && len(sec.Images) == 0 {
+			continue
+		}
+		sections = append(sections, sec)
+	}
+	data.Sections = &sections
+	return s.SaveDraft(ctx, userID, data)
+}
+
+func (s *LandingService) UploadImage(ctx context.Context, userID int64, filename, contentType string, file []byte) (*landing.UploadedImage, error) {
+	uploaded, err := s.mediaService.Upload(ctx, userID, filename, contentType, file)
+	if errors.Is(err, media.ErrUploadDisabled) {
+		return nil, landing.ErrUploadDisabled
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &landing.UploadedImage{
+		MediaID:  uploaded.ID,
+		URL:      uploaded.URL,
+		Key:      uploaded.Key,
+		Variants: uploaded.Variants,
+	}, nil
+}
+
+func (s *LandingService) GetSlug(ctx context.Context, userID int64) (*landing.Slug, error) {
+	slug, err := s.landingRepo.GetCurrentSlug(ctx, userID)
+	if !errors.Is(err, landing.ErrSlugNotFound) {
+		return slug, err
+	}
+	return s.createDefaultSlug(ctx, userID)
+}
+
+func (s *LandingService) UpdateSlug(ctx context.Context, userID int64, slug string) (*landing.Slug, error) {
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	if err := validateSlug(slug); err != nil {
+		return nil, err
+	}
+	return s.landingRepo.SetCurrentSlug(ctx, userID, slug)
+}
+
+func (s *LandingService) ResolveSlug(ctx context.Context, ref string) (int64, string, error) {
+	ref = strings.ToLower(strings.TrimSpace(ref))
+	slug, err := s.landingRepo.GetSlug(ctx, ref)
+	if err == nil {
+		if slug.IsCurrent {
+			return slug.UserID, slug.Slug, nil
+		}
+		current, err := s.landingRepo.GetCurrentSlug(ctx, slug.UserID)
+		if err != nil {
+			return 0, "", err
+		}
+		return slug.UserID, current.Slug, nil
+	}
+	if !errors.Is(err, landing.ErrSlugNotFound) {
+		return 0, "", err
+	}
+
+	// Links sent before slugs existed carry the numeric user ID. Slugs always
+	// contain a letter, so the two never overlap. Only pages that are live and
+	// already have a slug are redirected, so user IDs cannot be enumerated and
+	// public reads never create slugs.
+	id, parseErr := strconv.ParseInt(ref, 10, 64)
+	if parseErr != nil {
+		return 0, "", landing.ErrSlugNotFound
+	}
+	u, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return 0, "", landing.ErrSlugNotFound
+		}
+		return 0, "", err
+	}
+	if !hasPublicLanding(u) {
+		return 0, "", landing.ErrSlugNotFound
+	}
+	current, err := s.landingRepo.GetCurrentSlug(ctx, id)
+	if err != nil {
+		return 0, "", err
+	}
+	return id, current.Slug, nil
+}
+
+func (s *LandingService) URL(slug string) string {
+	return s.baseURL + "/" + slug
+}
+
+func (s *LandingService) TrackEvent(ctx context.Context, ref string, data landing.EventCreate) error {
+	if data.Type == landing.EventClick && data.Target == "" {
+		return landing.ErrInvalidEvent
+	}
+	if data.Type == landing.EventView {
+		data.Target = ""
+	}
+	userID, _, err := s.ResolveSlug(ctx, ref)
+	if err != nil {
+		return err
+	}
+	token := strings.ToLower(strings.TrimSpace(data.Token))
+	_, err = s.landingRepo.RecordEvent(ctx, userID, data.Type, data.Target, token)
+	return err
 }
 
-func (s *LandingService) UpsertByUserID(ctx context.Context, userID int64, data landing.LandingUpsert) (*landing.Landing, error) {
-	existing, err := s.landingRepo.GetByUserID(ctx, userID)
-	if err != nil && !errorsIsLandingNotFound(err) {
+func (s *LandingService) CreateLink(ctx context.Context, userID int64, data landing.LinkCreate) (*landing.Link, error) {
+	if data.Source == "" {
+		data.Source = landing.LinkSourceManual
+	}
+	data.Phone = normalizeLandingStringPtr(data.Phone)
+
+	slug, err := s.GetSlug(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	token, err := randomToken(landing.LinkTokenBytes)
+	if err != nil {
+		return nil, err
+	}
+	link, err := s.landingRepo.CreateLink(ctx, userID, token, data)
+	if err != nil {
+		return nil, err
+	}
+	link.URL = s.linkURL(slug.Slug, link.Token)
+	return link, nil
+}
+
+func (s *LandingService) GetAnalytics(ctx context.Context, userID int64, from, to time.Time) (*landing.Analytics, error) {
+	from = utcDay(from)
+	to = utcDay(to)
+	days := int(to.Sub(from).Hours()/24) + 1
+	if days < 1 || days > landing.MaxAnalyticsDays {
+		return nil, landing.ErrInvalidRange
+	}
+
+	rows, err := s.landingRepo.GetDailyStats(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	links, err := s.landingRepo.GetVisitedLinks(ctx, userID, from, landing.MaxAnalyticsLinks)
+	if err != nil {
 		return nil, err
 	}
+	if len(links) > 0 {
+		slug, err := s.GetSlug(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range links {
+			l.URL = s.linkURL(slug.Slug, l.Token)
+		}
+	}
+
+	analytics := &landing.Analytics{
+		From:   from.Format(time.DateOnly),
+		To:     to.Format(time.DateOnly),
+		Totals: landing.Stats{ClicksByTarget: map[string]int{}},
+		Days:   make([]*landing.DayStats, days),
+		Links:  links,
+	}
+	for i := range analytics.Days {
+		analytics.Days[i] = &landing.DayStats{
+			Date:  from.AddDate(0, 0, i).Format(time.DateOnly),
+			Stats: landing.Stats{ClicksByTarget: map[string]int{}},
+		}
+	}
+	for _, row := range rows {
+		i := int(utcDay(row.Day).Sub(from).Hours() / 24)
+		if i < 0 || i >= days {
+			continue
+		}
+		addStat(&analytics.Days[i].Stats, row)
+		addStat(&analytics.Totals, row)
+	}
+	return analytics, nil
+}
+
+// addStat adds a daily count to stats
+func addStat(stats *landing.Stats, row *landing.DailyStat) {
+	switch row.Event {
+	case landing.EventView:
+		stats.Views += row.Count
+		if row.Attributed {
+			stats.AttributedViews += row.Count
+		}
+	case landing.EventClick:
+		stats.Clicks += row.Count
+		if row.Attributed {
+			stats.AttributedClicks += row.Count
+		}
+		stats.ClicksByTarget[row.Target] += row.Count
+	}
+}
 
+// linkURL is the landing page address carrying an attribution token
+func (s *LandingService) linkURL(slug, token string) string {
+	return s.URL(slug) + "?t=" + token
+}
+
+func utcDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// prepareUpsert normalizes and validates data for the page it replaces, which
+// is nil when there is none. Omitted sections are taken from existing.
+func (s *LandingService) prepareUpsert(ctx context.Context, userID int64, data *landing.LandingUpsert, existing *landing.Landing) error {
 	// Normalize inputs
 	data.Headline = normalizeLandingStringPtr(data.Headline)
 	data.Description = normalizeLandingStringPtr(data.Description)
@@ -61,28 +400,285 @@ func (s *LandingService) UpsertByUserID(ctx context.Context, userID int64, data
 		data.ImageKey = nil
 	}
 
+	mediaID, imageURL, imageKey, err := linkMedia(ctx, s.mediaService, userID, data.MediaID, data.ImageURL, data.ImageKey)
+	if err != nil {
+		return err
+	}
+	data.MediaID, data.ImageURL, data.ImageKey = mediaID, imageURL, imageKey
+
 	requiresImageKey := data.ImageURL != nil && (existing == nil || existing.ImageURL == nil || *data.ImageURL != *existing.ImageURL)
 	if err := validateLandingImageFields(data.ImageURL, data.ImageKey, requiresImageKey); err != nil {
+		return err
+	}
+
+	// Clients that predate sections leave them out and keep the current ones.
+	if data.Sections == nil {
+		sections := []*landing.Section{}
+		if existing != nil {
+			sections = existing.Sections
+		}
+		data.Sections = &sections
+	} else if err := s.prepareSections(ctx, userID, *data.Sections); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// draftBase returns what a draft edit applies to: the draft, or the live page
+// when there is no draft yet. It is nil when the user has neither.
+func (s *LandingService) draftBase(ctx context.Context, userID int64) (*landing.Landing, error) {
+	d, err := s.landingRepo.GetDraft(ctx, userID)
+	if err == nil {
+		return d.Landing, nil
+	}
+	if !errors.Is(err, landing.ErrDraftNotFound) {
 		return nil, err
 	}
+	l, err := s.landingRepo.GetByUserID(ctx, userID)
+	if errorsIsLandingNotFound(err) {
+		return nil, nil
+	}
+	return l, err
+}
+
+// resolveDraft fills in the draft's images and preview URL
+func (s *LandingService) resolveDraft(ctx context.Context, userID int64, d *landing.Draft) error {
+	if err := s.resolveHero(ctx, userID, d.Landing); err != nil {
+		return err
+	}
+	if err := s.resolveGallery(ctx, userID, d.Sections); err != nil {
+		return err
+	}
+	slug, err := s.GetSlug(ctx, userID)
+	if err != nil {
+		return err
+	}
+	d.PreviewURL = s.URL(slug.Slug) + "?preview=" + d.PreviewToken
+	return nil
+}
+
+// resolveHero points the hero image of a draft or revision at its media
+// library image, which may have moved since it was saved, and clears it when
+// the image is gone.
+func (s *LandingService) resolveHero(ctx context.Context, userID int64, l *landing.Landing) error {
+	if l.MediaID == nil {
+		return nil
+	}
+	m, err := s.mediaService.Resolve(ctx, userID, l.MediaID, nil)
+	if errors.Is(err, media.ErrMediaNotFound) {
+		l.MediaID, l.ImageURL, l.ImageKey = nil, nil, nil
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	l.ImageURL, l.ImageKey = &m.URL, &m.ImageKey
+	return nil
+}
+
+// prepareSections validates sections against their type, normalizes them and
+// gives new sections an ID. Gallery images must be in the user's media library.
+func (s *LandingService) prepareSections(ctx context.Context, userID int64, sections []*landing.Section) error {
+	ids := make(map[string]struct{}, len(sections))
+	for i, sec := range sections {
+		if sec.ID == "" {
+			id, err := randomToken(4)
+			if err != nil {
+				return err
+			}
+			sec.ID = id
+		}
+		if _, ok := ids[sec.ID]; ok {
+			return fmt.Errorf("%w: section %d: duplicate id %q", landing.ErrInvalidSection, i, sec.ID)
+		}
+		ids[sec.ID] = struct{}{}
+		sec.Title = strings.TrimSpace(sec.Title)
+
+		if err := s.prepareSection(ctx, userID, sec); err != nil {
+			return fmt.Errorf("%w: section %d: %w", landing.ErrInvalidSection, i, err)
+		}
+	}
+	return nil
+}
+
+// prepareSection checks that a section carries its type's content and nothing else
+func (s *LandingService) prepareSection(ctx context.Context, userID int64, sec *landing.Section) error {
+	present := map[string]bool{
+		landing.SectionGallery:  len(sec.Images) > 0,
+		landing.SectionServices: len(sec.Services) > 0,
+		landing.SectionHours:    len(sec.Hours) > 0,
+		landing.SectionOffer:    sec.Offer != nil,
+		landing.SectionFAQ:      len(sec.FAQs) > 0,
+	}
+	for t, ok := range present {
+		if t == sec.Type && !ok {
+			return fmt.Errorf("%s section has no content", sec.Type)
+		}
+		if t != sec.Type && ok {
+			return fmt.Errorf("%s section carries %s content", sec.Type, t)
+		}
+	}
+
+	switch sec.Type {
+	case landing.SectionGallery:
+		for _, img := range sec.Images {
+			if _, err := s.mediaService.Get(ctx, img.MediaID, userID); err != nil {
+				return err
+			}
+			img.Caption = strings.TrimSpace(img.Caption)
+			// URLs are looked up on read so images that move keep working.
+			img.URL, img.ThumbnailURL = "", nil
+		}
+	case landing.SectionServices:
+		for _, item := range sec.Services {
+			item.Name = strings.TrimSpace(item.Name)
+			item.Description = strings.TrimSpace(item.Description)
+			item.Price = strings.TrimSpace(item.Price)
+			if item.Name == "" {
+				return errors.New("service name is required")
+			}
+		}
+	case landing.SectionHours:
+		days := make(map[string]struct{}, len(sec.Hours))
+		for _, h := range sec.Hours {
+			if _, ok := days[h.Day]; ok {
+				return fmt.Errorf("%s is listed twice", h.Day)
+			}
+			days[h.Day] = struct{}{}
+			if h.Closed {
+				h.Open, h.Close = "", ""
+				continue
+			}
+			openAt, err := time.Parse("15:04", h.Open)
+			if err != nil {
+				return fmt.Errorf("%s: open must be HH:MM", h.Day)
+			}
+			closeAt, err := time.Parse("15:04", h.Close)
+			if err != nil {
+				return fmt.Errorf("%s: close must be HH:MM", h.Day)
+			}
+			// A close before open runs past midnight.
+			if openAt.Equal(closeAt) {
+				return fmt.Errorf("%s: open and close are the same", h.Day)
+			}
+		}
+	case landing.SectionOffer:
+		sec.Offer.Title = strings.TrimSpace(sec.Offer.Title)
+		sec.Offer.Description = strings.TrimSpace(sec.Offer.Description)
+		sec.Offer.Code = strings.TrimSpace(sec.Offer.Code)
+		if sec.Offer.Title == "" {
+			return errors.New("offer title is required")
+		}
+	case landing.SectionFAQ:
+		for _, f := range sec.FAQs {
+			f.Question = strings.TrimSpace(f.Question)
+			f.Answer = strings.TrimSpace(f.Answer)
+			if f.Question == "" || f.Answer == "" {
+				return errors.New("FAQ needs a question and an answer")
+			}
+		}
+	}
+	return nil
+}
 
-	updated, err := s.landingRepo.UpsertByUserID(ctx, userID, data)
+// resolveGallery fills in the URLs of gallery images. Images that left the
+// media library are dropped.
+func (s *LandingService) resolveGallery(ctx context.Context, userID int64, sections []*landing.Section) error {
+	for _, sec := range sections {
+		images := sec.Images[:0]
+		for _, img := range sec.Images {
+			item, err := s.mediaService.Get(ctx, img.MediaID, userID)
+			if errors.Is(err, media.ErrMediaNotFound) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			img.URL, img.ThumbnailURL = item.URL, item.ThumbnailURL
+			images = append(images, img)
+		}
+		sec.Images = images
+	}
+	return nil
+}
+
+// createDefaultSlug derives a slug from the business name, adding a random
+// suffix when it is taken.
+func (s *LandingService) createDefaultSlug(ctx context.Context, userID int64) (*landing.Slug, error) {
+	u, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
+	base := slugify(u.BusinessName)
+	if validateSlug(base) != nil {
+		base = "page"
+	}
 
-	if existing != nil && shouldDeleteOldLandingImage(existing.ImageKey, updated.ImageKey) {
-		s.deleteImageKeyAsync(existing.ImageKey)
+	candidate := base
+	for attempt := 0; attempt < 5; attempt++ {
+		if attempt > 0 {
+			suffix, err := randomToken(3)
+			if err != nil {
+				return nil, err
+			}
+			candidate = strings.TrimRight(base[:min(len(base), landing.SlugMaxLength-len(suffix)-1)], "-") + "-" + suffix
+		}
+		slug, err := s.landingRepo.SetCurrentSlug(ctx, userID, candidate)
+		if !errors.Is(err, landing.ErrSlugTaken) {
+			return slug, err
+		}
 	}
+	return nil, landing.ErrSlugTaken
+}
 
-	return updated, nil
+// hasPublicLanding reports whether the user's landing page is served publicly:
+// the account is active and its plan includes SMS and has not expired.
+func hasPublicLanding(u *user.User) bool {
+	if u.Status != user.StatusActive || !u.HasChannel("sms") {
+		return false
+	}
+	return u.PlanExpiresAt == nil || time.Now().Before(*u.PlanExpiresAt)
 }
 
-func (s *LandingService) UploadImage(ctx context.Context, _ int64, filename, contentType string, file []byte) (*landing.UploadedImage, error) {
-	if s.imageStore == nil {
-		return nil, landing.ErrUploadDisabled
+// slugify lowercases name, strips accents and joins the remaining ASCII
+// letters and digits with hyphens.
+func slugify(name string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(strings.ToLower(name)) {
+		if !unicode.Is(unicode.Mn, r) {
+			b.WriteRune(r)
+		}
 	}
-	return s.imageStore.UploadLandingImage(ctx, filename, contentType, file)
+	slug := slugSeparators.ReplaceAllString(b.String(), "-")
+	slug = strings.Trim(slug, "-")
+	if len(slug) > landing.SlugMaxLength {
+		slug = strings.TrimRight(slug[:landing.SlugMaxLength], "-")
+	}
+	return slug
+}
+
+func validateSlug(slug string) error {
+	if len(slug) < landing.SlugMinLength || len(slug) > landing.SlugMaxLength || !slugPattern.MatchString(slug) {
+		return landing.ErrInvalidSlug
+	}
+	if !strings.ContainsAny(slug, "abcdefghijklmnopqrstuvwxyz") {
+		return landing.ErrInvalidSlug
+	}
+	if _, ok := landing.ReservedSlugs[slug]; ok {
+		return landing.ErrSlugReserved
+	}
+	return nil
+}
+
+// landingBaseURLFromEnv reads LANDING_BASE_URL, where the public web app
+// serves landing pages.
+func landingBaseURLFromEnv() string {
+	base := strings.TrimRight(strings.TrimSpace(os.Getenv("LANDING_BASE_URL")), "/")
+	if base == "" {
+		return defaultLandingBaseURL
+	}
+	return base
 }
 
 func validateLandingImageFields(imageURL, imageKey *string, requireImageKey bool) error {
@@ -90,7 +686,7 @@ func validateLandingImageFields(imageURL, imageKey *string, requireImageKey bool
 		return nil
 	}
 	parsed, err := url.Parse(*imageURL)
-	if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+	if err != nil || !isAllowedImageURL(parsed) {
 		return landing.ErrInvalidImageURL
 	}
 	if requireImageKey && imageKey == nil {
@@ -122,22 +718,22 @@ func normalizeLandingStringPtr(v *string) *string {
 	return &trimmed
 }
 
-func shouldDeleteOldLandingImage(oldKey, newKey *string) bool {
-	if oldKey == nil || *oldKey == "" {
-		return false
-	}
-	if newKey == nil || *newKey == "" {
-		return true
-	}
-	return *oldKey != *newKey
-}
-
-func (s *LandingService) deleteImageKeyAsync(imageKey *string) {
-	if imageKey == nil || s.imageStore == nil {
-		return
-	}
-	if err := s.imageStore.DeleteLandingImage(context.Background(), *imageKey); err != nil {
-		log.Printf("failed to delete landing image key %s: %v", *imageKey, err)
+// landingToUpsert copies the content of a stored page so it can be saved again
+func landingToUpsert(l *landing.Landing) landing.LandingUpsert {
+	sections := l.Sections
+	return landing.LandingUpsert{
+		Headline:     l.Headline,
+		Description:  l.Description,
+		ImageURL:     l.ImageURL,
+		ImageKey:     l.ImageKey,
+		MediaID:      l.MediaID,
+		WhatsappURL:  l.WhatsappURL,
+		FacebookURL:  l.FacebookURL,
+		InstagramURL: l.InstagramURL,
+		YoutubeURL:   l.YoutubeURL,
+		Email:        l.Email,
+		WebsiteURL:   l.WebsiteURL,
+		Sections:     &sections,
 	}
 }
 
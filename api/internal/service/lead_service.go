@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"callflow/internal/domain/contact"
+	"callflow/internal/domain/device"
+	"callflow/internal/domain/lead"
+	"callflow/internal/domain/rule"
+	"callflow/internal/domain/user"
+)
+
+// LeadService provides inbound lead business logic
+type LeadService struct {
+	leadRepo       lead.Repository
+	userRepo       user.Repository
+	contactService contact.Service
+	ruleService    rule.Service
+	deviceService  device.Service
+}
+
+// NewLeadService creates a new lead service instance
+func NewLeadService(leadRepo lead.Repository, userRepo user.Repository, contactService contact.Service, ruleService rule.Service, deviceService device.Service) *LeadService {
+	return &LeadService{
+		leadRepo:       leadRepo,
+		userRepo:       userRepo,
+		contactService: contactService,
+		ruleService:    ruleService,
+		deviceService:  deviceService,
+	}
+}
+
+func (s *LeadService) GetEndpoint(ctx context.Context, userID int64) (*lead.Endpoint, error) {
+	return s.leadRepo.GetEndpoint(ctx, userID)
+}
+
+func (s *LeadService) RotateEndpoint(ctx context.Context, userID int64) (*lead.CreatedEndpoint, error) {
+	token, err := randomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := randomToken(24)
+	if err != nil {
+		return nil, err
+	}
+	e, err := s.leadRepo.SaveEndpoint(ctx, userID, token, "whsec_"+secret)
+	if err != nil {
+		return nil, err
+	}
+	return &lead.CreatedEndpoint{Endpoint: e, Secret: e.Secret}, nil
+}
+
+func (s *LeadService) DeleteEndpoint(ctx context.Context, userID int64) error {
+	return s.leadRepo.DeleteEndpoint(ctx, userID)
+}
+
+func (s *LeadService) Get(ctx context.Context, userID int64) ([]*lead.Lead, error) {
+	return s.leadRepo.GetByUserID(ctx, userID, lead.MaxListedLeads)
+}
+
+func (s *LeadService) Authenticate(ctx context.Context, token, signature string, body []byte) (int64, error) {
+	e, err := s.leadRepo.GetEndpointByToken(ctx, token)
+	if err != nil {
+		return 0, err
+	}
+	if !verifySignature(e.Secret, signature, body, time.Now()) {
+		return 0, lead.ErrInvalidSignature
+	}
+
+	u, err := s.userRepo.GetByID(ctx, e.UserID)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return 0, lead.ErrEndpointNotFound
+		}
+		return 0, err
+	}
+	if u.Status != user.StatusActive {
+		return 0, lead.ErrEndpointNotFound
+	}
+	return u.ID, nil
+}
+
+func (s *LeadService) Receive(ctx context.Context, userID int64, data lead.LeadCreate) (*lead.Lead, error) {
+	return s.receive(ctx, userID, data, rule.TriggerLead)
+}
+
+func (s *LeadService) ReceiveEnquiry(ctx context.Context, userID int64, data lead.Enquiry) (*lead.Lead, error) {
+	if !isPhoneNumber(data.Phone) {
+		return nil, lead.ErrInvalidPhone
+	}
+	var fields map[string]any
+	if message := strings.TrimSpace(data.Message); message != "" {
+		fields = map[string]any{"message": message}
+	}
+	return s.receive(ctx, userID, lead.LeadCreate{
+		Phone:  data.Phone,
+		Name:   data.Name,
+		Source: lead.SourceLanding,
+		Fields: fields,
+	}, rule.TriggerEnquiry)
+}
+
+// receive stores the lead and its contact, then queues the rules' template for
+// the trigger.
+func (s *LeadService) receive(ctx context.Context, userID int64, data lead.LeadCreate, trigger string) (*lead.Lead, error) {
+	data.Phone = strings.TrimSpace(data.Phone)
+	data.Name = strings.TrimSpace(data.Name)
+	data.Source = strings.TrimSpace(data.Source)
+
+	ct, err := s.contactService.Upsert(ctx, userID, contact.ContactUpsert{
+		Phone: data.Phone,
+		Name:  data.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := s.leadRepo.Create(ctx, userID, ct.ID, data)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := s.enqueueFollowUp(ctx, userID, ct, trigger)
+	if err != nil {
+		return nil, err
+	}
+	if job != nil {
+		if err := s.leadRepo.SetJob(ctx, l.ID, job.ID); err != nil {
+			return nil, err
+		}
+		l.JobID = &job.ID
+	}
+	return l, nil
+}
+
+// enqueueFollowUp queues the rules' template for the trigger to the contact,
+// applying the same checks the app applies to calls. It returns nil when no
+// message is due.
+func (s *LeadService) enqueueFollowUp(ctx context.Context, userID int64, ct *contact.Contact, trigger string) (*device.Job, error) {
+	config, err := s.ruleService.GetCompiledConfig(ctx, userID)
+	if err != nil {
+		if errors.Is(err, rule.ErrRuleNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	templateID := config.SMS.TemplateFor(trigger)
+	if !config.SMS.Enabled || templateID == nil {
+		return nil, nil
+	}
+	if ct.IsOptedOut() || config.IsExcluded(ct.Phone) || !config.WorkingHours.Contains(time.Now()) {
+		return nil, nil
+	}
+
+	u, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !u.HasChannel("sms") || (u.PlanExpiresAt != nil && time.Now().After(*u.PlanExpiresAt)) {
+		return nil, nil
+	}
+
+	if trigger == rule.TriggerEnquiry {
+		allowed, err := s.allowEnquiryReply(ctx, userID, ct)
+		if err != nil || !allowed {
+			return nil, err
+		}
+	}
+
+	payload, err := json.Marshal(device.SMSPayload{Phone: ct.Phone, TemplateID: templateID})
+	if err != nil {
+		return nil, err
+	}
+	runAfter := time.Now().Add(time.Duration(config.DelaySeconds) * time.Second)
+	job, err := s.deviceService.Enqueue(ctx, userID, device.JobCreate{
+		Type:     device.JobTypeSMS,
+		Payload:  payload,
+		RunAfter: &runAfter,
+	})
+	if err != nil {
+		// A deleted template leaves the rule dangling; the lead is still kept.
+		if errors.Is(err, device.ErrInvalidTemplate) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return job, nil
+}
+
+// allowEnquiryReply reports whether an enquiry may be answered: the contact
+// got no enquiry reply within the interval and the landing page is below its
+// daily cap, which the reply is counted against.
+func (s *LeadService) allowEnquiryReply(ctx context.Context, userID int64, ct *contact.Contact) (bool, error) {
+	replied, err := s.leadRepo.HasRecentReply(ctx, userID, ct.ID, lead.SourceLanding, time.Now().Add(-lead.EnquiryReplyInterval))
+	if err != nil || replied {
+		return false, err
+	}
+	return s.leadRepo.ReserveEnquiryReply(ctx, userID, lead.MaxEnquiryRepliesPerDay)
+}
+
+// isPhoneNumber reports whether s looks like a phone number a visitor typed:
+// 7 to 15 digits, optionally with a leading + and spaces, dashes or brackets.
+func isPhoneNumber(s string) bool {
+	s = strings.TrimSpace(s)
+	digits := 0
+	for i, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			digits++
+		case r == '+' && i == 0:
+		case r == ' ' || r == '-' || r == '(' || r == ')':
+		default:
+			return false
+		}
+	}
+	return digits >= 7 && digits <= 15
+}
+
+// verifySignature checks a "t=<unix>,v1=<hex>" signature over "<t>.<body>"
+// and rejects timestamps outside lead.SignatureTolerance.
+func verifySignature(secret, header string, body []byte, now time.Time) bool {
+	var timestamp, signature string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signature = value
+		}
+	}
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || signature == "" {
+		return false
+	}
+	if d := now.Sub(time.Unix(unix, 0)); d > lead.SignatureTolerance || d < -lead.SignatureTolerance {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(signWebhook(secret, timestamp, body)))
+}
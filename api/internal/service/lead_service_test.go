@@ -0,0 +1,61 @@
+package service
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"callflow/internal/domain/lead"
+)
+
+func TestVerifySignature(t *testing.T) {
+	const secret = "lead-secret"
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	body := []byte(`{"phone":"+15550100","name":"Ada"}`)
+
+	header := func(at time.Time, secret string, body []byte) string {
+		ts := strconv.FormatInt(at.Unix(), 10)
+		return "t=" + ts + ",v1=" + signWebhook(secret, ts, body)
+	}
+	ts := strconv.FormatInt(now.Unix(), 10)
+	sig := signWebhook(secret, ts, body)
+
+	tests := []struct {
+		name   string
+		header string
+		body   []byte
+		want   bool
+	}{
+		{"valid", header(now, secret, body), body, true},
+		{"valid with reordered parts", "v1=" + sig + ",t=" + ts, body, true},
+		{"valid with spaces", "t=" + ts + ", v1=" + sig, body, true},
+		{"at the tolerance", header(now.Add(-lead.SignatureTolerance), secret, body), body, true},
+		{"stale", header(now.Add(-lead.SignatureTolerance-time.Second), secret, body), body, false},
+		{"from the future", header(now.Add(lead.SignatureTolerance+time.Second), secret, body), body, false},
+		{"wrong secret", header(now, "other-secret", body), body, false},
+		{"tampered body", header(now, secret, body), []byte(`{"phone":"+15550199","name":"Ada"}`), false},
+		{"missing v1", "t=" + ts, body, false},
+		{"missing t", "v1=" + sig, body, false},
+		{"non-numeric t", "t=soon,v1=" + sig, body, false},
+		{"empty header", "", body, false},
+		{"uppercase hex", "t=" + ts + ",v1=" + upperHex(sig), body, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := verifySignature(secret, tt.header, tt.body, now); got != tt.want {
+				t.Errorf("verifySignature(%q) = %v, want %v", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func upperHex(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if c >= 'a' && c <= 'f' {
+			b[i] = c - 'a' + 'A'
+		}
+	}
+	return string(b)
+}
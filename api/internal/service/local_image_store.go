@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalImageRoute is the path the API serves local images from
+const LocalImageRoute = "/images"
+
+// LocalImageStore keeps images on the API server's disk and serves them from
+// LocalImageRoute. It suits single-instance deployments and development.
+type LocalImageStore struct {
+	dir     string
+	baseURL string
+}
+
+// NewLocalImageStoreFromEnv configures the store from LOCAL_IMAGE_DIR (default
+// "data/images") and LOCAL_IMAGE_BASE_URL, the public URL of LocalImageRoute
+// (default http://localhost:$PORT/images).
+func NewLocalImageStoreFromEnv() (*LocalImageStore, error) {
+	baseURL := strings.TrimSpace(os.Getenv("LOCAL_IMAGE_BASE_URL"))
+	if baseURL == "" {
+		port := os.Getenv("PORT")
+		if port == "" {
+			port = "8080"
+		}
+		baseURL = "http://localhost:" + port + LocalImageRoute
+	}
+	return NewLocalImageStore(LocalImageDir(), baseURL)
+}
+
+// NewLocalImageStore creates a local image store writing to dir
+func NewLocalImageStore(dir, baseURL string) (*LocalImageStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &LocalImageStore{
+		dir:     dir,
+		baseURL: strings.TrimRight(baseURL, "/"),
+	}, nil
+}
+
+// LocalImageDir returns the directory local images are kept in
+func LocalImageDir() string {
+	if dir := strings.TrimSpace(os.Getenv("LOCAL_IMAGE_DIR")); dir != "" {
+		return dir
+	}
+	return filepath.Join("data", "images")
+}
+
+// IsLocalImageKey reports whether key can name a file in the local image
+// directory, which rules out path traversal.
+func IsLocalImageKey(key string) bool {
+	return key != "" && !strings.HasPrefix(key, ".") && filepath.Base(key) == key && !strings.ContainsAny(key, `/\`)
+}
+
+func (s *LocalImageStore) Put(_ context.Context, filename, contentType string, file []byte) (*StoredImage, error) {
+	if len(file) == 0 {
+		return nil, errors.New("empty file")
+	}
+	key, err := newImageKey(filename, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	// Write to a temporary file first so a partial upload is never served.
+	tmp, err := os.CreateTemp(s.dir, ".upload-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(file); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(s.dir, key)); err != nil {
+		return nil, err
+	}
+
+	return &StoredImage{
+		URL: s.baseURL + "/" + key,
+		Key: key,
+	}, nil
+}
+
+func (s *LocalImageStore) Get(_ context.Context, key string) ([]byte, string, error) {
+	if !IsLocalImageKey(key) {
+		return nil, "", ErrImageNotFound
+	}
+	data, err := os.ReadFile(filepath.Join(s.dir, key))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, "", ErrImageNotFound
+		}
+		return nil, "", err
+	}
+	contentType := mime.TypeByExtension(filepath.Ext(key))
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+	return data, contentType, nil
+}
+
+func (s *LocalImageStore) Delete(_ context.Context, key string) error {
+	key = strings.TrimSpace(key)
+	if !IsLocalImageKey(key) {
+		return nil
+	}
+	if err := os.Remove(filepath.Join(s.dir, key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"callflow/internal/domain/media"
+)
+
+const (
+	mediaReconcileInterval = time.Minute
+	mediaDeleteBatch       = 50
+	// mediaLockDuration must outlast a batch of deletions; media whose worker
+	// died are picked up again once it passes.
+	mediaLockDuration = 10 * time.Minute
+)
+
+// MediaReconciler keeps the media table in line with the templates and landing
+// pages that reference each image, purges uploads never attached within the
+// grace period and deletes released images, retrying failures with backoff.
+type MediaReconciler struct {
+	mediaRepo media.Repository
+	store     ImageStore
+	grace     time.Duration
+	stopCh    chan struct{}
+}
+
+// NewMediaReconciler creates a new media reconciler
+func NewMediaReconciler(mediaRepo media.Repository, store ImageStore) *MediaReconciler {
+	return &MediaReconciler{
+		mediaRepo: mediaRepo,
+		store:     store,
+		grace:     mediaOrphanGraceFromEnv(),
+		stopCh:    make(chan struct{}),
+	}
+}
+
+// Start runs the reconcile loop in a background goroutine
+func (r *MediaReconciler) Start() {
+	go r.loop()
+}
+
+// Stop stops the reconcile loop
+func (r *MediaReconciler) Stop() {
+	close(r.stopCh)
+}
+
+func (r *MediaReconciler) loop() {
+	ticker := time.NewTicker(mediaReconcileInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.reconcile()
+		case <-r.stopCh:
+			return
+		}
+	}
+}
+
+func (r *MediaReconciler) reconcile() {
+	ctx, cancel := context.WithTimeout(context.Background(), mediaLockDuration)
+	defer cancel()
+
+	attached, unattached, err := r.mediaRepo.RefreshReferences(ctx)
+	if err != nil {
+		log.Printf("media reconciler: failed to refresh references: %v", err)
+		return
+	}
+	expired, err := r.mediaRepo.ExpireUnattached(ctx, time.Now().Add(-r.grace))
+	if err != nil {
+		log.Printf("media reconciler: failed to expire unattached media: %v", err)
+		return
+	}
+	if attached > 0 || unattached > 0 || expired > 0 {
+		log.Printf("media reconciler: %d attached, %d unattached, %d expired", attached, unattached, expired)
+	}
+
+	items, err := r.mediaRepo.ClaimDeleting(ctx, time.Now().Add(mediaLockDuration), mediaDeleteBatch)
+	if err != nil {
+		log.Printf("media reconciler: failed to claim media: %v", err)
+		return
+	}
+	for _, m := range items {
+		if err := r.deleteImage(ctx, m.ImageKey); err != nil {
+			log.Printf("media reconciler: failed to delete image %s (attempt %d): %v", m.ImageKey, m.DeleteAttempts, err)
+			if err := r.mediaRepo.FailDeletion(ctx, m.ID, err.Error()); err != nil {
+				log.Printf("media reconciler: failed to reschedule media %d: %v", m.ID, err)
+			}
+			continue
+		}
+		if err := r.mediaRepo.Delete(ctx, m.ID); err != nil {
+			log.Printf("media reconciler: failed to delete media %d: %v", m.ID, err)
+		}
+	}
+}
+
+// deleteImage removes a stored image and all of its variants
+func (r *MediaReconciler) deleteImage(ctx context.Context, imageKey string) error {
+	variants, err := r.mediaRepo.GetVariants(ctx, imageKey)
+	if err != nil {
+		return err
+	}
+	// Images uploaded before processing existed have no variant rows.
+	if err := r.store.Delete(ctx, imageKey); err != nil {
+		return err
+	}
+	for _, v := range variants {
+		if v.Key == imageKey {
+			continue
+		}
+		if err := r.store.Delete(ctx, v.Key); err != nil {
+			return err
+		}
+	}
+	return r.mediaRepo.DeleteVariants(ctx, imageKey)
+}
+
+// mediaOrphanGraceFromEnv reads MEDIA_ORPHAN_GRACE_HOURS, how long an upload
+// may wait for its first reference before it is deleted. It defaults to 24 hours.
+func mediaOrphanGraceFromEnv() time.Duration {
+	hours, err := strconv.Atoi(os.Getenv("MEDIA_ORPHAN_GRACE_HOURS"))
+	if err != nil || hours <= 0 {
+		return 24 * time.Hour
+	}
+	return time.Duration(hours) * time.Hour
+}
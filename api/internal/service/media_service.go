@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"callflow/internal/domain/media"
+)
+
+// MediaService processes uploaded images, keeps them in an ImageStore and
+// serves each user's media library. Uploads are disabled when store is nil.
+type MediaService struct {
+	store     ImageStore
+	mediaRepo media.Repository
+}
+
+// NewMediaService creates a new media service instance
+func NewMediaService(store ImageStore, mediaRepo media.Repository) *MediaService {
+	return &MediaService{
+		store:     store,
+		mediaRepo: mediaRepo,
+	}
+}
+
+func (s *MediaService) Upload(ctx context.Context, userID int64, filename, _ string, file []byte) (*media.Upload, error) {
+	if s.store == nil {
+		return nil, media.ErrUploadDisabled
+	}
+	processed, err := processImage(file)
+	if err != nil {
+		return nil, err
+	}
+
+	base := sanitizeFileName(filename)
+	base = strings.TrimSuffix(base, filepath.Ext(base))
+	if base == "" {
+		base = "image"
+	}
+
+	// The original is stored first: its key identifies the image and every
+	// variant row points at it.
+	stored := make([]*StoredImage, 0, len(processed))
+	for _, p := range processed {
+		name := base + imageExtension(p.contentType)
+		if p.name != media.VariantOriginal {
+			name = base + "-" + p.name + imageExtension(p.contentType)
+		}
+		img, err := s.store.Put(ctx, name, p.contentType, p.data)
+		if err != nil {
+			s.deleteStored(stored)
+			return nil, err
+		}
+		stored = append(stored, img)
+	}
+
+	imageKey := stored[0].Key
+	upload := &media.Upload{
+		URL:      stored[0].URL,
+		Key:      imageKey,
+		Variants: make([]*media.Variant, 0, len(processed)),
+	}
+	for i, p := range processed {
+		v, err := s.mediaRepo.CreateVariant(ctx, media.Variant{
+			ImageKey:    imageKey,
+			Name:        p.name,
+			Key:         stored[i].Key,
+			URL:         stored[i].URL,
+			ContentType: p.contentType,
+			Width:       p.width,
+			Height:      p.height,
+			SizeBytes:   len(p.data),
+		})
+		if err != nil {
+			s.deleteStored(stored)
+			if delErr := s.mediaRepo.DeleteVariants(ctx, imageKey); delErr != nil {
+				log.Printf("failed to delete variants of %s: %v", imageKey, delErr)
+			}
+			return nil, err
+		}
+		upload.Variants = append(upload.Variants, v)
+	}
+
+	// Until a template or landing page references it, the upload is an
+	// orphan that the reconciler purges after the grace period.
+	m, err := s.mediaRepo.Create(ctx, userID, imageKey, upload.URL)
+	if err != nil {
+		s.deleteStored(stored)
+		if delErr := s.mediaRepo.DeleteVariants(ctx, imageKey); delErr != nil {
+			log.Printf("failed to delete variants of %s: %v", imageKey, delErr)
+		}
+		return nil, err
+	}
+	upload.ID = m.ID
+	return upload, nil
+}
+
+func (s *MediaService) List(ctx context.Context, userID int64) ([]*media.Item, error) {
+	return s.mediaRepo.ListItems(ctx, userID)
+}
+
+func (s *MediaService) Get(ctx context.Context, id, userID int64) (*media.Item, error) {
+	return s.mediaRepo.GetItem(ctx, id, userID)
+}
+
+func (s *MediaService) Delete(ctx context.Context, id, userID int64) error {
+	m, err := s.mediaRepo.GetByID(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	usage, err := s.mediaRepo.GetItem(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	// The reconciler never deletes a referenced image, so an image attached
+	// after this check is kept and marked attached again.
+	if usage.UsageCount > 0 {
+		return media.ErrMediaInUse
+	}
+	return s.mediaRepo.MarkDeleting(ctx, m.ImageKey)
+}
+
+func (s *MediaService) Resolve(ctx context.Context, userID int64, mediaID *int64, imageKey *string) (*media.Media, error) {
+	if mediaID != nil {
+		return s.mediaRepo.GetByID(ctx, *mediaID, userID)
+	}
+	if imageKey == nil {
+		return nil, nil
+	}
+	m, err := s.mediaRepo.GetByImageKey(ctx, userID, *imageKey)
+	if errors.Is(err, media.ErrMediaNotFound) {
+		return nil, nil
+	}
+	return m, err
+}
+
+func (s *MediaService) ReleaseAll(ctx context.Context, userID int64) error {
+	return s.mediaRepo.MarkUserDeleting(ctx, userID)
+}
+
+// deleteStored removes objects of an upload that could not be completed
+func (s *MediaService) deleteStored(stored []*StoredImage) {
+	for _, img := range stored {
+		if err := s.store.Delete(context.Background(), img.Key); err != nil {
+			log.Printf("failed to delete image key %s: %v", img.Key, err)
+		}
+	}
+}
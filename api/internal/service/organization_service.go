@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"callflow/internal/domain/organization"
+	"callflow/internal/domain/user"
+)
+
+// OrganizationService provides organization membership and access logic
+type OrganizationService struct {
+	orgRepo  organization.Repository
+	userRepo user.Repository
+}
+
+// NewOrganizationService creates a new organization service instance
+func NewOrganizationService(orgRepo organization.Repository, userRepo user.Repository) *OrganizationService {
+	return &OrganizationService{
+		orgRepo:  orgRepo,
+		userRepo: userRepo,
+	}
+}
+
+func (s *OrganizationService) GetOwn(ctx context.Context, ownerID int64) (*organization.Organization, error) {
+	org, err := s.orgRepo.GetByOwnerID(ctx, ownerID)
+	if err == nil || !errors.Is(err, organization.ErrOrganizationNotFound) {
+		return org, err
+	}
+
+	// Every account can become an organization; it is created on first use.
+	u, err := s.userRepo.GetByID(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	return s.orgRepo.Create(ctx, ownerID, defaultOrganizationName(u))
+}
+
+func (s *OrganizationService) UpdateOwn(ctx context.Context, ownerID int64, data organization.OrganizationUpdate) (*organization.Organization, error) {
+	org, err := s.GetOwn(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	return s.orgRepo.UpdateName(ctx, org.ID, data.Name)
+}
+
+func (s *OrganizationService) GetMembers(ctx context.Context, ownerID int64) ([]*organization.Member, error) {
+	org, err := s.GetOwn(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.orgRepo.ListMembers(ctx, org.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range members {
+		u, err := s.userRepo.GetByID(ctx, m.UserID)
+		if err != nil {
+			return nil, err
+		}
+		m.Phone = u.Phone
+		m.Name = u.Name
+	}
+	return members, nil
+}
+
+func (s *OrganizationService) UpdateMemberRole(ctx context.Context, ownerID, memberID int64, data organization.RoleUpdate) error {
+	org, err := s.GetOwn(ctx, ownerID)
+	if err != nil {
+		return err
+	}
+	return s.orgRepo.UpdateMemberRole(ctx, org.ID, memberID, data.Role)
+}
+
+func (s *OrganizationService) RemoveMember(ctx context.Context, ownerID, memberID int64) error {
+	org, err := s.GetOwn(ctx, ownerID)
+	if err != nil {
+		return err
+	}
+	return s.orgRepo.RemoveMember(ctx, org.ID, memberID)
+}
+
+func (s *OrganizationService) Invite(ctx context.Context, ownerID int64, data organization.InvitationCreate) (*organization.Invitation, error) {
+	org, err := s.GetOwn(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := s.userRepo.GetByID(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if owner.Phone == data.Phone {
+		return nil, organization.ErrCannotInviteSelf
+	}
+
+	invitee, err := s.userRepo.GetByPhone(ctx, data.Phone)
+	if err != nil && !errors.Is(err, user.ErrUserNotFound) {
+		return nil, err
+	}
+	if invitee != nil {
+		if _, err := s.orgRepo.GetMember(ctx, org.ID, invitee.ID); err == nil {
+			return nil, organization.ErrAlreadyMember
+		} else if !errors.Is(err, organization.ErrMemberNotFound) {
+			return nil, err
+		}
+	}
+
+	return s.orgRepo.CreateInvitation(ctx, org.ID, ownerID, data, time.Now().Add(organization.InvitationTTL))
+}
+
+func (s *OrganizationService) GetInvitations(ctx context.Context, ownerID int64) ([]*organization.Invitation, error) {
+	org, err := s.GetOwn(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	return s.orgRepo.ListInvitations(ctx, org.ID)
+}
+
+func (s *OrganizationService) RevokeInvitation(ctx context.Context, ownerID, invitationID int64) error {
+	org, err := s.GetOwn(ctx, ownerID)
+	if err != nil {
+		return err
+	}
+	inv, err := s.orgRepo.GetInvitation(ctx, invitationID)
+	if err != nil {
+		return err
+	}
+	if inv.OrganizationID != org.ID {
+		return organization.ErrInvitationNotFound
+	}
+	return s.orgRepo.RespondInvitation(ctx, inv.ID, organization.InvitationRevoked)
+}
+
+func (s *OrganizationService) GetMemberships(ctx context.Context, userID int64) ([]*organization.Membership, error) {
+	members, err := s.orgRepo.ListMemberships(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	memberships := make([]*organization.Membership, 0, len(members))
+	for _, m := range members {
+		org, err := s.orgRepo.GetByID(ctx, m.OrganizationID)
+		if err != nil {
+			return nil, err
+		}
+		memberships = append(memberships, &organization.Membership{Organization: org, Role: m.Role})
+	}
+	return memberships, nil
+}
+
+func (s *OrganizationService) GetReceivedInvitations(ctx context.Context, userID int64) ([]*organization.ReceivedInvitation, error) {
+	u, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	invitations, err := s.orgRepo.ListPendingInvitationsByPhone(ctx, u.Phone)
+	if err != nil {
+		return nil, err
+	}
+	received := make([]*organization.ReceivedInvitation, 0, len(invitations))
+	for _, inv := range invitations {
+		org, err := s.orgRepo.GetByID(ctx, inv.OrganizationID)
+		if err != nil {
+			return nil, err
+		}
+		received = append(received, &organization.ReceivedInvitation{Invitation: inv, OrganizationName: org.Name})
+	}
+	return received, nil
+}
+
+func (s *OrganizationService) AcceptInvitation(ctx context.Context, userID, invitationID int64) (*organization.Membership, error) {
+	u, inv, err := s.receivedInvitation(ctx, userID, invitationID)
+	if err != nil {
+		return nil, err
+	}
+	// Registration does not prove phone ownership, so an invitation addressed
+	// to a phone only binds once that phone has been verified.
+	if !u.PhoneVerified {
+		return nil, user.ErrPhoneNotVerified
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, inv.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.orgRepo.RespondInvitation(ctx, inv.ID, organization.InvitationAccepted); err != nil {
+		return nil, err
+	}
+	if err := s.orgRepo.AddMember(ctx, org.ID, userID, inv.Role); err != nil {
+		return nil, err
+	}
+	return &organization.Membership{Organization: org, Role: inv.Role}, nil
+}
+
+func (s *OrganizationService) DeclineInvitation(ctx context.Context, userID, invitationID int64) error {
+	_, inv, err := s.receivedInvitation(ctx, userID, invitationID)
+	if err != nil {
+		return err
+	}
+	return s.orgRepo.RespondInvitation(ctx, inv.ID, organization.InvitationDeclined)
+}
+
+func (s *OrganizationService) Leave(ctx context.Context, userID, organizationID int64) error {
+	org, err := s.orgRepo.GetByID(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+	if org.OwnerID == userID {
+		return organization.ErrOwnerCannotLeave
+	}
+	return s.orgRepo.RemoveMember(ctx, org.ID, userID)
+}
+
+func (s *OrganizationService) ResolveAccess(ctx context.Context, organizationID, userID int64) (*organization.Access, error) {
+	org, err := s.orgRepo.GetByID(ctx, organizationID)
+	if err != nil {
+		if errors.Is(err, organization.ErrOrganizationNotFound) {
+			return nil, organization.ErrNotMember
+		}
+		return nil, err
+	}
+	m, err := s.orgRepo.GetMember(ctx, org.ID, userID)
+	if err != nil {
+		if errors.Is(err, organization.ErrMemberNotFound) {
+			return nil, organization.ErrNotMember
+		}
+		return nil, err
+	}
+	owner, err := s.userRepo.GetByID(ctx, org.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+	if owner.Status != user.StatusActive {
+		return nil, organization.ErrNotMember
+	}
+	return &organization.Access{
+		OrganizationID: org.ID,
+		OwnerID:        org.OwnerID,
+		OwnerPlan:      owner.Plan,
+		Role:           m.Role,
+	}, nil
+}
+
+// receivedInvitation loads a pending, unexpired invitation addressed to the user's phone
+func (s *OrganizationService) receivedInvitation(ctx context.Context, userID, invitationID int64) (*user.User, *organization.Invitation, error) {
+	u, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	inv, err := s.orgRepo.GetInvitation(ctx, invitationID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if inv.Phone != u.Phone || inv.Status != organization.InvitationPending || time.Now().After(inv.ExpiresAt) {
+		return nil, nil, organization.ErrInvitationNotFound
+	}
+	return u, inv, nil
+}
+
+// defaultOrganizationName names a new organization after the owner's business
+func defaultOrganizationName(u *user.User) string {
+	switch {
+	case u.BusinessName != "":
+		return u.BusinessName
+	case u.Name != "":
+		return u.Name
+	default:
+		return u.Phone
+	}
+}
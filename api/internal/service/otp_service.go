@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"time"
+
+	"callflow/internal/domain/otp"
+	"callflow/internal/domain/user"
+)
+
+// OTPService provides one-time code business logic
+type OTPService struct {
+	otpRepo  otp.Repository
+	userRepo user.Repository
+	sender   otp.Sender
+	secret   []byte
+}
+
+// NewOTPService creates a new one-time code service instance.
+// Codes are hashed with HMAC-SHA256 keyed by secret before they are stored.
+func NewOTPService(otpRepo otp.Repository, userRepo user.Repository, sender otp.Sender, secret string) *OTPService {
+	return &OTPService{
+		otpRepo:  otpRepo,
+		userRepo: userRepo,
+		sender:   sender,
+		secret:   []byte(secret),
+	}
+}
+
+func (s *OTPService) Request(ctx context.Context, req otp.Request) error {
+	purpose := otpPurpose(req.Purpose)
+
+	if _, err := s.userRepo.GetByPhone(ctx, req.Phone); err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	sent, err := s.otpRepo.CountSince(ctx, req.Phone, purpose, time.Now().Add(-time.Hour))
+	if err != nil {
+		return err
+	}
+	if sent >= otp.MaxRequestsPerHour {
+		return otp.ErrTooManyRequests
+	}
+
+	code, err := generateOTPCode()
+	if err != nil {
+		return fmt.Errorf("failed to generate code: %w", err)
+	}
+
+	// Only the newest code of a purpose is valid.
+	if err := s.otpRepo.Invalidate(ctx, req.Phone, purpose); err != nil {
+		return err
+	}
+	if _, err := s.otpRepo.Create(ctx, otp.CodeCreate{
+		Phone:       req.Phone,
+		Purpose:     purpose,
+		CodeHash:    s.hashCode(req.Phone, purpose, code),
+		MaxAttempts: otp.MaxAttempts,
+		ExpiresAt:   time.Now().Add(otp.CodeTTL),
+	}); err != nil {
+		return err
+	}
+
+	return s.sender.Send(ctx, req.Phone, code, purpose)
+}
+
+func (s *OTPService) Verify(ctx context.Context, req otp.Verify) error {
+	purpose := otpPurpose(req.Purpose)
+	if err := s.check(ctx, req.Phone, purpose, req.Code); err != nil {
+		return err
+	}
+
+	switch purpose {
+	case otp.PurposeVerifyPhone:
+		return s.userRepo.MarkPhoneVerified(ctx, req.Phone)
+	}
+	return nil
+}
+
+// check validates a code and consumes it on success.
+func (s *OTPService) check(ctx context.Context, phone, purpose, code string) error {
+	c, err := s.otpRepo.GetActive(ctx, phone, purpose)
+	if err != nil {
+		if errors.Is(err, otp.ErrCodeNotFound) {
+			return otp.ErrInvalidCode
+		}
+		return err
+	}
+	if c.IsExpired() {
+		return otp.ErrCodeExpired
+	}
+	// Every guess uses an attempt before it is compared.
+	ok, err := s.otpRepo.UseAttempt(ctx, c.ID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return otp.ErrTooManyAttempts
+	}
+
+	expected, err := hex.DecodeString(c.CodeHash)
+	if err != nil {
+		return fmt.Errorf("failed to decode code hash: %w", err)
+	}
+	actual, _ := hex.DecodeString(s.hashCode(phone, purpose, code))
+	if !hmac.Equal(expected, actual) {
+		return otp.ErrInvalidCode
+	}
+
+	consumed, err := s.otpRepo.Consume(ctx, c.ID)
+	if err != nil {
+		return err
+	}
+	if !consumed {
+		// A concurrent request used the code first.
+		return otp.ErrInvalidCode
+	}
+	return nil
+}
+
+func (s *OTPService) hashCode(phone, purpose, code string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(phone + "|" + purpose + "|" + code))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func otpPurpose(purpose string) string {
+	if purpose == "" {
+		return otp.PurposeVerifyPhone
+	}
+	return purpose
+}
+
+func generateOTPCode() (string, error) {
+	limit := big.NewInt(1)
+	for i := 0; i < otp.CodeLength; i++ {
+		limit.Mul(limit, big.NewInt(10))
+	}
+	n, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", otp.CodeLength, n), nil
+}
+
+// LogOTPSender writes codes to the server log instead of sending them.
+// It stands in for a real SMS gateway during local development.
+type LogOTPSender struct{}
+
+func (LogOTPSender) Send(_ context.Context, phone, code, purpose string) error {
+	log.Printf("OTP for %s (%s): %s", phone, purpose, code)
+	return nil
+}
+
+// NewOTPSenderFromEnv returns the sender selected by OTP_SENDER.
+// Only "log" (the default) is built in.
+func NewOTPSenderFromEnv() (otp.Sender, error) {
+	switch name := os.Getenv("OTP_SENDER"); name {
+	case "", "log":
+		return LogOTPSender{}, nil
+	default:
+		return nil, fmt.Errorf("unknown OTP_SENDER %q", name)
+	}
+}
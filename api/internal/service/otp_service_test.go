@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"callflow/internal/domain/otp"
+)
+
+// fakeOTPRepo holds a single code, counting attempts like the database does
+type fakeOTPRepo struct {
+	code     *otp.Code
+	consumed bool
+}
+
+func (r *fakeOTPRepo) Create(_ context.Context, data otp.CodeCreate) (*otp.Code, error) {
+	r.code = &otp.Code{ID: 1, Phone: data.Phone, Purpose: data.Purpose, CodeHash: data.CodeHash, MaxAttempts: data.MaxAttempts, ExpiresAt: data.ExpiresAt}
+	return r.code, nil
+}
+
+func (r *fakeOTPRepo) GetActive(_ context.Context, phone, purpose string) (*otp.Code, error) {
+	if r.code == nil || r.consumed || r.code.Phone != phone || r.code.Purpose != purpose {
+		return nil, otp.ErrCodeNotFound
+	}
+	c := *r.code
+	return &c, nil
+}
+
+func (r *fakeOTPRepo) CountSince(context.Context, string, string, time.Time) (int, error) {
+	return 0, nil
+}
+
+func (r *fakeOTPRepo) UseAttempt(_ context.Context, id int64) (bool, error) {
+	if r.code.Attempts >= r.code.MaxAttempts {
+		return false, nil
+	}
+	r.code.Attempts++
+	return true, nil
+}
+
+func (r *fakeOTPRepo) Consume(context.Context, int64) (bool, error) {
+	if r.consumed {
+		return false, nil
+	}
+	r.consumed = true
+	return true, nil
+}
+
+func (r *fakeOTPRepo) Invalidate(context.Context, string, string) error {
+	r.code = nil
+	return nil
+}
+
+func TestOTPCheckAttemptCap(t *testing.T) {
+	const (
+		phone = "+15550100"
+		code  = "123456"
+		wrong = "654321"
+	)
+
+	tests := []struct {
+		name         string
+		priorGuesses int
+		expired      bool
+		guess        string
+		wantErr      error
+		wantAttempts int
+	}{
+		{"correct code", 0, false, code, nil, 1},
+		{"wrong code", 0, false, wrong, otp.ErrInvalidCode, 1},
+		{"correct code on the last attempt", otp.MaxAttempts - 1, false, code, nil, otp.MaxAttempts},
+		{"wrong code on the last attempt", otp.MaxAttempts - 1, false, wrong, otp.ErrInvalidCode, otp.MaxAttempts},
+		{"correct code after the cap", otp.MaxAttempts, false, code, otp.ErrTooManyAttempts, otp.MaxAttempts},
+		{"wrong code after the cap", otp.MaxAttempts, false, wrong, otp.ErrTooManyAttempts, otp.MaxAttempts},
+		{"expired code", 0, true, code, otp.ErrCodeExpired, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeOTPRepo{}
+			s := NewOTPService(repo, nil, LogOTPSender{}, "test-secret")
+
+			expiresAt := time.Now().Add(otp.CodeTTL)
+			if tt.expired {
+				expiresAt = time.Now().Add(-time.Second)
+			}
+			repo.Create(context.Background(), otp.CodeCreate{
+				Phone:       phone,
+				Purpose:     otp.PurposeVerifyPhone,
+				CodeHash:    s.hashCode(phone, otp.PurposeVerifyPhone, code),
+				MaxAttempts: otp.MaxAttempts,
+				ExpiresAt:   expiresAt,
+			})
+			repo.code.Attempts = tt.priorGuesses
+
+			err := s.check(context.Background(), phone, otp.PurposeVerifyPhone, tt.guess)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("check() error = %v, want %v", err, tt.wantErr)
+			}
+			if repo.code.Attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", repo.code.Attempts, tt.wantAttempts)
+			}
+			if repo.consumed != (tt.wantErr == nil) {
+				t.Errorf("consumed = %v, want %v", repo.consumed, tt.wantErr == nil)
+			}
+		})
+	}
+}
+
+func TestOTPCheckStopsGuessingAtCap(t *testing.T) {
+	const phone = "+15550100"
+	repo := &fakeOTPRepo{}
+	s := NewOTPService(repo, nil, LogOTPSender{}, "test-secret")
+	repo.Create(context.Background(), otp.CodeCreate{
+		Phone:       phone,
+		Purpose:     otp.PurposeVerifyPhone,
+		CodeHash:    s.hashCode(phone, otp.PurposeVerifyPhone, "123456"),
+		MaxAttempts: otp.MaxAttempts,
+		ExpiresAt:   time.Now().Add(otp.CodeTTL),
+	})
+
+	for i := 0; i < otp.MaxAttempts; i++ {
+		if err := s.check(context.Background(), phone, otp.PurposeVerifyPhone, "000000"); !errors.Is(err, otp.ErrInvalidCode) {
+			t.Fatalf("guess %d: error = %v, want %v", i+1, err, otp.ErrInvalidCode)
+		}
+	}
+	if err := s.check(context.Background(), phone, otp.PurposeVerifyPhone, "123456"); !errors.Is(err, otp.ErrTooManyAttempts) {
+		t.Fatalf("correct code after %d wrong guesses: error = %v, want %v", otp.MaxAttempts, err, otp.ErrTooManyAttempts)
+	}
+}
+
+func TestOTPHashCode(t *testing.T) {
+	s := NewOTPService(nil, nil, LogOTPSender{}, "test-secret")
+	base := s.hashCode("+15550100", otp.PurposeVerifyPhone, "123456")
+
+	tests := []struct {
+		name    string
+		secret  string
+		phone   string
+		purpose string
+		code    string
+		same    bool
+	}{
+		{"same input", "test-secret", "+15550100", otp.PurposeVerifyPhone, "123456", true},
+		{"other code", "test-secret", "+15550100", otp.PurposeVerifyPhone, "123457", false},
+		{"other phone", "test-secret", "+15550101", otp.PurposeVerifyPhone, "123456", false},
+		{"other purpose", "test-secret", "+15550100", otp.PurposePasswordReset, "123456", false},
+		{"other secret", "other-secret", "+15550100", otp.PurposeVerifyPhone, "123456", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewOTPService(nil, nil, LogOTPSender{}, tt.secret).hashCode(tt.phone, tt.purpose, tt.code)
+			if (got == base) != tt.same {
+				t.Errorf("hash equal = %v, want %v", got == base, tt.same)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"callflow/internal/domain/contact"
+	"callflow/internal/domain/device"
+	"callflow/internal/domain/sequence"
+	"callflow/internal/domain/template"
+	"callflow/internal/domain/webhook"
+)
+
+const maxListedEnrollments = 200
+
+// SequenceService provides follow-up sequence business logic
+type SequenceService struct {
+	sequenceRepo sequence.Repository
+	templateRepo template.Repository
+	events       webhook.Emitter
+}
+
+// NewSequenceService creates a new sequence service instance
+func NewSequenceService(sequenceRepo sequence.Repository, templateRepo template.Repository, events webhook.Emitter) *SequenceService {
+	return &SequenceService{
+		sequenceRepo: sequenceRepo,
+		templateRepo: templateRepo,
+		events:       events,
+	}
+}
+
+func (s *SequenceService) Get(ctx context.Context, userID int64) ([]*sequence.Sequence, error) {
+	return s.sequenceRepo.GetByUserID(ctx, userID)
+}
+
+func (s *SequenceService) GetByID(ctx context.Context, id int64, userID int64) (*sequence.Sequence, error) {
+	return s.sequenceRepo.GetByID(ctx, id, userID)
+}
+
+func (s *SequenceService) Create(ctx context.Context, userID int64, data sequence.SequenceCreate) (*sequence.Sequence, error) {
+	if err := s.validateSteps(ctx, userID, data.Steps); err != nil {
+		return nil, err
+	}
+	return s.sequenceRepo.Create(ctx, userID, data)
+}
+
+func (s *SequenceService) Update(ctx context.Context, id int64, userID int64, data sequence.SequenceUpdate) (*sequence.Sequence, error) {
+	if _, err := s.sequenceRepo.GetByID(ctx, id, userID); err != nil {
+		return nil, err
+	}
+	if err := s.validateSteps(ctx, userID, data.Steps); err != nil {
+		return nil, err
+	}
+	return s.sequenceRepo.Update(ctx, id, userID, data)
+}
+
+func (s *SequenceService) Delete(ctx context.Context, id int64, userID int64) error {
+	return s.sequenceRepo.Delete(ctx, id, userID)
+}
+
+func (s *SequenceService) GetEnrollments(ctx context.Context, id int64, userID int64) ([]*sequence.Enrollment, error) {
+	if _, err := s.sequenceRepo.GetByID(ctx, id, userID); err != nil {
+		return nil, err
+	}
+	return s.sequenceRepo.GetEnrollments(ctx, id, userID, maxListedEnrollments)
+}
+
+func (s *SequenceService) HandleCall(ctx context.Context, userID int64, c *contact.Contact, direction string) ([]*sequence.Enrollment, error) {
+	// An incoming or missed call from someone already in a sequence is a callback.
+	// Enroll skips the sequences stopped here, so the callback does not restart them.
+	if direction != contact.DirectionOutgoing {
+		if _, err := s.sequenceRepo.StopOnCallback(ctx, userID, c.Phone); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.IsOptedOut() {
+		return []*sequence.Enrollment{}, nil
+	}
+
+	sequences, err := s.sequenceRepo.GetEnabledByTrigger(ctx, userID, direction)
+	if err != nil {
+		return nil, err
+	}
+
+	startedAt := time.Now()
+	enrollments := make([]*sequence.Enrollment, 0, len(sequences))
+	for _, seq := range sequences {
+		if len(seq.Steps) == 0 {
+			continue
+		}
+		firstRunAt := startedAt.Add(time.Duration(seq.Steps[0].DelaySeconds) * time.Second)
+		e, err := s.sequenceRepo.Enroll(ctx, seq.ID, userID, c.Phone, firstRunAt)
+		if err != nil {
+			if errors.Is(err, sequence.ErrAlreadyEnrolled) {
+				continue
+			}
+			return nil, err
+		}
+		enrollments = append(enrollments, e)
+	}
+	return enrollments, nil
+}
+
+func (s *SequenceService) HandleOptOut(ctx context.Context, userID int64, phone string) error {
+	_, err := s.sequenceRepo.StopOnOptOut(ctx, userID, phone)
+	return err
+}
+
+func (s *SequenceService) JobSource() string {
+	return device.JobSourceSequence
+}
+
+func (s *SequenceService) QueueDue(ctx context.Context, userID int64, limit int) error {
+	enrollments, err := s.sequenceRepo.ClaimDueEnrollments(ctx, userID, limit)
+	if err != nil {
+		return err
+	}
+
+	sequences := make(map[int64]*sequence.Sequence)
+	for _, e := range enrollments {
+		seq, ok := sequences[e.SequenceID]
+		if !ok {
+			seq, err = s.sequenceRepo.GetByID(ctx, e.SequenceID, userID)
+			if err != nil && !errors.Is(err, sequence.ErrSequenceNotFound) {
+				return err
+			}
+			sequences[e.SequenceID] = seq
+		}
+
+		if seq == nil || !seq.Enabled {
+			s.stopEnrollment(ctx, e.ID, sequence.StopReasonSequenceDisabled)
+			continue
+		}
+		if e.NextStep >= len(seq.Steps) {
+			if err := s.sequenceRepo.AdvanceEnrollment(ctx, e.ID, sequence.EnrollmentAdvance{
+				NextStep: e.NextStep,
+				Status:   sequence.StatusCompleted,
+			}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// The job and the advance are written together. A claimed enrollment
+		// that fails to queue is claimed again once its claim runs out, so the
+		// step is neither lost nor sent twice.
+		templateID := seq.Steps[e.NextStep].TemplateID
+		payload, err := json.Marshal(device.SMSPayload{Phone: e.Phone, TemplateID: &templateID})
+		if err != nil {
+			return err
+		}
+		job := device.JobCreate{
+			Type:    device.JobTypeSMS,
+			Payload: payload,
+			Source:  device.JobSourceSequence,
+			RefID:   &e.ID,
+		}
+		if err := s.sequenceRepo.QueueStep(ctx, e.ID, userID, job, nextAdvance(seq, e)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SequenceService) JobFinished(ctx context.Context, job *device.Job) error {
+	var payload device.SMSPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return err
+	}
+	eventType := webhook.EventMessageSent
+	if job.Status == device.JobStatusFailed {
+		eventType = webhook.EventMessageFailed
+	}
+	data := webhook.MessageData{
+		Source:     webhook.SourceSequence,
+		JobID:      job.ID,
+		Phone:      payload.Phone,
+		TemplateID: payload.TemplateID,
+		Error:      job.LastError,
+	}
+	if job.RefID != nil {
+		data.EnrollmentID = *job.RefID
+	}
+	s.events.Emit(ctx, job.UserID, eventType, data)
+	return nil
+}
+
+func (s *SequenceService) validateSteps(ctx context.Context, userID int64, steps []sequence.Step) error {
+	prevDelay := 0
+	for _, step := range steps {
+		if step.DelaySeconds < prevDelay {
+			return sequence.ErrStepsOutOfOrder
+		}
+		prevDelay = step.DelaySeconds
+
+		if _, err := s.templateRepo.GetByID(ctx, step.TemplateID, userID); err != nil {
+			if errors.Is(err, template.ErrTemplateNotFound) {
+				return sequence.ErrInvalidTemplate
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SequenceService) stopEnrollment(ctx context.Context, id int64, reason string) {
+	if err := s.sequenceRepo.StopEnrollment(ctx, id, reason); err != nil {
+		log.Printf("failed to stop sequence enrollment %d: %v", id, err)
+	}
+}
+
+// nextAdvance computes the enrollment state after its current step has been handed out.
+func nextAdvance(seq *sequence.Sequence, e *sequence.Enrollment) sequence.EnrollmentAdvance {
+	next := e.NextStep + 1
+	if next >= len(seq.Steps) {
+		return sequence.EnrollmentAdvance{
+			NextStep: next,
+			Status:   sequence.StatusCompleted,
+		}
+	}
+	runAt := e.StartedAt.Add(time.Duration(seq.Steps[next].DelaySeconds) * time.Second)
+	return sequence.EnrollmentAdvance{
+		NextStep:  next,
+		NextRunAt: &runAt,
+		Status:    sequence.StatusActive,
+	}
+}
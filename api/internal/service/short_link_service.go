@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"os"
+	"regexp"
+	"strings"
+
+	"callflow/internal/domain/shortlink"
+)
+
+const (
+	defaultShortLinkBaseURL = "https://adflow.up.railway.app"
+	shortLinkCodeAttempts   = 5
+	shortLinkAlphabet       = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+)
+
+var textURLPattern = regexp.MustCompile(`https?://[^\s<>"']+`)
+
+// ShortLinkService issues short links under baseURL and logs their clicks
+type ShortLinkService struct {
+	shortLinkRepo shortlink.Repository
+	baseURL       string
+}
+
+// NewShortLinkService creates a new short link service instance
+func NewShortLinkService(shortLinkRepo shortlink.Repository) *ShortLinkService {
+	return &ShortLinkService{
+		shortLinkRepo: shortLinkRepo,
+		baseURL:       shortLinkBaseURLFromEnv(),
+	}
+}
+
+func (s *ShortLinkService) Create(ctx context.Context, userID int64, data shortlink.ShortLinkCreate) (*shortlink.ShortLink, error) {
+	if data.Source == "" {
+		data.Source = shortlink.SourceManual
+	}
+	for attempt := 1; ; attempt++ {
+		code, err := randomCode(shortlink.CodeLength)
+		if err != nil {
+			return nil, err
+		}
+		link, err := s.shortLinkRepo.Create(ctx, userID, code, data)
+		if errors.Is(err, shortlink.ErrCodeTaken) && attempt < shortLinkCodeAttempts {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		link.ShortURL = s.shortURL(link.Code)
+		return link, nil
+	}
+}
+
+func (s *ShortLinkService) Get(ctx context.Context, userID int64) ([]*shortlink.ShortLink, error) {
+	links, err := s.shortLinkRepo.GetByUserID(ctx, userID, shortlink.MaxListedLinks)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range links {
+		l.ShortURL = s.shortURL(l.Code)
+	}
+	return links, nil
+}
+
+func (s *ShortLinkService) GetByID(ctx context.Context, id, userID int64) (*shortlink.ShortLink, error) {
+	link, err := s.shortLinkRepo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	link.ShortURL = s.shortURL(link.Code)
+	link.RecentClicks, err = s.shortLinkRepo.GetClicks(ctx, link.ID, shortlink.MaxListedClicks)
+	if err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+func (s *ShortLinkService) GetByRef(ctx context.Context, userID int64, source string, refID int64) (*shortlink.ShortLink, error) {
+	link, err := s.shortLinkRepo.GetByRef(ctx, userID, source, refID)
+	if err != nil {
+		return nil, err
+	}
+	link.ShortURL = s.shortURL(link.Code)
+	return link, nil
+}
+
+func (s *ShortLinkService) Follow(ctx context.Context, code, userAgent string) (string, error) {
+	link, err := s.shortLinkRepo.GetByCode(ctx, code)
+	if err != nil {
+		return "", err
+	}
+	if len(userAgent) > shortlink.MaxUserAgentLength {
+		userAgent = userAgent[:shortlink.MaxUserAgentLength]
+	}
+	if err := s.shortLinkRepo.RecordClick(ctx, link.ID, userAgent, classifyDevice(userAgent)); err != nil {
+		return "", err
+	}
+	return link.URL, nil
+}
+
+func (s *ShortLinkService) ShortenText(ctx context.Context, userID int64, text string, phone *string) (string, []*shortlink.ShortLink, error) {
+	var links []*shortlink.ShortLink
+	var firstErr error
+	shortened := textURLPattern.ReplaceAllStringFunc(text, func(match string) string {
+		if firstErr != nil {
+			return match
+		}
+		// Punctuation closing a sentence is not part of the URL.
+		url := strings.TrimRight(match, ".,;:!?)]}")
+		if strings.HasPrefix(url, s.baseURL+"/s/") {
+			return match
+		}
+		link, err := s.Create(ctx, userID, shortlink.ShortLinkCreate{
+			URL:    url,
+			Phone:  phone,
+			Source: shortlink.SourceRender,
+		})
+		if err != nil {
+			firstErr = err
+			return match
+		}
+		links = append(links, link)
+		return link.ShortURL + match[len(url):]
+	})
+	if firstErr != nil {
+		return "", nil, firstErr
+	}
+	return shortened, links, nil
+}
+
+func (s *ShortLinkService) shortURL(code string) string {
+	return s.baseURL + "/s/" + code
+}
+
+// classifyDevice derives a coarse device class from a user agent
+func classifyDevice(userAgent string) string {
+	ua := strings.ToLower(userAgent)
+	switch {
+	case ua == "":
+		return shortlink.DeviceUnknown
+	case containsAny(ua, "bot", "crawler", "spider", "preview", "facebookexternalhit", "whatsapp/", "curl/", "wget/", "python-", "go-http-client"):
+		return shortlink.DeviceBot
+	case containsAny(ua, "ipad", "tablet") || (strings.Contains(ua, "android") && !strings.Contains(ua, "mobile")):
+		return shortlink.DeviceTablet
+	case containsAny(ua, "mobi", "iphone", "android"):
+		return shortlink.DeviceMobile
+	case containsAny(ua, "windows", "macintosh", "x11", "cros"):
+		return shortlink.DeviceDesktop
+	default:
+		return shortlink.DeviceUnknown
+	}
+}
+
+func containsAny(s string, substrs ...string) bool {
+	for _, sub := range substrs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// randomCode returns n random base62 characters
+func randomCode(n int) (string, error) {
+	base := big.NewInt(int64(len(shortLinkAlphabet)))
+	b := make([]byte, n)
+	for i := range b {
+		v, err := rand.Int(rand.Reader, base)
+		if err != nil {
+			return "", err
+		}
+		b[i] = shortLinkAlphabet[v.Int64()]
+	}
+	return string(b), nil
+}
+
+// shortLinkBaseURLFromEnv reads SHORT_LINK_BASE_URL, the public origin of this
+// API that serves /s/<code>.
+func shortLinkBaseURLFromEnv() string {
+	base := strings.TrimRight(strings.TrimSpace(os.Getenv("SHORT_LINK_BASE_URL")), "/")
+	if base == "" {
+		return defaultShortLinkBaseURL
+	}
+	return base
+}
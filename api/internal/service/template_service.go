@@ -2,29 +2,35 @@ package service
 
 import (
 	"context"
-	"log"
+	"errors"
 	"net/url"
 	"strings"
+	"time"
 
+	"callflow/internal/domain/landing"
+	"callflow/internal/domain/media"
+	"callflow/internal/domain/shortlink"
 	"callflow/internal/domain/template"
+	"callflow/internal/domain/user"
 )
 
-type TemplateImageStore interface {
-	UploadTemplateImage(ctx context.Context, filename, contentType string, file []byte) (*template.UploadedImage, error)
-	DeleteTemplateImage(ctx context.Context, imageKey string) error
-}
-
 // TemplateService provides template business logic
 type TemplateService struct {
-	templateRepo template.Repository
-	imageStore   TemplateImageStore
+	templateRepo     template.Repository
+	userRepo         user.Repository
+	mediaService     media.Service
+	landingService   landing.Service
+	shortLinkService shortlink.Service
 }
 
 // NewTemplateService creates a new template service instance
-func NewTemplateService(templateRepo template.Repository, imageStore TemplateImageStore) *TemplateService {
+func NewTemplateService(templateRepo template.Repository, userRepo user.Repository, mediaService media.Service, landingService landing.Service, shortLinkService shortlink.Service) *TemplateService {
 	return &TemplateService{
-		templateRepo: templateRepo,
-		imageStore:   imageStore,
+		templateRepo:     templateRepo,
+		userRepo:         userRepo,
+		mediaService:     mediaService,
+		landingService:   landingService,
+		shortLinkService: shortLinkService,
 	}
 }
 
@@ -40,6 +46,12 @@ func (s *TemplateService) Create(ctx context.Context, userID int64, data templat
 	data.ImageURL = normalizeURL(data.ImageURL)
 	data.ImageKey = normalizeStringPtr(data.ImageKey)
 
+	mediaID, imageURL, imageKey, err := linkMedia(ctx, s.mediaService, userID, data.MediaID, data.ImageURL, data.ImageKey)
+	if err != nil {
+		return nil, err
+	}
+	data.MediaID, data.ImageURL, data.ImageKey = mediaID, imageURL, imageKey
+
 	if err := validateImageFields(data.ImageURL, data.ImageKey, true); err != nil {
 		return nil, err
 	}
@@ -69,6 +81,12 @@ func (s *TemplateService) Update(ctx context.Context, id int64, userID int64, da
 		data.ImageKey = nil
 	}
 
+	mediaID, imageURL, imageKey, err := linkMedia(ctx, s.mediaService, userID, data.MediaID, data.ImageURL, data.ImageKey)
+	if err != nil {
+		return nil, err
+	}
+	data.MediaID, data.ImageURL, data.ImageKey = mediaID, imageURL, imageKey
+
 	requiresImageKey := data.ImageURL != nil && (existing.ImageURL == nil || *data.ImageURL != *existing.ImageURL)
 	if err := validateImageFields(data.ImageURL, data.ImageKey, requiresImageKey); err != nil {
 		return nil, err
@@ -77,40 +95,88 @@ func (s *TemplateService) Update(ctx context.Context, id int64, userID int64, da
 		return nil, err
 	}
 
-	updated, err := s.templateRepo.Update(ctx, id, userID, data)
-	if err != nil {
-		return nil, err
-	}
+	return s.templateRepo.Update(ctx, id, userID, data)
+}
 
-	if shouldDeleteOldImage(existing.ImageKey, updated.ImageKey) {
-		s.deleteImageKeyAsync(existing.ImageKey)
+func (s *TemplateService) Delete(ctx context.Context, id int64, userID int64) error {
+	if _, err := s.templateRepo.GetByID(ctx, id, userID); err != nil {
+		return err
 	}
-
-	return updated, nil
+	return s.templateRepo.Delete(ctx, id, userID)
 }
 
-func (s *TemplateService) Delete(ctx context.Context, id int64, userID int64) error {
-	existing, err := s.templateRepo.GetByID(ctx, id, userID)
+func (s *TemplateService) UploadImage(ctx context.Context, userID int64, filename, contentType string, file []byte) (*template.UploadedImage, error) {
+	uploaded, err := s.mediaService.Upload(ctx, userID, filename, contentType, file)
+	if errors.Is(err, media.ErrUploadDisabled) {
+		return nil, template.ErrUploadDisabled
+	}
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return &template.UploadedImage{
+		MediaID:  uploaded.ID,
+		URL:      uploaded.URL,
+		Key:      uploaded.Key,
+		Variants: uploaded.Variants,
+	}, nil
+}
 
-	if err := s.templateRepo.Delete(ctx, id, userID); err != nil {
-		return err
+func (s *TemplateService) Render(ctx context.Context, id int64, userID int64, data template.RenderRequest) (*template.Rendered, error) {
+	t, err := s.templateRepo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	u, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
 	}
 
-	if existing.ImageKey != nil {
-		s.deleteImageKeyAsync(existing.ImageKey)
+	now := time.Now()
+	contactName := data.ContactName
+	if contactName == "" {
+		contactName = data.Phone
+	}
+	vars := map[string]string{
+		"contact_name":  contactName,
+		"business_name": u.BusinessName,
+		"phone_number":  data.Phone,
+		"call_duration": "",
+		"date":          now.Format("02/01/2006"),
+		"time":          now.Format("03:04 PM"),
+	}
+	// Only messages that carry the landing page get an attribution link.
+	if strings.Contains(t.Body, "{landing_url}") {
+		link, err := s.landingService.CreateLink(ctx, userID, landing.LinkCreate{
+			Source: landing.LinkSourceManual,
+			Phone:  &data.Phone,
+		})
+		if err != nil {
+			return nil, err
+		}
+		vars["landing_url"] = link.URL
+	}
+	for k, v := range data.Variables {
+		vars[k] = v
 	}
 
-	return nil
-}
+	pairs := make([]string, 0, len(vars)*2)
+	for k, v := range vars {
+		pairs = append(pairs, "{"+k+"}", v)
+	}
+	body := strings.NewReplacer(pairs...).Replace(t.Body)
 
-func (s *TemplateService) UploadImage(ctx context.Context, _ int64, filename, contentType string, file []byte) (*template.UploadedImage, error) {
-	if s.imageStore == nil {
-		return nil, template.ErrUploadDisabled
+	body, links, err := s.shortLinkService.ShortenText(ctx, userID, body, &data.Phone)
+	if err != nil {
+		return nil, err
 	}
-	return s.imageStore.UploadTemplateImage(ctx, filename, contentType, file)
+	if links == nil {
+		links = []*shortlink.ShortLink{}
+	}
+	return &template.Rendered{
+		Body:     body,
+		ImageURL: t.ImageURL,
+		Links:    links,
+	}, nil
 }
 
 func validateSMSLength(channel string, body string, imageURL *string) error {
@@ -133,7 +199,7 @@ func validateImageFields(imageURL, imageKey *string, requireImageKey bool) error
 		return nil
 	}
 	parsed, err := url.Parse(*imageURL)
-	if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+	if err != nil || !isAllowedImageURL(parsed) {
 		return template.ErrInvalidImageURL
 	}
 	if requireImageKey && imageKey == nil {
@@ -165,21 +231,19 @@ func normalizeStringPtr(v *string) *string {
 	return &trimmed
 }
 
-func shouldDeleteOldImage(oldKey, newKey *string) bool {
-	if oldKey == nil || *oldKey == "" {
-		return false
-	}
-	if newKey == nil || *newKey == "" {
-		return true
+// linkMedia points an image at the user's media library. A media ID replaces
+// the image URL and key with the library image's; otherwise an uploaded key is
+// matched to its media ID. Images outside the library keep a nil media ID.
+func linkMedia(ctx context.Context, mediaService media.Service, userID int64, mediaID *int64, imageURL, imageKey *string) (*int64, *string, *string, error) {
+	m, err := mediaService.Resolve(ctx, userID, mediaID, imageKey)
+	if err != nil {
+		return nil, nil, nil, err
 	}
-	return *oldKey != *newKey
-}
-
-func (s *TemplateService) deleteImageKeyAsync(imageKey *string) {
-	if imageKey == nil || s.imageStore == nil {
-		return
+	if m == nil {
+		return nil, imageURL, imageKey, nil
 	}
-	if err := s.imageStore.DeleteTemplateImage(context.Background(), *imageKey); err != nil {
-		log.Printf("failed to delete image key %s: %v", *imageKey, err)
+	if mediaID == nil {
+		return &m.ID, imageURL, imageKey, nil
 	}
+	return &m.ID, &m.URL, &m.ImageKey, nil
 }
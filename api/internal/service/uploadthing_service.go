@@ -11,13 +11,11 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/textproto"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
-
-	"callflow/internal/domain/landing"
-	"callflow/internal/domain/template"
 )
 
 const (
@@ -41,6 +39,8 @@ type prepareUploadResponse struct {
 	Key string `json:"key"`
 }
 
+// UploadThingImageStore stores images on UploadThing, which serves them from
+// its own CDN.
 type UploadThingImageStore struct {
 	apiKey string
 	appID  string
@@ -59,46 +59,14 @@ func NewUploadThingImageStoreFromEnv() (*UploadThingImageStore, error) {
 	}, nil
 }
 
-func (s *UploadThingImageStore) UploadTemplateImage(
-	ctx context.Context,
-	filename,
-	contentType string,
-	file []byte,
-) (*template.UploadedImage, error) {
-	url, key, err := s.uploadImage(ctx, filename, contentType, file)
-	if err != nil {
-		return nil, err
-	}
-	return &template.UploadedImage{
-		URL: url,
-		Key: key,
-	}, nil
-}
-
-func (s *UploadThingImageStore) UploadLandingImage(
-	ctx context.Context,
-	filename,
-	contentType string,
-	file []byte,
-) (*landing.UploadedImage, error) {
-	url, key, err := s.uploadImage(ctx, filename, contentType, file)
-	if err != nil {
-		return nil, err
-	}
-	return &landing.UploadedImage{
-		URL: url,
-		Key: key,
-	}, nil
-}
-
-func (s *UploadThingImageStore) uploadImage(
+func (s *UploadThingImageStore) Put(
 	ctx context.Context,
 	filename,
 	contentType string,
 	file []byte,
-) (string, string, error) {
+) (*StoredImage, error) {
 	if len(file) == 0 {
-		return "", "", errors.New("empty file")
+		return nil, errors.New("empty file")
 	}
 
 	fileName := sanitizeFileName(filename)
@@ -108,25 +76,49 @@ func (s *UploadThingImageStore) uploadImage(
 
 	uploadURL, fileKey, err := s.prepareUpload(ctx, fileName, contentType, len(file))
 	if err != nil {
-		return "", "", err
+		return nil, err
 	}
 
 	if err := s.putMultipartFile(ctx, uploadURL, fileName, contentType, file); err != nil {
-		return "", "", err
+		return nil, err
 	}
 
-	return fmt.Sprintf("https://%s.ufs.sh/f/%s", s.appID, fileKey), fileKey, nil
+	return &StoredImage{
+		URL: s.fileURL(fileKey),
+		Key: fileKey,
+	}, nil
 }
 
-func (s *UploadThingImageStore) DeleteTemplateImage(ctx context.Context, imageKey string) error {
-	return s.deleteImage(ctx, imageKey)
+func (s *UploadThingImageStore) Get(ctx context.Context, imageKey string) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.fileURL(imageKey), nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, "", ErrImageNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, "", fmt.Errorf("uploadthing download failed (%s)", resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, resp.Header.Get("Content-Type"), nil
 }
 
-func (s *UploadThingImageStore) DeleteLandingImage(ctx context.Context, imageKey string) error {
-	return s.deleteImage(ctx, imageKey)
+func (s *UploadThingImageStore) fileURL(fileKey string) string {
+	return fmt.Sprintf("https://%s.ufs.sh/f/%s", s.appID, url.PathEscape(fileKey))
 }
 
-func (s *UploadThingImageStore) deleteImage(ctx context.Context, imageKey string) error {
+func (s *UploadThingImageStore) Delete(ctx context.Context, imageKey string) error {
 	imageKey = strings.TrimSpace(imageKey)
 	if imageKey == "" {
 		return nil
@@ -3,18 +3,24 @@ package service
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"callflow/internal/domain/user"
 )
 
 // UserService provides user business logic
 type UserService struct {
-	userRepo user.Repository
+	userRepo                 user.Repository
+	requirePhoneVerification bool
 }
 
-// NewUserService creates a new user service instance
+// NewUserService creates a new user service instance.
+// With REQUIRE_PHONE_VERIFICATION=true, paid plans can only be given to verified phones.
 func NewUserService(userRepo user.Repository) *UserService {
-	return &UserService{userRepo: userRepo}
+	return &UserService{
+		userRepo:                 userRepo,
+		requirePhoneVerification: os.Getenv("REQUIRE_PHONE_VERIFICATION") == "true",
+	}
 }
 
 func (s *UserService) GetUser(ctx context.Context, id int64) (*user.User, error) {
@@ -40,6 +46,15 @@ func (s *UserService) UpdatePlan(ctx context.Context, id int64, plan string) err
 	default:
 		return fmt.Errorf("invalid plan: %s", plan)
 	}
+	if s.requirePhoneVerification && plan != user.PlanNone {
+		u, err := s.userRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !u.PhoneVerified {
+			return user.ErrPhoneNotVerified
+		}
+	}
 	return s.userRepo.UpdatePlan(ctx, id, plan)
 }
 
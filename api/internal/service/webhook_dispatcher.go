@@ -0,0 +1,146 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"callflow/internal/domain/webhook"
+)
+
+const (
+	webhookDispatchInterval = 5 * time.Second
+	webhookDispatchBatch    = 20
+	webhookRequestTimeout   = 10 * time.Second
+	// webhookLockDuration must outlast a batch of requests; a delivery whose
+	// worker died is picked up again once it passes.
+	webhookLockDuration = 5 * time.Minute
+)
+
+// WebhookDispatcher posts queued webhook deliveries and schedules retries
+type WebhookDispatcher struct {
+	webhookRepo webhook.Repository
+	client      *http.Client
+	stopCh      chan struct{}
+}
+
+// NewWebhookDispatcher creates a new webhook dispatcher
+func NewWebhookDispatcher(webhookRepo webhook.Repository) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		webhookRepo: webhookRepo,
+		client:      newWebhookClient(),
+		stopCh:      make(chan struct{}),
+	}
+}
+
+// Start runs the dispatch loop in a background goroutine
+func (d *WebhookDispatcher) Start() {
+	go d.loop()
+}
+
+// Stop stops the dispatch loop
+func (d *WebhookDispatcher) Stop() {
+	close(d.stopCh)
+}
+
+func (d *WebhookDispatcher) loop() {
+	ticker := time.NewTicker(webhookDispatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			d.dispatchDue()
+		case <-d.stopCh:
+			return
+		}
+	}
+}
+
+func (d *WebhookDispatcher) dispatchDue() {
+	ctx, cancel := context.WithTimeout(context.Background(), webhookLockDuration)
+	defer cancel()
+
+	deliveries, err := d.webhookRepo.ClaimDeliveries(ctx, time.Now().Add(webhookLockDuration), webhookDispatchBatch)
+	if err != nil {
+		log.Printf("webhook dispatcher: failed to claim deliveries: %v", err)
+		return
+	}
+
+	webhooks := make(map[int64]*webhook.Webhook)
+	for _, delivery := range deliveries {
+		w, ok := webhooks[delivery.WebhookID]
+		if !ok {
+			w, err = d.webhookRepo.GetForDelivery(ctx, delivery.WebhookID)
+			if err != nil && !errors.Is(err, webhook.ErrWebhookNotFound) {
+				log.Printf("webhook dispatcher: failed to load webhook %d: %v", delivery.WebhookID, err)
+				continue
+			}
+			webhooks[delivery.WebhookID] = w
+		}
+
+		attempt := d.deliver(ctx, w, delivery)
+		if attempt.Error == "" {
+			err = d.webhookRepo.CompleteDelivery(ctx, delivery.ID, attempt)
+		} else {
+			err = d.webhookRepo.FailDelivery(ctx, delivery.ID, attempt)
+		}
+		if err != nil {
+			log.Printf("webhook dispatcher: failed to record delivery %d: %v", delivery.ID, err)
+		}
+	}
+}
+
+// deliver posts one delivery. A 2xx response is a success; anything else is
+// retried until the delivery runs out of attempts.
+func (d *WebhookDispatcher) deliver(ctx context.Context, w *webhook.Webhook, delivery *webhook.Delivery) webhook.Attempt {
+	if w == nil || !w.Active {
+		return webhook.Attempt{Error: "webhook is disabled", Retryable: false}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return webhook.Attempt{Error: err.Error(), Retryable: false}
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "CallFlow-Webhooks/1.0")
+	req.Header.Set(webhook.HeaderEvent, delivery.EventType)
+	req.Header.Set(webhook.HeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(webhook.HeaderSignature, "t="+timestamp+",v1="+signWebhook(w.Secret, timestamp, delivery.Payload))
+
+	start := time.Now()
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return webhook.Attempt{Error: err.Error(), Duration: time.Since(start), Retryable: true}
+	}
+	// Only the status is kept; the body never reaches the delivery log.
+	resp.Body.Close()
+
+	attempt := webhook.Attempt{
+		ResponseStatus: resp.StatusCode,
+		Duration:       time.Since(start),
+		Retryable:      true,
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		attempt.Error = fmt.Sprintf("unexpected status %d", resp.StatusCode)
+	}
+	return attempt
+}
+
+// signWebhook returns the hex HMAC-SHA256 of "<timestamp>.<body>" keyed by the secret
+func signWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
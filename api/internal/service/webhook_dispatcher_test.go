@@ -0,0 +1,24 @@
+package service
+
+import "testing"
+
+func TestSignWebhook(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		body      string
+		want      string
+	}{
+		{"json body", "whsec_test", "1700000000", `{"event":"lead.created"}`, "fdd93c8881ba06421d5630e8b2384e748a68dbbd165fdfda6f3f2ac3c12f4583"},
+		{"empty body", "whsec_test", "1700000000", "", "5967f3c560522fa40cf2876ebc3c3a08551dd6959aaade3b413460591895bdcc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := signWebhook(tt.secret, tt.timestamp, []byte(tt.body)); got != tt.want {
+				t.Errorf("signWebhook() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
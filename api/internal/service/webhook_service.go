@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/url"
+	"time"
+
+	"callflow/internal/domain/webhook"
+)
+
+// WebhookService provides webhook subscription management and event emission
+type WebhookService struct {
+	webhookRepo webhook.Repository
+}
+
+// NewWebhookService creates a new webhook service instance
+func NewWebhookService(webhookRepo webhook.Repository) *WebhookService {
+	return &WebhookService{webhookRepo: webhookRepo}
+}
+
+func (s *WebhookService) Create(ctx context.Context, userID int64, data webhook.WebhookCreate) (*webhook.CreatedWebhook, error) {
+	if err := validateWebhook(ctx, data.URL, data.Events); err != nil {
+		return nil, err
+	}
+	if data.Secret == "" {
+		secret, err := randomToken(24)
+		if err != nil {
+			return nil, err
+		}
+		data.Secret = "whsec_" + secret
+	}
+
+	w, err := s.webhookRepo.Create(ctx, userID, data)
+	if err != nil {
+		return nil, err
+	}
+	return &webhook.CreatedWebhook{Webhook: w, Secret: w.Secret}, nil
+}
+
+func (s *WebhookService) Get(ctx context.Context, userID int64) ([]*webhook.Webhook, error) {
+	return s.webhookRepo.GetByUserID(ctx, userID)
+}
+
+func (s *WebhookService) Update(ctx context.Context, id, userID int64, data webhook.WebhookUpdate) (*webhook.Webhook, error) {
+	if err := validateWebhook(ctx, data.URL, data.Events); err != nil {
+		return nil, err
+	}
+	return s.webhookRepo.Update(ctx, id, userID, data)
+}
+
+func (s *WebhookService) Delete(ctx context.Context, id, userID int64) error {
+	return s.webhookRepo.Delete(ctx, id, userID)
+}
+
+func (s *WebhookService) GetDeliveries(ctx context.Context, id, userID int64) ([]*webhook.Delivery, error) {
+	if _, err := s.webhookRepo.GetByID(ctx, id, userID); err != nil {
+		return nil, err
+	}
+	return s.webhookRepo.GetDeliveries(ctx, id, webhook.MaxListedDeliveries)
+}
+
+func (s *WebhookService) Redeliver(ctx context.Context, id, deliveryID, userID int64) (*webhook.Delivery, error) {
+	if _, err := s.webhookRepo.GetByID(ctx, id, userID); err != nil {
+		return nil, err
+	}
+	d, err := s.webhookRepo.GetDelivery(ctx, deliveryID, id)
+	if err != nil {
+		return nil, err
+	}
+	// A redelivery is queued as a new delivery with the same event ID and body,
+	// so receivers can deduplicate and the original log entry is kept.
+	return s.webhookRepo.CreateDelivery(ctx, id, d.EventID, d.EventType, d.Payload)
+}
+
+func (s *WebhookService) Emit(ctx context.Context, userID int64, eventType string, data any) {
+	webhooks, err := s.webhookRepo.GetForEvent(ctx, userID, eventType)
+	if err != nil {
+		log.Printf("Failed to emit %s for user %d: %v", eventType, userID, err)
+		return
+	}
+	if len(webhooks) == 0 {
+		return
+	}
+
+	eventID, err := randomToken(16)
+	if err != nil {
+		log.Printf("Failed to emit %s for user %d: %v", eventType, userID, err)
+		return
+	}
+	payload, err := json.Marshal(webhook.Event{
+		ID:        "evt_" + eventID,
+		Type:      eventType,
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	})
+	if err != nil {
+		log.Printf("Failed to emit %s for user %d: %v", eventType, userID, err)
+		return
+	}
+
+	for _, w := range webhooks {
+		if _, err := s.webhookRepo.CreateDelivery(ctx, w.ID, "evt_"+eventID, eventType, payload); err != nil {
+			log.Printf("Failed to queue %s for webhook %d: %v", eventType, w.ID, err)
+		}
+	}
+}
+
+// validateWebhook checks the target URL, which must be http or https on a
+// public address, and the subscribed event types
+func validateWebhook(ctx context.Context, rawURL string, events []string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return webhook.ErrInvalidURL
+	}
+	for _, e := range events {
+		if !webhook.IsValidEventType(e) {
+			return webhook.ErrInvalidEventType
+		}
+	}
+	return checkWebhookHost(ctx, u.Hostname())
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+
+	"callflow/internal/domain/webhook"
+)
+
+// nonPublicPrefixes are special-purpose ranges that netip has no predicate for
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// isPublicIP reports whether addr is routable on the internet, so posting to
+// it cannot reach this server, its metadata service or a private network.
+func isPublicIP(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsUnspecified() || addr.IsLoopback() || addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() {
+		return false
+	}
+	for _, p := range nonPublicPrefixes {
+		if p.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkWebhookHost rejects hosts that are, or resolve to, a non-public address
+func checkWebhookHost(ctx context.Context, host string) error {
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if !isPublicIP(addr) {
+			return webhook.ErrPrivateURL
+		}
+		return nil
+	}
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil || len(addrs) == 0 {
+		return webhook.ErrInvalidURL
+	}
+	for _, addr := range addrs {
+		if !isPublicIP(addr) {
+			return webhook.ErrPrivateURL
+		}
+	}
+	return nil
+}
+
+// refusePrivateAddress is a net.Dialer Control hook. It sees the address
+// actually dialed, after DNS resolution, so a name that was public when the
+// webhook was saved cannot be rebound to an internal address.
+func refusePrivateAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil || !isPublicIP(addr) {
+		return fmt.Errorf("%w: %s", webhook.ErrPrivateURL, host)
+	}
+	return nil
+}
+
+// newWebhookClient returns an HTTP client that only connects to public
+// addresses, redirects included, and ignores proxy settings.
+func newWebhookClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: webhookRequestTimeout,
+		Control: refusePrivateAddress,
+	}
+	return &http.Client{
+		Timeout: webhookRequestTimeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: webhookRequestTimeout,
+			MaxIdleConns:        webhookDispatchBatch,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}
+}
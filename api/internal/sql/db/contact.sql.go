@@ -11,8 +11,34 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const getContactByPhone = `-- name: GetContactByPhone :one
+SELECT id, user_id, phone, name, created_at, last_called_at, last_call_direction, opted_out_at, tags FROM contacts WHERE user_id = $1 AND phone = $2
+`
+
+type GetContactByPhoneParams struct {
+	UserID int64  `json:"user_id"`
+	Phone  string `json:"phone"`
+}
+
+func (q *Queries) GetContactByPhone(ctx context.Context, arg GetContactByPhoneParams) (Contact, error) {
+	row := q.db.QueryRow(ctx, getContactByPhone, arg.UserID, arg.Phone)
+	var i Contact
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Phone,
+		&i.Name,
+		&i.CreatedAt,
+		&i.LastCalledAt,
+		&i.LastCallDirection,
+		&i.OptedOutAt,
+		&i.Tags,
+	)
+	return i, err
+}
+
 const getContactsByUserID = `-- name: GetContactsByUserID :many
-SELECT id, user_id, phone, name, created_at FROM contacts WHERE user_id = $1 ORDER BY created_at DESC
+SELECT id, user_id, phone, name, created_at, last_called_at, last_call_direction, opted_out_at, tags FROM contacts WHERE user_id = $1 ORDER BY created_at DESC
 `
 
 func (q *Queries) GetContactsByUserID(ctx context.Context, userID int64) ([]Contact, error) {
@@ -30,6 +56,10 @@ func (q *Queries) GetContactsByUserID(ctx context.Context, userID int64) ([]Cont
 			&i.Phone,
 			&i.Name,
 			&i.CreatedAt,
+			&i.LastCalledAt,
+			&i.LastCallDirection,
+			&i.OptedOutAt,
+			&i.Tags,
 		); err != nil {
 			return nil, err
 		}
@@ -41,12 +71,139 @@ func (q *Queries) GetContactsByUserID(ctx context.Context, userID int64) ([]Cont
 	return items, nil
 }
 
+const optOutContact = `-- name: OptOutContact :one
+INSERT INTO contacts (user_id, phone, opted_out_at)
+VALUES ($1, $2, NOW())
+ON CONFLICT (user_id, phone) DO UPDATE
+SET opted_out_at = COALESCE(contacts.opted_out_at, NOW())
+RETURNING id, user_id, phone, name, created_at, last_called_at, last_call_direction, opted_out_at, tags, (xmax = 0)::boolean AS created
+`
+
+type OptOutContactParams struct {
+	UserID int64  `json:"user_id"`
+	Phone  string `json:"phone"`
+}
+
+type OptOutContactRow struct {
+	ID                int64              `json:"id"`
+	UserID            int64              `json:"user_id"`
+	Phone             string             `json:"phone"`
+	Name              pgtype.Text        `json:"name"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	LastCalledAt      pgtype.Timestamptz `json:"last_called_at"`
+	LastCallDirection pgtype.Text        `json:"last_call_direction"`
+	OptedOutAt        pgtype.Timestamptz `json:"opted_out_at"`
+	Tags              []string           `json:"tags"`
+	Created           bool               `json:"created"`
+}
+
+func (q *Queries) OptOutContact(ctx context.Context, arg OptOutContactParams) (OptOutContactRow, error) {
+	row := q.db.QueryRow(ctx, optOutContact, arg.UserID, arg.Phone)
+	var i OptOutContactRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Phone,
+		&i.Name,
+		&i.CreatedAt,
+		&i.LastCalledAt,
+		&i.LastCallDirection,
+		&i.OptedOutAt,
+		&i.Tags,
+		&i.Created,
+	)
+	return i, err
+}
+
+const recordContactCall = `-- name: RecordContactCall :one
+INSERT INTO contacts (user_id, phone, name, last_called_at, last_call_direction)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (user_id, phone) DO UPDATE
+SET name = COALESCE(EXCLUDED.name, contacts.name),
+    last_called_at = EXCLUDED.last_called_at,
+    last_call_direction = EXCLUDED.last_call_direction
+RETURNING id, user_id, phone, name, created_at, last_called_at, last_call_direction, opted_out_at, tags, (xmax = 0)::boolean AS created
+`
+
+type RecordContactCallParams struct {
+	UserID            int64              `json:"user_id"`
+	Phone             string             `json:"phone"`
+	Name              pgtype.Text        `json:"name"`
+	LastCalledAt      pgtype.Timestamptz `json:"last_called_at"`
+	LastCallDirection pgtype.Text        `json:"last_call_direction"`
+}
+
+type RecordContactCallRow struct {
+	ID                int64              `json:"id"`
+	UserID            int64              `json:"user_id"`
+	Phone             string             `json:"phone"`
+	Name              pgtype.Text        `json:"name"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	LastCalledAt      pgtype.Timestamptz `json:"last_called_at"`
+	LastCallDirection pgtype.Text        `json:"last_call_direction"`
+	OptedOutAt        pgtype.Timestamptz `json:"opted_out_at"`
+	Tags              []string           `json:"tags"`
+	Created           bool               `json:"created"`
+}
+
+func (q *Queries) RecordContactCall(ctx context.Context, arg RecordContactCallParams) (RecordContactCallRow, error) {
+	row := q.db.QueryRow(ctx, recordContactCall,
+		arg.UserID,
+		arg.Phone,
+		arg.Name,
+		arg.LastCalledAt,
+		arg.LastCallDirection,
+	)
+	var i RecordContactCallRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Phone,
+		&i.Name,
+		&i.CreatedAt,
+		&i.LastCalledAt,
+		&i.LastCallDirection,
+		&i.OptedOutAt,
+		&i.Tags,
+		&i.Created,
+	)
+	return i, err
+}
+
+const setContactTags = `-- name: SetContactTags :one
+UPDATE contacts SET tags = $3 WHERE id = $1 AND user_id = $2
+RETURNING id, user_id, phone, name, created_at, last_called_at, last_call_direction, opted_out_at, tags
+`
+
+type SetContactTagsParams struct {
+	ID     int64    `json:"id"`
+	UserID int64    `json:"user_id"`
+	Tags   []string `json:"tags"`
+}
+
+func (q *Queries) SetContactTags(ctx context.Context, arg SetContactTagsParams) (Contact, error) {
+	row := q.db.QueryRow(ctx, setContactTags, arg.ID, arg.UserID, arg.Tags)
+	var i Contact
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Phone,
+		&i.Name,
+		&i.CreatedAt,
+		&i.LastCalledAt,
+		&i.LastCallDirection,
+		&i.OptedOutAt,
+		&i.Tags,
+	)
+	return i, err
+}
+
 const upsertContact = `-- name: UpsertContact :one
 INSERT INTO contacts (user_id, phone, name)
 VALUES ($1, $2, $3)
 ON CONFLICT (user_id, phone) DO UPDATE
-SET name = EXCLUDED.name
-RETURNING id, user_id, phone, name, created_at
+SET name = COALESCE(EXCLUDED.name, contacts.name)
+RETURNING id, user_id, phone, name, created_at, last_called_at, last_call_direction, opted_out_at, tags, (xmax = 0)::boolean AS created
 `
 
 type UpsertContactParams struct {
@@ -55,33 +212,92 @@ type UpsertContactParams struct {
 	Name   pgtype.Text `json:"name"`
 }
 
-func (q *Queries) UpsertContact(ctx context.Context, arg UpsertContactParams) (Contact, error) {
+type UpsertContactRow struct {
+	ID                int64              `json:"id"`
+	UserID            int64              `json:"user_id"`
+	Phone             string             `json:"phone"`
+	Name              pgtype.Text        `json:"name"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	LastCalledAt      pgtype.Timestamptz `json:"last_called_at"`
+	LastCallDirection pgtype.Text        `json:"last_call_direction"`
+	OptedOutAt        pgtype.Timestamptz `json:"opted_out_at"`
+	Tags              []string           `json:"tags"`
+	Created           bool               `json:"created"`
+}
+
+func (q *Queries) UpsertContact(ctx context.Context, arg UpsertContactParams) (UpsertContactRow, error) {
 	row := q.db.QueryRow(ctx, upsertContact, arg.UserID, arg.Phone, arg.Name)
-	var i Contact
+	var i UpsertContactRow
 	err := row.Scan(
 		&i.ID,
 		&i.UserID,
 		&i.Phone,
 		&i.Name,
 		&i.CreatedAt,
+		&i.LastCalledAt,
+		&i.LastCallDirection,
+		&i.OptedOutAt,
+		&i.Tags,
+		&i.Created,
 	)
 	return i, err
 }
 
-const upsertContactBatch = `-- name: UpsertContactBatch :exec
+const upsertContactBatch = `-- name: UpsertContactBatch :many
 INSERT INTO contacts (user_id, phone, name)
-VALUES ($1, $2, $3)
+SELECT $1::bigint, c.phone, NULLIF(c.name, '')
+FROM unnest($2::text[], $3::text[]) AS c(phone, name)
 ON CONFLICT (user_id, phone) DO UPDATE
 SET name = EXCLUDED.name
+RETURNING id, user_id, phone, name, created_at, last_called_at, last_call_direction, opted_out_at, tags, (xmax = 0)::boolean AS created
 `
 
 type UpsertContactBatchParams struct {
-	UserID int64       `json:"user_id"`
-	Phone  string      `json:"phone"`
-	Name   pgtype.Text `json:"name"`
+	UserID int64    `json:"user_id"`
+	Phones []string `json:"phones"`
+	Names  []string `json:"names"`
+}
+
+type UpsertContactBatchRow struct {
+	ID                int64              `json:"id"`
+	UserID            int64              `json:"user_id"`
+	Phone             string             `json:"phone"`
+	Name              pgtype.Text        `json:"name"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	LastCalledAt      pgtype.Timestamptz `json:"last_called_at"`
+	LastCallDirection pgtype.Text        `json:"last_call_direction"`
+	OptedOutAt        pgtype.Timestamptz `json:"opted_out_at"`
+	Tags              []string           `json:"tags"`
+	Created           bool               `json:"created"`
 }
 
-func (q *Queries) UpsertContactBatch(ctx context.Context, arg UpsertContactBatchParams) error {
-	_, err := q.db.Exec(ctx, upsertContactBatch, arg.UserID, arg.Phone, arg.Name)
-	return err
+func (q *Queries) UpsertContactBatch(ctx context.Context, arg UpsertContactBatchParams) ([]UpsertContactBatchRow, error) {
+	rows, err := q.db.Query(ctx, upsertContactBatch, arg.UserID, arg.Phones, arg.Names)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UpsertContactBatchRow{}
+	for rows.Next() {
+		var i UpsertContactBatchRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Phone,
+			&i.Name,
+			&i.CreatedAt,
+			&i.LastCalledAt,
+			&i.LastCallDirection,
+			&i.OptedOutAt,
+			&i.Tags,
+			&i.Created,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
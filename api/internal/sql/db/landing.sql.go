@@ -11,8 +11,157 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const clearCurrentLandingSlug = `-- name: ClearCurrentLandingSlug :exec
+UPDATE landing_slugs
+SET is_current = FALSE,
+    updated_at = NOW()
+WHERE user_id = $1 AND is_current
+`
+
+func (q *Queries) ClearCurrentLandingSlug(ctx context.Context, userID int64) error {
+	_, err := q.db.Exec(ctx, clearCurrentLandingSlug, userID)
+	return err
+}
+
+const createLandingLink = `-- name: CreateLandingLink :one
+INSERT INTO landing_links (user_id, token, source, ref_id, phone)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (source, ref_id) WHERE ref_id IS NOT NULL DO UPDATE
+SET phone = COALESCE(EXCLUDED.phone, landing_links.phone)
+RETURNING id, user_id, token, source, ref_id, phone, views, clicks, first_visit_at, last_visit_at, created_at
+`
+
+type CreateLandingLinkParams struct {
+	UserID int64       `json:"user_id"`
+	Token  string      `json:"token"`
+	Source string      `json:"source"`
+	RefID  pgtype.Int8 `json:"ref_id"`
+	Phone  pgtype.Text `json:"phone"`
+}
+
+func (q *Queries) CreateLandingLink(ctx context.Context, arg CreateLandingLinkParams) (LandingLink, error) {
+	row := q.db.QueryRow(ctx, createLandingLink,
+		arg.UserID,
+		arg.Token,
+		arg.Source,
+		arg.RefID,
+		arg.Phone,
+	)
+	var i LandingLink
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Token,
+		&i.Source,
+		&i.RefID,
+		&i.Phone,
+		&i.Views,
+		&i.Clicks,
+		&i.FirstVisitAt,
+		&i.LastVisitAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createLandingRevision = `-- name: CreateLandingRevision :one
+INSERT INTO landing_revisions (
+  user_id,
+  number,
+  headline,
+  description,
+  image_url,
+  image_key,
+  media_id,
+  whatsapp_url,
+  facebook_url,
+  instagram_url,
+  youtube_url,
+  email,
+  website_url,
+  sections,
+  sections_version,
+  gallery_media_ids
+)
+SELECT l.user_id,
+    COALESCE((SELECT MAX(r.number) FROM landing_revisions r WHERE r.user_id = l.user_id), 0) + 1,
+    l.headline,
+    l.description,
+    l.image_url,
+    l.image_key,
+    l.media_id,
+    l.whatsapp_url,
+    l.facebook_url,
+    l.instagram_url,
+    l.youtube_url,
+    l.email,
+    l.website_url,
+    l.sections,
+    l.sections_version,
+    l.gallery_media_ids
+FROM landing_pages l
+WHERE l.user_id = $1
+RETURNING id, user_id, number, headline, description, image_url, image_key, media_id, whatsapp_url, facebook_url, instagram_url, youtube_url, email, website_url, sections, sections_version, published_at, gallery_media_ids
+`
+
+// Copies the live page as the user's next revision.
+func (q *Queries) CreateLandingRevision(ctx context.Context, userID int64) (LandingRevision, error) {
+	row := q.db.QueryRow(ctx, createLandingRevision, userID)
+	var i LandingRevision
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Number,
+		&i.Headline,
+		&i.Description,
+		&i.ImageUrl,
+		&i.ImageKey,
+		&i.MediaID,
+		&i.WhatsappUrl,
+		&i.FacebookUrl,
+		&i.InstagramUrl,
+		&i.YoutubeUrl,
+		&i.Email,
+		&i.WebsiteUrl,
+		&i.Sections,
+		&i.SectionsVersion,
+		&i.PublishedAt,
+		&i.GalleryMediaIds,
+	)
+	return i, err
+}
+
+const deleteLandingDraft = `-- name: DeleteLandingDraft :execrows
+DELETE FROM landing_drafts WHERE user_id = $1
+`
+
+func (q *Queries) DeleteLandingDraft(ctx context.Context, userID int64) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteLandingDraft, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getCurrentLandingSlug = `-- name: GetCurrentLandingSlug :one
+SELECT slug, user_id, is_current, created_at, updated_at FROM landing_slugs WHERE user_id = $1 AND is_current
+`
+
+func (q *Queries) GetCurrentLandingSlug(ctx context.Context, userID int64) (LandingSlug, error) {
+	row := q.db.QueryRow(ctx, getCurrentLandingSlug, userID)
+	var i LandingSlug
+	err := row.Scan(
+		&i.Slug,
+		&i.UserID,
+		&i.IsCurrent,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const getLandingByUserID = `-- name: GetLandingByUserID :one
-SELECT id, user_id, headline, description, image_url, image_key, whatsapp_url, facebook_url, instagram_url, youtube_url, email, website_url, created_at, updated_at FROM landing_pages WHERE user_id = $1
+SELECT id, user_id, headline, description, image_url, image_key, whatsapp_url, facebook_url, instagram_url, youtube_url, email, website_url, created_at, updated_at, media_id, sections, sections_version, gallery_media_ids FROM landing_pages WHERE user_id = $1
 `
 
 func (q *Queries) GetLandingByUserID(ctx context.Context, userID int64) (LandingPage, error) {
@@ -33,6 +182,431 @@ func (q *Queries) GetLandingByUserID(ctx context.Context, userID int64) (Landing
 		&i.WebsiteUrl,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MediaID,
+		&i.Sections,
+		&i.SectionsVersion,
+		&i.GalleryMediaIds,
+	)
+	return i, err
+}
+
+const getLandingDraftByUserID = `-- name: GetLandingDraftByUserID :one
+SELECT id, user_id, headline, description, image_url, image_key, media_id, whatsapp_url, facebook_url, instagram_url, youtube_url, email, website_url, sections, sections_version, gallery_media_ids, preview_token, created_at, updated_at FROM landing_drafts WHERE user_id = $1
+`
+
+func (q *Queries) GetLandingDraftByUserID(ctx context.Context, userID int64) (LandingDraft, error) {
+	row := q.db.QueryRow(ctx, getLandingDraftByUserID, userID)
+	var i LandingDraft
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Headline,
+		&i.Description,
+		&i.ImageUrl,
+		&i.ImageKey,
+		&i.MediaID,
+		&i.WhatsappUrl,
+		&i.FacebookUrl,
+		&i.InstagramUrl,
+		&i.YoutubeUrl,
+		&i.Email,
+		&i.WebsiteUrl,
+		&i.Sections,
+		&i.SectionsVersion,
+		&i.GalleryMediaIds,
+		&i.PreviewToken,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getLandingRevisionByID = `-- name: GetLandingRevisionByID :one
+SELECT id, user_id, number, headline, description, image_url, image_key, media_id, whatsapp_url, facebook_url, instagram_url, youtube_url, email, website_url, sections, sections_version, published_at, gallery_media_ids FROM landing_revisions WHERE id = $1 AND user_id = $2
+`
+
+type GetLandingRevisionByIDParams struct {
+	ID     int64 `json:"id"`
+	UserID int64 `json:"user_id"`
+}
+
+func (q *Queries) GetLandingRevisionByID(ctx context.Context, arg GetLandingRevisionByIDParams) (LandingRevision, error) {
+	row := q.db.QueryRow(ctx, getLandingRevisionByID, arg.ID, arg.UserID)
+	var i LandingRevision
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Number,
+		&i.Headline,
+		&i.Description,
+		&i.ImageUrl,
+		&i.ImageKey,
+		&i.MediaID,
+		&i.WhatsappUrl,
+		&i.FacebookUrl,
+		&i.InstagramUrl,
+		&i.YoutubeUrl,
+		&i.Email,
+		&i.WebsiteUrl,
+		&i.Sections,
+		&i.SectionsVersion,
+		&i.PublishedAt,
+		&i.GalleryMediaIds,
+	)
+	return i, err
+}
+
+const getLandingSlug = `-- name: GetLandingSlug :one
+SELECT slug, user_id, is_current, created_at, updated_at FROM landing_slugs WHERE slug = $1
+`
+
+func (q *Queries) GetLandingSlug(ctx context.Context, slug string) (LandingSlug, error) {
+	row := q.db.QueryRow(ctx, getLandingSlug, slug)
+	var i LandingSlug
+	err := row.Scan(
+		&i.Slug,
+		&i.UserID,
+		&i.IsCurrent,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const incrementLandingDailyStat = `-- name: IncrementLandingDailyStat :exec
+INSERT INTO landing_daily_stats (user_id, day, event, target, attributed, count)
+VALUES ($1, $2, $3, $4, $5, 1)
+ON CONFLICT (user_id, day, event, target, attributed) DO UPDATE
+SET count = landing_daily_stats.count + 1
+`
+
+type IncrementLandingDailyStatParams struct {
+	UserID     int64       `json:"user_id"`
+	Day        pgtype.Date `json:"day"`
+	Event      string      `json:"event"`
+	Target     string      `json:"target"`
+	Attributed bool        `json:"attributed"`
+}
+
+func (q *Queries) IncrementLandingDailyStat(ctx context.Context, arg IncrementLandingDailyStatParams) error {
+	_, err := q.db.Exec(ctx, incrementLandingDailyStat,
+		arg.UserID,
+		arg.Day,
+		arg.Event,
+		arg.Target,
+		arg.Attributed,
+	)
+	return err
+}
+
+const listLandingDailyStats = `-- name: ListLandingDailyStats :many
+SELECT user_id, day, event, target, attributed, count FROM landing_daily_stats
+WHERE user_id = $1 AND day >= $2::date AND day <= $3::date
+ORDER BY day, event, target
+`
+
+type ListLandingDailyStatsParams struct {
+	UserID  int64       `json:"user_id"`
+	FromDay pgtype.Date `json:"from_day"`
+	ToDay   pgtype.Date `json:"to_day"`
+}
+
+func (q *Queries) ListLandingDailyStats(ctx context.Context, arg ListLandingDailyStatsParams) ([]LandingDailyStat, error) {
+	rows, err := q.db.Query(ctx, listLandingDailyStats, arg.UserID, arg.FromDay, arg.ToDay)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []LandingDailyStat{}
+	for rows.Next() {
+		var i LandingDailyStat
+		if err := rows.Scan(
+			&i.UserID,
+			&i.Day,
+			&i.Event,
+			&i.Target,
+			&i.Attributed,
+			&i.Count,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLandingRevisionsByUserID = `-- name: ListLandingRevisionsByUserID :many
+SELECT id, user_id, number, headline, description, image_url, image_key, media_id, whatsapp_url, facebook_url, instagram_url, youtube_url, email, website_url, sections, sections_version, published_at, gallery_media_ids FROM landing_revisions
+WHERE user_id = $1
+ORDER BY number DESC
+LIMIT $2
+`
+
+type ListLandingRevisionsByUserIDParams struct {
+	UserID int64 `json:"user_id"`
+	Limit  int32 `json:"limit"`
+}
+
+func (q *Queries) ListLandingRevisionsByUserID(ctx context.Context, arg ListLandingRevisionsByUserIDParams) ([]LandingRevision, error) {
+	rows, err := q.db.Query(ctx, listLandingRevisionsByUserID, arg.UserID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []LandingRevision{}
+	for rows.Next() {
+		var i LandingRevision
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Number,
+			&i.Headline,
+			&i.Description,
+			&i.ImageUrl,
+			&i.ImageKey,
+			&i.MediaID,
+			&i.WhatsappUrl,
+			&i.FacebookUrl,
+			&i.InstagramUrl,
+			&i.YoutubeUrl,
+			&i.Email,
+			&i.WebsiteUrl,
+			&i.Sections,
+			&i.SectionsVersion,
+			&i.PublishedAt,
+			&i.GalleryMediaIds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLandingsWithImage = `-- name: ListLandingsWithImage :many
+SELECT id, user_id, headline, description, image_url, image_key, whatsapp_url, facebook_url, instagram_url, youtube_url, email, website_url, created_at, updated_at, media_id, sections, sections_version, gallery_media_ids FROM landing_pages WHERE image_key IS NOT NULL ORDER BY id
+`
+
+func (q *Queries) ListLandingsWithImage(ctx context.Context) ([]LandingPage, error) {
+	rows, err := q.db.Query(ctx, listLandingsWithImage)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []LandingPage{}
+	for rows.Next() {
+		var i LandingPage
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Headline,
+			&i.Description,
+			&i.ImageUrl,
+			&i.ImageKey,
+			&i.WhatsappUrl,
+			&i.FacebookUrl,
+			&i.InstagramUrl,
+			&i.YoutubeUrl,
+			&i.Email,
+			&i.WebsiteUrl,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.MediaID,
+			&i.Sections,
+			&i.SectionsVersion,
+			&i.GalleryMediaIds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listVisitedLandingLinks = `-- name: ListVisitedLandingLinks :many
+SELECT id, user_id, token, source, ref_id, phone, views, clicks, first_visit_at, last_visit_at, created_at FROM landing_links
+WHERE user_id = $1 AND last_visit_at >= $2::timestamptz
+ORDER BY last_visit_at DESC
+LIMIT $3::int
+`
+
+type ListVisitedLandingLinksParams struct {
+	UserID       int64              `json:"user_id"`
+	VisitedSince pgtype.Timestamptz `json:"visited_since"`
+	MaxLinks     int32              `json:"max_links"`
+}
+
+func (q *Queries) ListVisitedLandingLinks(ctx context.Context, arg ListVisitedLandingLinksParams) ([]LandingLink, error) {
+	rows, err := q.db.Query(ctx, listVisitedLandingLinks, arg.UserID, arg.VisitedSince, arg.MaxLinks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []LandingLink{}
+	for rows.Next() {
+		var i LandingLink
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Token,
+			&i.Source,
+			&i.RefID,
+			&i.Phone,
+			&i.Views,
+			&i.Clicks,
+			&i.FirstVisitAt,
+			&i.LastVisitAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const pruneLandingRevisions = `-- name: PruneLandingRevisions :exec
+DELETE FROM landing_revisions
+WHERE user_id = $1 AND number <= $2::int - $3::int
+`
+
+type PruneLandingRevisionsParams struct {
+	UserID       int64 `json:"user_id"`
+	NewestNumber int32 `json:"newest_number"`
+	Keep         int32 `json:"keep"`
+}
+
+func (q *Queries) PruneLandingRevisions(ctx context.Context, arg PruneLandingRevisionsParams) error {
+	_, err := q.db.Exec(ctx, pruneLandingRevisions, arg.UserID, arg.NewestNumber, arg.Keep)
+	return err
+}
+
+const recordLandingLinkVisit = `-- name: RecordLandingLinkVisit :execrows
+UPDATE landing_links
+SET views = views + CASE WHEN $1::text = 'view' THEN 1 ELSE 0 END,
+    clicks = clicks + CASE WHEN $1::text = 'click' THEN 1 ELSE 0 END,
+    first_visit_at = COALESCE(first_visit_at, NOW()),
+    last_visit_at = NOW()
+WHERE token = $2 AND user_id = $3
+`
+
+type RecordLandingLinkVisitParams struct {
+	Event  string `json:"event"`
+	Token  string `json:"token"`
+	UserID int64  `json:"user_id"`
+}
+
+func (q *Queries) RecordLandingLinkVisit(ctx context.Context, arg RecordLandingLinkVisitParams) (int64, error) {
+	result, err := q.db.Exec(ctx, recordLandingLinkVisit, arg.Event, arg.Token, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const replaceLandingImage = `-- name: ReplaceLandingImage :execrows
+UPDATE landing_pages
+SET image_url = $1,
+    image_key = $2
+WHERE user_id = $3 AND image_key = $4
+`
+
+type ReplaceLandingImageParams struct {
+	ImageUrl    pgtype.Text `json:"image_url"`
+	ImageKey    pgtype.Text `json:"image_key"`
+	UserID      int64       `json:"user_id"`
+	OldImageKey pgtype.Text `json:"old_image_key"`
+}
+
+func (q *Queries) ReplaceLandingImage(ctx context.Context, arg ReplaceLandingImageParams) (int64, error) {
+	result, err := q.db.Exec(ctx, replaceLandingImage,
+		arg.ImageUrl,
+		arg.ImageKey,
+		arg.UserID,
+		arg.OldImageKey,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setCurrentLandingSlug = `-- name: SetCurrentLandingSlug :one
+INSERT INTO landing_slugs (slug, user_id, is_current)
+VALUES ($1, $2, TRUE)
+ON CONFLICT (slug) DO UPDATE
+SET is_current = TRUE,
+    updated_at = NOW()
+WHERE landing_slugs.user_id = EXCLUDED.user_id
+RETURNING slug, user_id, is_current, created_at, updated_at
+`
+
+type SetCurrentLandingSlugParams struct {
+	Slug   string `json:"slug"`
+	UserID int64  `json:"user_id"`
+}
+
+func (q *Queries) SetCurrentLandingSlug(ctx context.Context, arg SetCurrentLandingSlugParams) (LandingSlug, error) {
+	row := q.db.QueryRow(ctx, setCurrentLandingSlug, arg.Slug, arg.UserID)
+	var i LandingSlug
+	err := row.Scan(
+		&i.Slug,
+		&i.UserID,
+		&i.IsCurrent,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const setLandingDraftPreviewToken = `-- name: SetLandingDraftPreviewToken :one
+UPDATE landing_drafts
+SET preview_token = $2,
+    updated_at = NOW()
+WHERE user_id = $1
+RETURNING id, user_id, headline, description, image_url, image_key, media_id, whatsapp_url, facebook_url, instagram_url, youtube_url, email, website_url, sections, sections_version, gallery_media_ids, preview_token, created_at, updated_at
+`
+
+type SetLandingDraftPreviewTokenParams struct {
+	UserID       int64  `json:"user_id"`
+	PreviewToken string `json:"preview_token"`
+}
+
+func (q *Queries) SetLandingDraftPreviewToken(ctx context.Context, arg SetLandingDraftPreviewTokenParams) (LandingDraft, error) {
+	row := q.db.QueryRow(ctx, setLandingDraftPreviewToken, arg.UserID, arg.PreviewToken)
+	var i LandingDraft
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Headline,
+		&i.Description,
+		&i.ImageUrl,
+		&i.ImageKey,
+		&i.MediaID,
+		&i.WhatsappUrl,
+		&i.FacebookUrl,
+		&i.InstagramUrl,
+		&i.YoutubeUrl,
+		&i.Email,
+		&i.WebsiteUrl,
+		&i.Sections,
+		&i.SectionsVersion,
+		&i.GalleryMediaIds,
+		&i.PreviewToken,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
@@ -49,9 +623,13 @@ INSERT INTO landing_pages (
   instagram_url,
   youtube_url,
   email,
-  website_url
+  website_url,
+  media_id,
+  sections,
+  sections_version,
+  gallery_media_ids
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
 )
 ON CONFLICT (user_id) DO UPDATE
 SET headline = EXCLUDED.headline,
@@ -64,22 +642,30 @@ SET headline = EXCLUDED.headline,
     youtube_url = EXCLUDED.youtube_url,
     email = EXCLUDED.email,
     website_url = EXCLUDED.website_url,
+    media_id = EXCLUDED.media_id,
+    sections = EXCLUDED.sections,
+    sections_version = EXCLUDED.sections_version,
+    gallery_media_ids = EXCLUDED.gallery_media_ids,
     updated_at = NOW()
-RETURNING id, user_id, headline, description, image_url, image_key, whatsapp_url, facebook_url, instagram_url, youtube_url, email, website_url, created_at, updated_at
+RETURNING id, user_id, headline, description, image_url, image_key, whatsapp_url, facebook_url, instagram_url, youtube_url, email, website_url, created_at, updated_at, media_id, sections, sections_version, gallery_media_ids
 `
 
 type UpsertLandingByUserIDParams struct {
-	UserID       int64       `json:"user_id"`
-	Headline     pgtype.Text `json:"headline"`
-	Description  pgtype.Text `json:"description"`
-	ImageUrl     pgtype.Text `json:"image_url"`
-	ImageKey     pgtype.Text `json:"image_key"`
-	WhatsappUrl  pgtype.Text `json:"whatsapp_url"`
-	FacebookUrl  pgtype.Text `json:"facebook_url"`
-	InstagramUrl pgtype.Text `json:"instagram_url"`
-	YoutubeUrl   pgtype.Text `json:"youtube_url"`
-	Email        pgtype.Text `json:"email"`
-	WebsiteUrl   pgtype.Text `json:"website_url"`
+	UserID          int64       `json:"user_id"`
+	Headline        pgtype.Text `json:"headline"`
+	Description     pgtype.Text `json:"description"`
+	ImageUrl        pgtype.Text `json:"image_url"`
+	ImageKey        pgtype.Text `json:"image_key"`
+	WhatsappUrl     pgtype.Text `json:"whatsapp_url"`
+	FacebookUrl     pgtype.Text `json:"facebook_url"`
+	InstagramUrl    pgtype.Text `json:"instagram_url"`
+	YoutubeUrl      pgtype.Text `json:"youtube_url"`
+	Email           pgtype.Text `json:"email"`
+	WebsiteUrl      pgtype.Text `json:"website_url"`
+	MediaID         pgtype.Int8 `json:"media_id"`
+	Sections        []byte      `json:"sections"`
+	SectionsVersion int32       `json:"sections_version"`
+	GalleryMediaIds []int64     `json:"gallery_media_ids"`
 }
 
 func (q *Queries) UpsertLandingByUserID(ctx context.Context, arg UpsertLandingByUserIDParams) (LandingPage, error) {
@@ -95,6 +681,10 @@ func (q *Queries) UpsertLandingByUserID(ctx context.Context, arg UpsertLandingBy
 		arg.YoutubeUrl,
 		arg.Email,
 		arg.WebsiteUrl,
+		arg.MediaID,
+		arg.Sections,
+		arg.SectionsVersion,
+		arg.GalleryMediaIds,
 	)
 	var i LandingPage
 	err := row.Scan(
@@ -112,6 +702,114 @@ func (q *Queries) UpsertLandingByUserID(ctx context.Context, arg UpsertLandingBy
 		&i.WebsiteUrl,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MediaID,
+		&i.Sections,
+		&i.SectionsVersion,
+		&i.GalleryMediaIds,
+	)
+	return i, err
+}
+
+const upsertLandingDraftByUserID = `-- name: UpsertLandingDraftByUserID :one
+INSERT INTO landing_drafts (
+  user_id,
+  headline,
+  description,
+  image_url,
+  image_key,
+  whatsapp_url,
+  facebook_url,
+  instagram_url,
+  youtube_url,
+  email,
+  website_url,
+  media_id,
+  sections,
+  sections_version,
+  gallery_media_ids,
+  preview_token
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
+)
+ON CONFLICT (user_id) DO UPDATE
+SET headline = EXCLUDED.headline,
+    description = EXCLUDED.description,
+    image_url = EXCLUDED.image_url,
+    image_key = EXCLUDED.image_key,
+    whatsapp_url = EXCLUDED.whatsapp_url,
+    facebook_url = EXCLUDED.facebook_url,
+    instagram_url = EXCLUDED.instagram_url,
+    youtube_url = EXCLUDED.youtube_url,
+    email = EXCLUDED.email,
+    website_url = EXCLUDED.website_url,
+    media_id = EXCLUDED.media_id,
+    sections = EXCLUDED.sections,
+    sections_version = EXCLUDED.sections_version,
+    gallery_media_ids = EXCLUDED.gallery_media_ids,
+    updated_at = NOW()
+RETURNING id, user_id, headline, description, image_url, image_key, media_id, whatsapp_url, facebook_url, instagram_url, youtube_url, email, website_url, sections, sections_version, gallery_media_ids, preview_token, created_at, updated_at
+`
+
+type UpsertLandingDraftByUserIDParams struct {
+	UserID          int64       `json:"user_id"`
+	Headline        pgtype.Text `json:"headline"`
+	Description     pgtype.Text `json:"description"`
+	ImageUrl        pgtype.Text `json:"image_url"`
+	ImageKey        pgtype.Text `json:"image_key"`
+	WhatsappUrl     pgtype.Text `json:"whatsapp_url"`
+	FacebookUrl     pgtype.Text `json:"facebook_url"`
+	InstagramUrl    pgtype.Text `json:"instagram_url"`
+	YoutubeUrl      pgtype.Text `json:"youtube_url"`
+	Email           pgtype.Text `json:"email"`
+	WebsiteUrl      pgtype.Text `json:"website_url"`
+	MediaID         pgtype.Int8 `json:"media_id"`
+	Sections        []byte      `json:"sections"`
+	SectionsVersion int32       `json:"sections_version"`
+	GalleryMediaIds []int64     `json:"gallery_media_ids"`
+	PreviewToken    string      `json:"preview_token"`
+}
+
+// An existing draft keeps its preview token.
+func (q *Queries) UpsertLandingDraftByUserID(ctx context.Context, arg UpsertLandingDraftByUserIDParams) (LandingDraft, error) {
+	row := q.db.QueryRow(ctx, upsertLandingDraftByUserID,
+		arg.UserID,
+		arg.Headline,
+		arg.Description,
+		arg.ImageUrl,
+		arg.ImageKey,
+		arg.WhatsappUrl,
+		arg.FacebookUrl,
+		arg.InstagramUrl,
+		arg.YoutubeUrl,
+		arg.Email,
+		arg.WebsiteUrl,
+		arg.MediaID,
+		arg.Sections,
+		arg.SectionsVersion,
+		arg.GalleryMediaIds,
+		arg.PreviewToken,
+	)
+	var i LandingDraft
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Headline,
+		&i.Description,
+		&i.ImageUrl,
+		&i.ImageKey,
+		&i.MediaID,
+		&i.WhatsappUrl,
+		&i.FacebookUrl,
+		&i.InstagramUrl,
+		&i.YoutubeUrl,
+		&i.Email,
+		&i.WebsiteUrl,
+		&i.Sections,
+		&i.SectionsVersion,
+		&i.GalleryMediaIds,
+		&i.PreviewToken,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
@@ -8,31 +8,325 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type ApiKey struct {
+	ID         int64              `json:"id"`
+	UserID     int64              `json:"user_id"`
+	Name       string             `json:"name"`
+	Prefix     string             `json:"prefix"`
+	KeyHash    string             `json:"key_hash"`
+	Scopes     []string           `json:"scopes"`
+	ExpiresAt  pgtype.Timestamptz `json:"expires_at"`
+	LastUsedAt pgtype.Timestamptz `json:"last_used_at"`
+	RevokedAt  pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type Campaign struct {
+	ID                int64              `json:"id"`
+	UserID            int64              `json:"user_id"`
+	Name              string             `json:"name"`
+	TemplateID        int64              `json:"template_id"`
+	Segment           []byte             `json:"segment"`
+	ScheduledAt       pgtype.Timestamptz `json:"scheduled_at"`
+	ThrottlePerMinute int32              `json:"throttle_per_minute"`
+	Status            string             `json:"status"`
+	TotalRecipients   int32              `json:"total_recipients"`
+	StartedAt         pgtype.Timestamptz `json:"started_at"`
+	CompletedAt       pgtype.Timestamptz `json:"completed_at"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
+}
+
+type CampaignRecipient struct {
+	ID           int64              `json:"id"`
+	CampaignID   int64              `json:"campaign_id"`
+	ContactID    pgtype.Int8        `json:"contact_id"`
+	Phone        string             `json:"phone"`
+	Status       string             `json:"status"`
+	Attempts     int32              `json:"attempts"`
+	Error        pgtype.Text        `json:"error"`
+	DispatchedAt pgtype.Timestamptz `json:"dispatched_at"`
+	CompletedAt  pgtype.Timestamptz `json:"completed_at"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
 type Contact struct {
+	ID                int64              `json:"id"`
+	UserID            int64              `json:"user_id"`
+	Phone             string             `json:"phone"`
+	Name              pgtype.Text        `json:"name"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	LastCalledAt      pgtype.Timestamptz `json:"last_called_at"`
+	LastCallDirection pgtype.Text        `json:"last_call_direction"`
+	OptedOutAt        pgtype.Timestamptz `json:"opted_out_at"`
+	Tags              []string           `json:"tags"`
+}
+
+type Device struct {
+	ID              int64              `json:"id"`
+	UserID          int64              `json:"user_id"`
+	DeviceID        string             `json:"device_id"`
+	Name            pgtype.Text        `json:"name"`
+	Model           pgtype.Text        `json:"model"`
+	AppVersion      pgtype.Text        `json:"app_version"`
+	SimInfo         []byte             `json:"sim_info"`
+	PushToken       pgtype.Text        `json:"push_token"`
+	SmsLine         pgtype.Text        `json:"sms_line"`
+	LastSeenAt      pgtype.Timestamptz `json:"last_seen_at"`
+	RevokedAt       pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+	LastHeartbeatAt pgtype.Timestamptz `json:"last_heartbeat_at"`
+	Health          []byte             `json:"health"`
+	Healthy         bool               `json:"healthy"`
+}
+
+type DeviceAlert struct {
+	ID         int64              `json:"id"`
+	DeviceID   int64              `json:"device_id"`
+	UserID     int64              `json:"user_id"`
+	Kind       string             `json:"kind"`
+	Message    string             `json:"message"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	ResolvedAt pgtype.Timestamptz `json:"resolved_at"`
+}
+
+type DeviceHeartbeat struct {
+	ID               int64              `json:"id"`
+	DeviceID         int64              `json:"device_id"`
+	BatteryOptimized bool               `json:"battery_optimized"`
+	Permissions      []byte             `json:"permissions"`
+	AppVersion       pgtype.Text        `json:"app_version"`
+	QueueDepth       int32              `json:"queue_depth"`
+	LastCallAt       pgtype.Timestamptz `json:"last_call_at"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+}
+
+type DeviceJob struct {
+	ID             int64              `json:"id"`
+	UserID         int64              `json:"user_id"`
+	DeviceID       pgtype.Text        `json:"device_id"`
+	JobType        string             `json:"job_type"`
+	Payload        []byte             `json:"payload"`
+	Status         string             `json:"status"`
+	Attempts       int32              `json:"attempts"`
+	MaxAttempts    int32              `json:"max_attempts"`
+	RunAfter       pgtype.Timestamptz `json:"run_after"`
+	LeaseToken     pgtype.Text        `json:"lease_token"`
+	LeasedBy       pgtype.Text        `json:"leased_by"`
+	LeaseExpiresAt pgtype.Timestamptz `json:"lease_expires_at"`
+	LastError      pgtype.Text        `json:"last_error"`
+	CompletedAt    pgtype.Timestamptz `json:"completed_at"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	Source         pgtype.Text        `json:"source"`
+	RefID          pgtype.Int8        `json:"ref_id"`
+}
+
+type EnquiryReplyCount struct {
+	UserID int64       `json:"user_id"`
+	Day    pgtype.Date `json:"day"`
+	Count  int32       `json:"count"`
+}
+
+type ImageVariant struct {
+	ID          int64              `json:"id"`
+	ImageKey    string             `json:"image_key"`
+	Variant     string             `json:"variant"`
+	Key         string             `json:"key"`
+	Url         string             `json:"url"`
+	ContentType string             `json:"content_type"`
+	Width       int32              `json:"width"`
+	Height      int32              `json:"height"`
+	SizeBytes   int32              `json:"size_bytes"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type LandingDailyStat struct {
+	UserID     int64       `json:"user_id"`
+	Day        pgtype.Date `json:"day"`
+	Event      string      `json:"event"`
+	Target     string      `json:"target"`
+	Attributed bool        `json:"attributed"`
+	Count      int32       `json:"count"`
+}
+
+type LandingDraft struct {
+	ID              int64              `json:"id"`
+	UserID          int64              `json:"user_id"`
+	Headline        pgtype.Text        `json:"headline"`
+	Description     pgtype.Text        `json:"description"`
+	ImageUrl        pgtype.Text        `json:"image_url"`
+	ImageKey        pgtype.Text        `json:"image_key"`
+	MediaID         pgtype.Int8        `json:"media_id"`
+	WhatsappUrl     pgtype.Text        `json:"whatsapp_url"`
+	FacebookUrl     pgtype.Text        `json:"facebook_url"`
+	InstagramUrl    pgtype.Text        `json:"instagram_url"`
+	YoutubeUrl      pgtype.Text        `json:"youtube_url"`
+	Email           pgtype.Text        `json:"email"`
+	WebsiteUrl      pgtype.Text        `json:"website_url"`
+	Sections        []byte             `json:"sections"`
+	SectionsVersion int32              `json:"sections_version"`
+	GalleryMediaIds []int64            `json:"gallery_media_ids"`
+	PreviewToken    string             `json:"preview_token"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+}
+
+type LandingLink struct {
+	ID           int64              `json:"id"`
+	UserID       int64              `json:"user_id"`
+	Token        string             `json:"token"`
+	Source       string             `json:"source"`
+	RefID        pgtype.Int8        `json:"ref_id"`
+	Phone        pgtype.Text        `json:"phone"`
+	Views        int32              `json:"views"`
+	Clicks       int32              `json:"clicks"`
+	FirstVisitAt pgtype.Timestamptz `json:"first_visit_at"`
+	LastVisitAt  pgtype.Timestamptz `json:"last_visit_at"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
+type LandingPage struct {
+	ID              int64              `json:"id"`
+	UserID          int64              `json:"user_id"`
+	Headline        pgtype.Text        `json:"headline"`
+	Description     pgtype.Text        `json:"description"`
+	ImageUrl        pgtype.Text        `json:"image_url"`
+	ImageKey        pgtype.Text        `json:"image_key"`
+	WhatsappUrl     pgtype.Text        `json:"whatsapp_url"`
+	FacebookUrl     pgtype.Text        `json:"facebook_url"`
+	InstagramUrl    pgtype.Text        `json:"instagram_url"`
+	YoutubeUrl      pgtype.Text        `json:"youtube_url"`
+	Email           pgtype.Text        `json:"email"`
+	WebsiteUrl      pgtype.Text        `json:"website_url"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+	MediaID         pgtype.Int8        `json:"media_id"`
+	Sections        []byte             `json:"sections"`
+	SectionsVersion int32              `json:"sections_version"`
+	GalleryMediaIds []int64            `json:"gallery_media_ids"`
+}
+
+type LandingRevision struct {
+	ID              int64              `json:"id"`
+	UserID          int64              `json:"user_id"`
+	Number          int32              `json:"number"`
+	Headline        pgtype.Text        `json:"headline"`
+	Description     pgtype.Text        `json:"description"`
+	ImageUrl        pgtype.Text        `json:"image_url"`
+	ImageKey        pgtype.Text        `json:"image_key"`
+	MediaID         pgtype.Int8        `json:"media_id"`
+	WhatsappUrl     pgtype.Text        `json:"whatsapp_url"`
+	FacebookUrl     pgtype.Text        `json:"facebook_url"`
+	InstagramUrl    pgtype.Text        `json:"instagram_url"`
+	YoutubeUrl      pgtype.Text        `json:"youtube_url"`
+	Email           pgtype.Text        `json:"email"`
+	WebsiteUrl      pgtype.Text        `json:"website_url"`
+	Sections        []byte             `json:"sections"`
+	SectionsVersion int32              `json:"sections_version"`
+	PublishedAt     pgtype.Timestamptz `json:"published_at"`
+	GalleryMediaIds []int64            `json:"gallery_media_ids"`
+}
+
+type LandingSlug struct {
+	Slug      string             `json:"slug"`
+	UserID    int64              `json:"user_id"`
+	IsCurrent bool               `json:"is_current"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type Lead struct {
 	ID        int64              `json:"id"`
 	UserID    int64              `json:"user_id"`
+	ContactID pgtype.Int8        `json:"contact_id"`
 	Phone     string             `json:"phone"`
 	Name      pgtype.Text        `json:"name"`
+	Source    string             `json:"source"`
+	Fields    []byte             `json:"fields"`
+	JobID     pgtype.Int8        `json:"job_id"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
 
-type LandingPage struct {
-	ID           int64              `json:"id"`
-	UserID       int64              `json:"user_id"`
-	Headline     pgtype.Text        `json:"headline"`
-	Description  pgtype.Text        `json:"description"`
-	ImageUrl     pgtype.Text        `json:"image_url"`
-	ImageKey     pgtype.Text        `json:"image_key"`
-	WhatsappUrl  pgtype.Text        `json:"whatsapp_url"`
-	FacebookUrl  pgtype.Text        `json:"facebook_url"`
-	InstagramUrl pgtype.Text        `json:"instagram_url"`
-	YoutubeUrl   pgtype.Text        `json:"youtube_url"`
-	Email        pgtype.Text        `json:"email"`
-	WebsiteUrl   pgtype.Text        `json:"website_url"`
-	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+type LeadEndpoint struct {
+	UserID    int64              `json:"user_id"`
+	Token     string             `json:"token"`
+	Secret    string             `json:"secret"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type LoginAttempt struct {
+	Phone        string             `json:"phone"`
+	FailedCount  int32              `json:"failed_count"`
+	Lockouts     int32              `json:"lockouts"`
+	LockedUntil  pgtype.Timestamptz `json:"locked_until"`
+	LastFailedAt pgtype.Timestamptz `json:"last_failed_at"`
+	LastIp       pgtype.Text        `json:"last_ip"`
 	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
 }
 
+type Medium struct {
+	ID                int64              `json:"id"`
+	UserID            pgtype.Int8        `json:"user_id"`
+	ImageKey          string             `json:"image_key"`
+	Url               string             `json:"url"`
+	Status            string             `json:"status"`
+	UnreferencedSince pgtype.Timestamptz `json:"unreferenced_since"`
+	DeleteAttempts    int32              `json:"delete_attempts"`
+	NextAttemptAt     pgtype.Timestamptz `json:"next_attempt_at"`
+	LastError         pgtype.Text        `json:"last_error"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
+}
+
+type Organization struct {
+	ID        int64              `json:"id"`
+	OwnerID   int64              `json:"owner_id"`
+	Name      string             `json:"name"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type OrganizationInvitation struct {
+	ID             int64              `json:"id"`
+	OrganizationID int64              `json:"organization_id"`
+	Phone          string             `json:"phone"`
+	Role           string             `json:"role"`
+	InvitedBy      int64              `json:"invited_by"`
+	Status         string             `json:"status"`
+	ExpiresAt      pgtype.Timestamptz `json:"expires_at"`
+	RespondedAt    pgtype.Timestamptz `json:"responded_at"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+type OrganizationMember struct {
+	OrganizationID int64              `json:"organization_id"`
+	UserID         int64              `json:"user_id"`
+	Role           string             `json:"role"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+}
+
+type OtpCode struct {
+	ID          int64              `json:"id"`
+	Phone       string             `json:"phone"`
+	Purpose     string             `json:"purpose"`
+	CodeHash    string             `json:"code_hash"`
+	Attempts    int32              `json:"attempts"`
+	MaxAttempts int32              `json:"max_attempts"`
+	ExpiresAt   pgtype.Timestamptz `json:"expires_at"`
+	ConsumedAt  pgtype.Timestamptz `json:"consumed_at"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type RateLimitBucket struct {
+	Key         string             `json:"key"`
+	WindowStart pgtype.Timestamptz `json:"window_start"`
+	Count       int32              `json:"count"`
+	ExpiresAt   pgtype.Timestamptz `json:"expires_at"`
+}
+
 type Rule struct {
 	ID        int64              `json:"id"`
 	UserID    int64              `json:"user_id"`
@@ -40,6 +334,53 @@ type Rule struct {
 	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
 }
 
+type Sequence struct {
+	ID             int64              `json:"id"`
+	UserID         int64              `json:"user_id"`
+	Name           string             `json:"name"`
+	TriggerType    string             `json:"trigger_type"`
+	Steps          []byte             `json:"steps"`
+	StopOnCallback bool               `json:"stop_on_callback"`
+	StopOnOptOut   bool               `json:"stop_on_opt_out"`
+	Enabled        bool               `json:"enabled"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+}
+
+type SequenceEnrollment struct {
+	ID         int64              `json:"id"`
+	SequenceID int64              `json:"sequence_id"`
+	UserID     int64              `json:"user_id"`
+	Phone      string             `json:"phone"`
+	Status     string             `json:"status"`
+	NextStep   int32              `json:"next_step"`
+	NextRunAt  pgtype.Timestamptz `json:"next_run_at"`
+	StopReason pgtype.Text        `json:"stop_reason"`
+	StartedAt  pgtype.Timestamptz `json:"started_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+}
+
+type ShortLink struct {
+	ID          int64              `json:"id"`
+	UserID      int64              `json:"user_id"`
+	Code        string             `json:"code"`
+	Url         string             `json:"url"`
+	Source      string             `json:"source"`
+	RefID       pgtype.Int8        `json:"ref_id"`
+	Phone       pgtype.Text        `json:"phone"`
+	Clicks      int32              `json:"clicks"`
+	LastClickAt pgtype.Timestamptz `json:"last_click_at"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type ShortLinkClick struct {
+	ID          int64              `json:"id"`
+	ShortLinkID int64              `json:"short_link_id"`
+	UserAgent   string             `json:"user_agent"`
+	Device      string             `json:"device"`
+	ClickedAt   pgtype.Timestamptz `json:"clicked_at"`
+}
+
 type Template struct {
 	ID        int64              `json:"id"`
 	UserID    int64              `json:"user_id"`
@@ -53,6 +394,7 @@ type Template struct {
 	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
 	ImageUrl  pgtype.Text        `json:"image_url"`
 	ImageKey  pgtype.Text        `json:"image_key"`
+	MediaID   pgtype.Int8        `json:"media_id"`
 }
 
 type Token struct {
@@ -66,22 +408,56 @@ type Token struct {
 	UserAgent  pgtype.Text        `json:"user_agent"`
 	CreatedAt  pgtype.Timestamptz `json:"created_at"`
 	LastUsedAt pgtype.Timestamptz `json:"last_used_at"`
+	DeviceID   pgtype.Int8        `json:"device_id"`
 }
 
 type User struct {
-	ID            int64              `json:"id"`
-	Phone         string             `json:"phone"`
-	PasswordHash  string             `json:"password_hash"`
-	PhoneVerified bool               `json:"phone_verified"`
-	Name          pgtype.Text        `json:"name"`
-	BusinessName  pgtype.Text        `json:"business_name"`
-	City          pgtype.Text        `json:"city"`
-	Address       pgtype.Text        `json:"address"`
-	LocationUrl   pgtype.Text        `json:"location_url"`
-	Plan          string             `json:"plan"`
-	PlanStartedAt pgtype.Timestamptz `json:"plan_started_at"`
-	PlanExpiresAt pgtype.Timestamptz `json:"plan_expires_at"`
-	Status        string             `json:"status"`
-	CreatedAt     pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+	ID               int64              `json:"id"`
+	Phone            string             `json:"phone"`
+	PasswordHash     string             `json:"password_hash"`
+	PhoneVerified    bool               `json:"phone_verified"`
+	Name             pgtype.Text        `json:"name"`
+	BusinessName     pgtype.Text        `json:"business_name"`
+	City             pgtype.Text        `json:"city"`
+	Address          pgtype.Text        `json:"address"`
+	LocationUrl      pgtype.Text        `json:"location_url"`
+	Plan             string             `json:"plan"`
+	PlanStartedAt    pgtype.Timestamptz `json:"plan_started_at"`
+	PlanExpiresAt    pgtype.Timestamptz `json:"plan_expires_at"`
+	Status           string             `json:"status"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt        pgtype.Timestamptz `json:"deleted_at"`
+	PurgeAfter       pgtype.Timestamptz `json:"purge_after"`
+	TokensValidAfter pgtype.Timestamptz `json:"tokens_valid_after"`
+}
+
+type Webhook struct {
+	ID        int64              `json:"id"`
+	UserID    int64              `json:"user_id"`
+	Url       string             `json:"url"`
+	Events    []string           `json:"events"`
+	Secret    string             `json:"secret"`
+	Active    bool               `json:"active"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type WebhookDelivery struct {
+	ID             int64              `json:"id"`
+	WebhookID      int64              `json:"webhook_id"`
+	EventID        string             `json:"event_id"`
+	EventType      string             `json:"event_type"`
+	Payload        []byte             `json:"payload"`
+	Status         string             `json:"status"`
+	Attempts       int32              `json:"attempts"`
+	MaxAttempts    int32              `json:"max_attempts"`
+	NextAttemptAt  pgtype.Timestamptz `json:"next_attempt_at"`
+	LockedUntil    pgtype.Timestamptz `json:"locked_until"`
+	ResponseStatus pgtype.Int4        `json:"response_status"`
+	LastError      pgtype.Text        `json:"last_error"`
+	DurationMs     pgtype.Int4        `json:"duration_ms"`
+	DeliveredAt    pgtype.Timestamptz `json:"delivered_at"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
 }
@@ -11,32 +11,222 @@ import (
 )
 
 type Querier interface {
+	AckDeviceJob(ctx context.Context, arg AckDeviceJobParams) (DeviceJob, error)
+	AddOrganizationMember(ctx context.Context, arg AddOrganizationMemberParams) error
+	AdvanceSequenceEnrollment(ctx context.Context, arg AdvanceSequenceEnrollmentParams) (int64, error)
+	CancelCampaign(ctx context.Context, arg CancelCampaignParams) (Campaign, error)
+	ClaimCampaignRecipients(ctx context.Context, arg ClaimCampaignRecipientsParams) ([]CampaignRecipient, error)
+	ClaimDeletingMedia(ctx context.Context, arg ClaimDeletingMediaParams) ([]Medium, error)
+	ClaimDueSequenceEnrollments(ctx context.Context, arg ClaimDueSequenceEnrollmentsParams) ([]SequenceEnrollment, error)
+	ClaimWebhookDeliveries(ctx context.Context, arg ClaimWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	ClearCurrentLandingSlug(ctx context.Context, userID int64) error
+	ClearDeviceSMSLine(ctx context.Context, arg ClearDeviceSMSLineParams) error
+	CompleteCampaign(ctx context.Context, id int64) error
+	CompleteWebhookDelivery(ctx context.Context, arg CompleteWebhookDeliveryParams) error
+	ConsumeOTPCode(ctx context.Context, id int64) (int64, error)
+	CountActiveAPIKeys(ctx context.Context, userID int64) (int64, error)
+	CountCampaignRecipientsByStatus(ctx context.Context, campaignID int64) ([]CountCampaignRecipientsByStatusRow, error)
+	CountOpenCampaignRecipients(ctx context.Context, campaignID int64) (int64, error)
+	CountRecentCampaignDispatches(ctx context.Context, arg CountRecentCampaignDispatchesParams) (int64, error)
+	CountRecentOTPCodes(ctx context.Context, arg CountRecentOTPCodesParams) (int64, error)
+	CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (ApiKey, error)
+	CreateCampaign(ctx context.Context, arg CreateCampaignParams) (Campaign, error)
+	CreateDeviceHeartbeat(ctx context.Context, arg CreateDeviceHeartbeatParams) error
+	CreateDeviceJob(ctx context.Context, arg CreateDeviceJobParams) (DeviceJob, error)
+	CreateDeviceToken(ctx context.Context, arg CreateDeviceTokenParams) error
+	CreateImageVariant(ctx context.Context, arg CreateImageVariantParams) (ImageVariant, error)
+	CreateLandingLink(ctx context.Context, arg CreateLandingLinkParams) (LandingLink, error)
+	// Copies the live page as the user's next revision.
+	CreateLandingRevision(ctx context.Context, userID int64) (LandingRevision, error)
+	CreateLead(ctx context.Context, arg CreateLeadParams) (Lead, error)
+	CreateMedia(ctx context.Context, arg CreateMediaParams) (Medium, error)
+	CreateOTPCode(ctx context.Context, arg CreateOTPCodeParams) (OtpCode, error)
+	CreateOrganization(ctx context.Context, arg CreateOrganizationParams) (Organization, error)
+	CreateOrganizationInvitation(ctx context.Context, arg CreateOrganizationInvitationParams) (OrganizationInvitation, error)
+	CreateSequence(ctx context.Context, arg CreateSequenceParams) (Sequence, error)
+	// A phone that called back while enrolled is not enrolled in that sequence again.
+	CreateSequenceEnrollment(ctx context.Context, arg CreateSequenceEnrollmentParams) (SequenceEnrollment, error)
+	CreateShortLink(ctx context.Context, arg CreateShortLinkParams) (ShortLink, error)
+	CreateShortLinkClick(ctx context.Context, arg CreateShortLinkClickParams) error
 	CreateTemplate(ctx context.Context, arg CreateTemplateParams) (Template, error)
 	CreateToken(ctx context.Context, arg CreateTokenParams) (Token, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateWebhook(ctx context.Context, arg CreateWebhookParams) (Webhook, error)
+	CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) (WebhookDelivery, error)
+	DeleteDeviceHeartbeatsBefore(ctx context.Context, createdAt pgtype.Timestamptz) (int64, error)
+	DeleteExpiredOTPCodes(ctx context.Context, expiresAt pgtype.Timestamptz) error
+	DeleteExpiredRateLimitBuckets(ctx context.Context) error
 	DeleteExpiredTokens(ctx context.Context, expiresAt pgtype.Timestamptz) error
+	DeleteImageVariants(ctx context.Context, imageKey string) error
+	DeleteLandingDraft(ctx context.Context, userID int64) (int64, error)
+	DeleteLeadEndpoint(ctx context.Context, userID int64) (int64, error)
+	DeleteLoginAttemptsByPhone(ctx context.Context, phone string) error
+	DeleteMedia(ctx context.Context, id int64) error
+	DeleteOTPCodesByPhone(ctx context.Context, phone string) error
+	DeleteOrganizationMember(ctx context.Context, arg DeleteOrganizationMemberParams) (int64, error)
+	DeleteQueuedCampaignJobs(ctx context.Context, campaignID int64) error
+	DeleteSequence(ctx context.Context, arg DeleteSequenceParams) error
 	DeleteTemplate(ctx context.Context, arg DeleteTemplateParams) error
+	DeleteUser(ctx context.Context, id int64) error
+	DeleteWebhook(ctx context.Context, arg DeleteWebhookParams) (int64, error)
+	ExpandCampaignRecipients(ctx context.Context, arg ExpandCampaignRecipientsParams) (int64, error)
+	ExpireOrganizationInvitations(ctx context.Context, arg ExpireOrganizationInvitationsParams) error
+	ExpireUnattachedMedia(ctx context.Context, unreferencedBefore pgtype.Timestamptz) (int64, error)
+	FailDeviceJob(ctx context.Context, arg FailDeviceJobParams) (DeviceJob, error)
+	FailExhaustedDeviceJobs(ctx context.Context, userID int64) ([]DeviceJob, error)
+	FailMediaDeletion(ctx context.Context, arg FailMediaDeletionParams) error
+	FailWebhookDelivery(ctx context.Context, arg FailWebhookDeliveryParams) error
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (ApiKey, error)
+	GetActiveOTPCode(ctx context.Context, arg GetActiveOTPCodeParams) (OtpCode, error)
+	GetCampaignByID(ctx context.Context, arg GetCampaignByIDParams) (Campaign, error)
+	GetContactByPhone(ctx context.Context, arg GetContactByPhoneParams) (Contact, error)
 	GetContactsByUserID(ctx context.Context, userID int64) ([]Contact, error)
+	GetCurrentLandingSlug(ctx context.Context, userID int64) (LandingSlug, error)
+	GetDeviceByDeviceID(ctx context.Context, arg GetDeviceByDeviceIDParams) (Device, error)
+	GetDeviceByID(ctx context.Context, arg GetDeviceByIDParams) (Device, error)
 	GetLandingByUserID(ctx context.Context, userID int64) (LandingPage, error)
+	GetLandingDraftByUserID(ctx context.Context, userID int64) (LandingDraft, error)
+	GetLandingRevisionByID(ctx context.Context, arg GetLandingRevisionByIDParams) (LandingRevision, error)
+	GetLandingSlug(ctx context.Context, slug string) (LandingSlug, error)
+	GetLeadEndpointByToken(ctx context.Context, token string) (LeadEndpoint, error)
+	GetLeadEndpointByUserID(ctx context.Context, userID int64) (LeadEndpoint, error)
+	GetLoginAttempt(ctx context.Context, phone string) (LoginAttempt, error)
+	GetMediaByID(ctx context.Context, arg GetMediaByIDParams) (Medium, error)
+	GetMediaByImageKey(ctx context.Context, arg GetMediaByImageKeyParams) (Medium, error)
+	GetMediaItem(ctx context.Context, arg GetMediaItemParams) (GetMediaItemRow, error)
+	GetOrganizationByID(ctx context.Context, id int64) (Organization, error)
+	GetOrganizationByOwnerID(ctx context.Context, ownerID int64) (Organization, error)
+	GetOrganizationInvitationByID(ctx context.Context, id int64) (OrganizationInvitation, error)
+	GetOrganizationMember(ctx context.Context, arg GetOrganizationMemberParams) (OrganizationMember, error)
+	GetRateLimitBucketCount(ctx context.Context, arg GetRateLimitBucketCountParams) (int32, error)
 	GetRuleByUserID(ctx context.Context, userID int64) (Rule, error)
+	GetSequenceByID(ctx context.Context, arg GetSequenceByIDParams) (Sequence, error)
+	GetShortLinkByCode(ctx context.Context, code string) (ShortLink, error)
+	GetShortLinkByID(ctx context.Context, arg GetShortLinkByIDParams) (ShortLink, error)
+	GetShortLinkByRef(ctx context.Context, arg GetShortLinkByRefParams) (ShortLink, error)
 	GetTemplateByID(ctx context.Context, arg GetTemplateByIDParams) (Template, error)
 	GetTemplateByUserID(ctx context.Context, userID int64) ([]Template, error)
 	GetTokenByToken(ctx context.Context, token string) (Token, error)
 	GetUserByID(ctx context.Context, id int64) (User, error)
 	GetUserByPhone(ctx context.Context, phone string) (User, error)
+	GetWebhookByID(ctx context.Context, arg GetWebhookByIDParams) (Webhook, error)
+	GetWebhookDeliveryByID(ctx context.Context, arg GetWebhookDeliveryByIDParams) (WebhookDelivery, error)
+	GetWebhookForDelivery(ctx context.Context, id int64) (Webhook, error)
+	HasRecentLeadReply(ctx context.Context, arg HasRecentLeadReplyParams) (bool, error)
+	HitRateLimitBucket(ctx context.Context, arg HitRateLimitBucketParams) (int32, error)
+	IncrementLandingDailyStat(ctx context.Context, arg IncrementLandingDailyStatParams) error
+	IncrementShortLinkClicks(ctx context.Context, id int64) error
+	InvalidateOTPCodes(ctx context.Context, arg InvalidateOTPCodesParams) error
+	InvalidateUserTokens(ctx context.Context, id int64) error
+	LeaseDeviceJobs(ctx context.Context, arg LeaseDeviceJobsParams) ([]DeviceJob, error)
+	ListAPIKeysByUserID(ctx context.Context, userID int64) ([]ApiKey, error)
+	ListActiveWebhooksForEvent(ctx context.Context, arg ListActiveWebhooksForEventParams) ([]Webhook, error)
+	ListAllDeviceJobsByUserID(ctx context.Context, userID int64) ([]DeviceJob, error)
+	ListAllLandingRevisionsByUserID(ctx context.Context, userID int64) ([]LandingRevision, error)
+	ListAllLeadsByUserID(ctx context.Context, userID int64) ([]Lead, error)
+	ListAllShortLinksByUserID(ctx context.Context, userID int64) ([]ShortLink, error)
 	ListAllUsers(ctx context.Context) ([]User, error)
+	ListAssignedSMSLines(ctx context.Context, userID int64) ([]string, error)
+	ListCampaignRecipients(ctx context.Context, arg ListCampaignRecipientsParams) ([]CampaignRecipient, error)
+	ListCampaignRecipientsByUserID(ctx context.Context, userID int64) ([]CampaignRecipient, error)
+	ListCampaignsByUserID(ctx context.Context, userID int64) ([]Campaign, error)
+	ListDeviceJobs(ctx context.Context, arg ListDeviceJobsParams) ([]DeviceJob, error)
+	ListDevicesByUserID(ctx context.Context, userID int64) ([]Device, error)
+	ListDueScheduledCampaigns(ctx context.Context, userID int64) ([]Campaign, error)
+	ListEnabledSequencesByTrigger(ctx context.Context, arg ListEnabledSequencesByTriggerParams) ([]Sequence, error)
+	ListImageVariants(ctx context.Context, imageKey string) ([]ImageVariant, error)
+	ListLandingDailyStats(ctx context.Context, arg ListLandingDailyStatsParams) ([]LandingDailyStat, error)
+	ListLandingRevisionsByUserID(ctx context.Context, arg ListLandingRevisionsByUserIDParams) ([]LandingRevision, error)
+	ListLandingSlugsByUserID(ctx context.Context, userID int64) ([]LandingSlug, error)
+	ListLandingsWithImage(ctx context.Context) ([]LandingPage, error)
+	ListLeadsByUserID(ctx context.Context, arg ListLeadsByUserIDParams) ([]Lead, error)
+	ListLockedLoginAccounts(ctx context.Context) ([]LoginAttempt, error)
+	ListMediaItemsByUserID(ctx context.Context, userID pgtype.Int8) ([]ListMediaItemsByUserIDRow, error)
+	ListOpenDeviceAlerts(ctx context.Context, limit int32) ([]DeviceAlert, error)
+	ListOrganizationInvitations(ctx context.Context, organizationID int64) ([]OrganizationInvitation, error)
+	ListOrganizationMembers(ctx context.Context, organizationID int64) ([]OrganizationMember, error)
+	ListOrganizationMembershipsByUserID(ctx context.Context, userID int64) ([]OrganizationMember, error)
+	ListPendingInvitationsByPhone(ctx context.Context, phone string) ([]OrganizationInvitation, error)
+	ListRunningCampaigns(ctx context.Context, userID int64) ([]Campaign, error)
+	ListSequenceEnrollments(ctx context.Context, arg ListSequenceEnrollmentsParams) ([]SequenceEnrollment, error)
+	ListSequenceEnrollmentsByUserID(ctx context.Context, userID int64) ([]SequenceEnrollment, error)
+	ListSequencesByUserID(ctx context.Context, userID int64) ([]Sequence, error)
+	ListShortLinkClicks(ctx context.Context, arg ListShortLinkClicksParams) ([]ShortLinkClick, error)
+	ListShortLinkClicksByUserID(ctx context.Context, userID int64) ([]ShortLinkClick, error)
+	ListShortLinksByUserID(ctx context.Context, arg ListShortLinksByUserIDParams) ([]ShortLink, error)
+	ListTemplatesWithImage(ctx context.Context) ([]Template, error)
+	ListUnhealthyDevices(ctx context.Context, silentBefore pgtype.Timestamptz) ([]Device, error)
+	ListUsersDueForPurge(ctx context.Context, limit int32) ([]User, error)
+	ListVisitedLandingLinks(ctx context.Context, arg ListVisitedLandingLinksParams) ([]LandingLink, error)
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	ListWebhooksByUserID(ctx context.Context, userID int64) ([]Webhook, error)
+	LockLoginAccount(ctx context.Context, arg LockLoginAccountParams) error
+	MarkMediaDeleting(ctx context.Context, imageKey string) error
+	MarkReferencedMedia(ctx context.Context) (int64, error)
+	// Media that were attached once stay in the library, so unreferenced_since is
+	// left unset and they never expire.
+	MarkUnreferencedMedia(ctx context.Context) (int64, error)
+	MarkUserMediaDeleting(ctx context.Context, userID pgtype.Int8) error
+	MarkUserPhoneVerified(ctx context.Context, phone string) error
+	OpenDeviceAlert(ctx context.Context, arg OpenDeviceAlertParams) error
+	OpenSilentDeviceAlerts(ctx context.Context, silentBefore pgtype.Timestamptz) (int64, error)
+	OptOutContact(ctx context.Context, arg OptOutContactParams) (OptOutContactRow, error)
+	PauseCampaign(ctx context.Context, arg PauseCampaignParams) (Campaign, error)
+	PruneLandingRevisions(ctx context.Context, arg PruneLandingRevisionsParams) error
+	RecordContactCall(ctx context.Context, arg RecordContactCallParams) (RecordContactCallRow, error)
+	RecordLandingLinkVisit(ctx context.Context, arg RecordLandingLinkVisitParams) (int64, error)
+	RecordLoginFailure(ctx context.Context, arg RecordLoginFailureParams) (LoginAttempt, error)
+	RenameMedia(ctx context.Context, arg RenameMediaParams) error
+	ReplaceLandingImage(ctx context.Context, arg ReplaceLandingImageParams) (int64, error)
+	ReplaceTemplateImage(ctx context.Context, arg ReplaceTemplateImageParams) (int64, error)
+	ReportCampaignRecipient(ctx context.Context, arg ReportCampaignRecipientParams) (int64, error)
+	ReserveEnquiryReply(ctx context.Context, arg ReserveEnquiryReplyParams) (int32, error)
+	ResetLoginAttempts(ctx context.Context, phone string) error
+	ResolveDeviceAlert(ctx context.Context, arg ResolveDeviceAlertParams) error
+	RespondOrganizationInvitation(ctx context.Context, arg RespondOrganizationInvitationParams) (int64, error)
+	ResumeCampaign(ctx context.Context, arg ResumeCampaignParams) (Campaign, error)
+	RevokeAPIKey(ctx context.Context, arg RevokeAPIKeyParams) (int64, error)
 	RevokeAllUserTokens(ctx context.Context, userID int64) error
 	RevokeAllUserTokensByType(ctx context.Context, arg RevokeAllUserTokensByTypeParams) error
+	RevokeDevice(ctx context.Context, arg RevokeDeviceParams) (Device, error)
+	RevokeDeviceTokens(ctx context.Context, deviceID pgtype.Int8) error
+	RevokeOtherUserTokens(ctx context.Context, arg RevokeOtherUserTokensParams) error
 	RevokeToken(ctx context.Context, token string) error
+	SetCampaignTotalRecipients(ctx context.Context, arg SetCampaignTotalRecipientsParams) error
+	SetContactTags(ctx context.Context, arg SetContactTagsParams) (Contact, error)
+	SetCurrentLandingSlug(ctx context.Context, arg SetCurrentLandingSlugParams) (LandingSlug, error)
+	SetDeviceSMSLine(ctx context.Context, arg SetDeviceSMSLineParams) (Device, error)
+	SetLandingDraftPreviewToken(ctx context.Context, arg SetLandingDraftPreviewTokenParams) (LandingDraft, error)
+	SetLeadJob(ctx context.Context, arg SetLeadJobParams) error
+	SkipOpenCampaignRecipients(ctx context.Context, campaignID int64) error
+	SoftDeleteUser(ctx context.Context, arg SoftDeleteUserParams) error
+	StartCampaign(ctx context.Context, id int64) (Campaign, error)
+	StopSequenceEnrollment(ctx context.Context, arg StopSequenceEnrollmentParams) error
+	StopSequenceEnrollmentsOnCallback(ctx context.Context, arg StopSequenceEnrollmentsOnCallbackParams) (int64, error)
+	StopSequenceEnrollmentsOnOptOut(ctx context.Context, arg StopSequenceEnrollmentsOnOptOutParams) (int64, error)
+	TouchAPIKey(ctx context.Context, id int64) error
+	UpdateDeviceHealth(ctx context.Context, arg UpdateDeviceHealthParams) (Device, error)
+	UpdateOrganizationMemberRole(ctx context.Context, arg UpdateOrganizationMemberRoleParams) (int64, error)
+	UpdateOrganizationName(ctx context.Context, arg UpdateOrganizationNameParams) (Organization, error)
+	UpdateSequence(ctx context.Context, arg UpdateSequenceParams) (Sequence, error)
 	UpdateTemplate(ctx context.Context, arg UpdateTemplateParams) (Template, error)
 	UpdateTokenLastUsed(ctx context.Context, id int64) error
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error
 	UpdateUserPlan(ctx context.Context, arg UpdateUserPlanParams) error
 	UpdateUserStatus(ctx context.Context, arg UpdateUserStatusParams) error
-	UpsertContact(ctx context.Context, arg UpsertContactParams) (Contact, error)
-	UpsertContactBatch(ctx context.Context, arg UpsertContactBatchParams) error
+	UpdateWebhook(ctx context.Context, arg UpdateWebhookParams) (Webhook, error)
+	UpsertContact(ctx context.Context, arg UpsertContactParams) (UpsertContactRow, error)
+	UpsertContactBatch(ctx context.Context, arg UpsertContactBatchParams) ([]UpsertContactBatchRow, error)
+	UpsertDevice(ctx context.Context, arg UpsertDeviceParams) (Device, error)
 	UpsertLandingByUserID(ctx context.Context, arg UpsertLandingByUserIDParams) (LandingPage, error)
+	// An existing draft keeps its preview token.
+	UpsertLandingDraftByUserID(ctx context.Context, arg UpsertLandingDraftByUserIDParams) (LandingDraft, error)
+	UpsertLeadEndpoint(ctx context.Context, arg UpsertLeadEndpointParams) (LeadEndpoint, error)
 	UpsertRule(ctx context.Context, arg UpsertRuleParams) (Rule, error)
+	// Counts a guess in the same statement that checks the cap, so concurrent
+	// guesses cannot all pass it.
+	UseOTPAttempt(ctx context.Context, id int64) (int32, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -12,9 +12,9 @@ import (
 )
 
 const createTemplate = `-- name: CreateTemplate :one
-INSERT INTO templates (user_id, name, body, type, channel, image_url, image_key, language, is_default)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-RETURNING id, user_id, name, body, type, channel, language, is_default, created_at, updated_at, image_url, image_key
+INSERT INTO templates (user_id, name, body, type, channel, image_url, image_key, language, is_default, media_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING id, user_id, name, body, type, channel, language, is_default, created_at, updated_at, image_url, image_key, media_id
 `
 
 type CreateTemplateParams struct {
@@ -27,6 +27,7 @@ type CreateTemplateParams struct {
 	ImageKey  pgtype.Text `json:"image_key"`
 	Language  pgtype.Text `json:"language"`
 	IsDefault bool        `json:"is_default"`
+	MediaID   pgtype.Int8 `json:"media_id"`
 }
 
 func (q *Queries) CreateTemplate(ctx context.Context, arg CreateTemplateParams) (Template, error) {
@@ -40,6 +41,7 @@ func (q *Queries) CreateTemplate(ctx context.Context, arg CreateTemplateParams)
 		arg.ImageKey,
 		arg.Language,
 		arg.IsDefault,
+		arg.MediaID,
 	)
 	var i Template
 	err := row.Scan(
@@ -55,6 +57,7 @@ func (q *Queries) CreateTemplate(ctx context.Context, arg CreateTemplateParams)
 		&i.UpdatedAt,
 		&i.ImageUrl,
 		&i.ImageKey,
+		&i.MediaID,
 	)
 	return i, err
 }
@@ -74,7 +77,7 @@ func (q *Queries) DeleteTemplate(ctx context.Context, arg DeleteTemplateParams)
 }
 
 const getTemplateByID = `-- name: GetTemplateByID :one
-SELECT id, user_id, name, body, type, channel, language, is_default, created_at, updated_at, image_url, image_key FROM templates WHERE id = $1 AND user_id = $2
+SELECT id, user_id, name, body, type, channel, language, is_default, created_at, updated_at, image_url, image_key, media_id FROM templates WHERE id = $1 AND user_id = $2
 `
 
 type GetTemplateByIDParams struct {
@@ -98,12 +101,13 @@ func (q *Queries) GetTemplateByID(ctx context.Context, arg GetTemplateByIDParams
 		&i.UpdatedAt,
 		&i.ImageUrl,
 		&i.ImageKey,
+		&i.MediaID,
 	)
 	return i, err
 }
 
 const getTemplateByUserID = `-- name: GetTemplateByUserID :many
-SELECT id, user_id, name, body, type, channel, language, is_default, created_at, updated_at, image_url, image_key FROM templates WHERE user_id = $1 ORDER BY created_at DESC
+SELECT id, user_id, name, body, type, channel, language, is_default, created_at, updated_at, image_url, image_key, media_id FROM templates WHERE user_id = $1 ORDER BY created_at DESC
 `
 
 func (q *Queries) GetTemplateByUserID(ctx context.Context, userID int64) ([]Template, error) {
@@ -128,6 +132,7 @@ func (q *Queries) GetTemplateByUserID(ctx context.Context, userID int64) ([]Temp
 			&i.UpdatedAt,
 			&i.ImageUrl,
 			&i.ImageKey,
+			&i.MediaID,
 		); err != nil {
 			return nil, err
 		}
@@ -139,6 +144,71 @@ func (q *Queries) GetTemplateByUserID(ctx context.Context, userID int64) ([]Temp
 	return items, nil
 }
 
+const listTemplatesWithImage = `-- name: ListTemplatesWithImage :many
+SELECT id, user_id, name, body, type, channel, language, is_default, created_at, updated_at, image_url, image_key, media_id FROM templates WHERE image_key IS NOT NULL ORDER BY id
+`
+
+func (q *Queries) ListTemplatesWithImage(ctx context.Context) ([]Template, error) {
+	rows, err := q.db.Query(ctx, listTemplatesWithImage)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Template{}
+	for rows.Next() {
+		var i Template
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.Body,
+			&i.Type,
+			&i.Channel,
+			&i.Language,
+			&i.IsDefault,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ImageUrl,
+			&i.ImageKey,
+			&i.MediaID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const replaceTemplateImage = `-- name: ReplaceTemplateImage :execrows
+UPDATE templates
+SET image_url = $1,
+    image_key = $2
+WHERE id = $3 AND image_key = $4
+`
+
+type ReplaceTemplateImageParams struct {
+	ImageUrl    pgtype.Text `json:"image_url"`
+	ImageKey    pgtype.Text `json:"image_key"`
+	ID          int64       `json:"id"`
+	OldImageKey pgtype.Text `json:"old_image_key"`
+}
+
+func (q *Queries) ReplaceTemplateImage(ctx context.Context, arg ReplaceTemplateImageParams) (int64, error) {
+	result, err := q.db.Exec(ctx, replaceTemplateImage,
+		arg.ImageUrl,
+		arg.ImageKey,
+		arg.ID,
+		arg.OldImageKey,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateTemplate = `-- name: UpdateTemplate :one
 UPDATE templates
 SET name = $3,
@@ -149,9 +219,10 @@ SET name = $3,
     image_key = $8,
     language = $9,
     is_default = $10,
+    media_id = $11,
     updated_at = NOW()
 WHERE id = $1 AND user_id = $2
-RETURNING id, user_id, name, body, type, channel, language, is_default, created_at, updated_at, image_url, image_key
+RETURNING id, user_id, name, body, type, channel, language, is_default, created_at, updated_at, image_url, image_key, media_id
 `
 
 type UpdateTemplateParams struct {
@@ -165,6 +236,7 @@ type UpdateTemplateParams struct {
 	ImageKey  pgtype.Text `json:"image_key"`
 	Language  pgtype.Text `json:"language"`
 	IsDefault bool        `json:"is_default"`
+	MediaID   pgtype.Int8 `json:"media_id"`
 }
 
 func (q *Queries) UpdateTemplate(ctx context.Context, arg UpdateTemplateParams) (Template, error) {
@@ -179,6 +251,7 @@ func (q *Queries) UpdateTemplate(ctx context.Context, arg UpdateTemplateParams)
 		arg.ImageKey,
 		arg.Language,
 		arg.IsDefault,
+		arg.MediaID,
 	)
 	var i Template
 	err := row.Scan(
@@ -194,6 +267,7 @@ func (q *Queries) UpdateTemplate(ctx context.Context, arg UpdateTemplateParams)
 		&i.UpdatedAt,
 		&i.ImageUrl,
 		&i.ImageKey,
+		&i.MediaID,
 	)
 	return i, err
 }
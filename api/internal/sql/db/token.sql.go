@@ -11,10 +11,34 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const createDeviceToken = `-- name: CreateDeviceToken :exec
+INSERT INTO tokens (user_id, token, expires_at, token_type, device_id)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+type CreateDeviceTokenParams struct {
+	UserID    int64              `json:"user_id"`
+	Token     string             `json:"token"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	TokenType string             `json:"token_type"`
+	DeviceID  pgtype.Int8        `json:"device_id"`
+}
+
+func (q *Queries) CreateDeviceToken(ctx context.Context, arg CreateDeviceTokenParams) error {
+	_, err := q.db.Exec(ctx, createDeviceToken,
+		arg.UserID,
+		arg.Token,
+		arg.ExpiresAt,
+		arg.TokenType,
+		arg.DeviceID,
+	)
+	return err
+}
+
 const createToken = `-- name: CreateToken :one
 INSERT INTO tokens (user_id, token, expires_at, token_type, client_ip, user_agent)
 VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, user_id, token, expires_at, is_revoked, token_type, client_ip, user_agent, created_at, last_used_at
+RETURNING id, user_id, token, expires_at, is_revoked, token_type, client_ip, user_agent, created_at, last_used_at, device_id
 `
 
 type CreateTokenParams struct {
@@ -47,6 +71,7 @@ func (q *Queries) CreateToken(ctx context.Context, arg CreateTokenParams) (Token
 		&i.UserAgent,
 		&i.CreatedAt,
 		&i.LastUsedAt,
+		&i.DeviceID,
 	)
 	return i, err
 }
@@ -61,7 +86,7 @@ func (q *Queries) DeleteExpiredTokens(ctx context.Context, expiresAt pgtype.Time
 }
 
 const getTokenByToken = `-- name: GetTokenByToken :one
-SELECT id, user_id, token, expires_at, is_revoked, token_type, client_ip, user_agent, created_at, last_used_at FROM tokens WHERE token = $1
+SELECT id, user_id, token, expires_at, is_revoked, token_type, client_ip, user_agent, created_at, last_used_at, device_id FROM tokens WHERE token = $1
 `
 
 func (q *Queries) GetTokenByToken(ctx context.Context, token string) (Token, error) {
@@ -78,6 +103,7 @@ func (q *Queries) GetTokenByToken(ctx context.Context, token string) (Token, err
 		&i.UserAgent,
 		&i.CreatedAt,
 		&i.LastUsedAt,
+		&i.DeviceID,
 	)
 	return i, err
 }
@@ -105,6 +131,29 @@ func (q *Queries) RevokeAllUserTokensByType(ctx context.Context, arg RevokeAllUs
 	return err
 }
 
+const revokeDeviceTokens = `-- name: RevokeDeviceTokens :exec
+UPDATE tokens SET is_revoked = true WHERE device_id = $1
+`
+
+func (q *Queries) RevokeDeviceTokens(ctx context.Context, deviceID pgtype.Int8) error {
+	_, err := q.db.Exec(ctx, revokeDeviceTokens, deviceID)
+	return err
+}
+
+const revokeOtherUserTokens = `-- name: RevokeOtherUserTokens :exec
+UPDATE tokens SET is_revoked = true WHERE user_id = $1 AND token <> $2
+`
+
+type RevokeOtherUserTokensParams struct {
+	UserID int64  `json:"user_id"`
+	Token  string `json:"token"`
+}
+
+func (q *Queries) RevokeOtherUserTokens(ctx context.Context, arg RevokeOtherUserTokensParams) error {
+	_, err := q.db.Exec(ctx, revokeOtherUserTokens, arg.UserID, arg.Token)
+	return err
+}
+
 const revokeToken = `-- name: RevokeToken :exec
 UPDATE tokens SET is_revoked = true WHERE token = $1
 `
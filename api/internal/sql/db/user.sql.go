@@ -14,7 +14,7 @@ import (
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (phone, phone_verified, password_hash, name, business_name, city, address)
 VALUES ($1, $2, $3, $4, $5, $6, $7)
-RETURNING id, phone, password_hash, phone_verified, name, business_name, city, address, location_url, plan, plan_started_at, plan_expires_at, status, created_at, updated_at
+RETURNING id, phone, password_hash, phone_verified, name, business_name, city, address, location_url, plan, plan_started_at, plan_expires_at, status, created_at, updated_at, deleted_at, purge_after, tokens_valid_after
 `
 
 type CreateUserParams struct {
@@ -54,12 +54,15 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.PurgeAfter,
+		&i.TokensValidAfter,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, phone, password_hash, phone_verified, name, business_name, city, address, location_url, plan, plan_started_at, plan_expires_at, status, created_at, updated_at FROM users WHERE id = $1
+SELECT id, phone, password_hash, phone_verified, name, business_name, city, address, location_url, plan, plan_started_at, plan_expires_at, status, created_at, updated_at, deleted_at, purge_after, tokens_valid_after FROM users WHERE id = $1
 `
 
 func (q *Queries) GetUserByID(ctx context.Context, id int64) (User, error) {
@@ -81,12 +84,15 @@ func (q *Queries) GetUserByID(ctx context.Context, id int64) (User, error) {
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.PurgeAfter,
+		&i.TokensValidAfter,
 	)
 	return i, err
 }
 
 const getUserByPhone = `-- name: GetUserByPhone :one
-SELECT id, phone, password_hash, phone_verified, name, business_name, city, address, location_url, plan, plan_started_at, plan_expires_at, status, created_at, updated_at FROM users WHERE phone = $1
+SELECT id, phone, password_hash, phone_verified, name, business_name, city, address, location_url, plan, plan_started_at, plan_expires_at, status, created_at, updated_at, deleted_at, purge_after, tokens_valid_after FROM users WHERE phone = $1
 `
 
 func (q *Queries) GetUserByPhone(ctx context.Context, phone string) (User, error) {
@@ -108,12 +114,24 @@ func (q *Queries) GetUserByPhone(ctx context.Context, phone string) (User, error
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.PurgeAfter,
+		&i.TokensValidAfter,
 	)
 	return i, err
 }
 
+const invalidateUserTokens = `-- name: InvalidateUserTokens :exec
+UPDATE users SET tokens_valid_after = NOW(), updated_at = NOW() WHERE id = $1
+`
+
+func (q *Queries) InvalidateUserTokens(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, invalidateUserTokens, id)
+	return err
+}
+
 const listAllUsers = `-- name: ListAllUsers :many
-SELECT id, phone, password_hash, phone_verified, name, business_name, city, address, location_url, plan, plan_started_at, plan_expires_at, status, created_at, updated_at FROM users ORDER BY created_at DESC
+SELECT id, phone, password_hash, phone_verified, name, business_name, city, address, location_url, plan, plan_started_at, plan_expires_at, status, created_at, updated_at, deleted_at, purge_after, tokens_valid_after FROM users ORDER BY created_at DESC
 `
 
 func (q *Queries) ListAllUsers(ctx context.Context) ([]User, error) {
@@ -141,6 +159,9 @@ func (q *Queries) ListAllUsers(ctx context.Context) ([]User, error) {
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.PurgeAfter,
+			&i.TokensValidAfter,
 		); err != nil {
 			return nil, err
 		}
@@ -152,6 +173,15 @@ func (q *Queries) ListAllUsers(ctx context.Context) ([]User, error) {
 	return items, nil
 }
 
+const markUserPhoneVerified = `-- name: MarkUserPhoneVerified :exec
+UPDATE users SET phone_verified = true, updated_at = NOW() WHERE phone = $1
+`
+
+func (q *Queries) MarkUserPhoneVerified(ctx context.Context, phone string) error {
+	_, err := q.db.Exec(ctx, markUserPhoneVerified, phone)
+	return err
+}
+
 const updateUser = `-- name: UpdateUser :one
 UPDATE users
 SET name = COALESCE($2, name),
@@ -161,7 +191,7 @@ SET name = COALESCE($2, name),
     location_url = COALESCE($6, location_url),
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, phone, password_hash, phone_verified, name, business_name, city, address, location_url, plan, plan_started_at, plan_expires_at, status, created_at, updated_at
+RETURNING id, phone, password_hash, phone_verified, name, business_name, city, address, location_url, plan, plan_started_at, plan_expires_at, status, created_at, updated_at, deleted_at, purge_after, tokens_valid_after
 `
 
 type UpdateUserParams struct {
@@ -199,10 +229,27 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.PurgeAfter,
+		&i.TokensValidAfter,
 	)
 	return i, err
 }
 
+const updateUserPassword = `-- name: UpdateUserPassword :exec
+UPDATE users SET password_hash = $2, updated_at = NOW() WHERE id = $1
+`
+
+type UpdateUserPasswordParams struct {
+	ID           int64  `json:"id"`
+	PasswordHash string `json:"password_hash"`
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error {
+	_, err := q.db.Exec(ctx, updateUserPassword, arg.ID, arg.PasswordHash)
+	return err
+}
+
 const updateUserPlan = `-- name: UpdateUserPlan :exec
 UPDATE users
 SET plan = $2,
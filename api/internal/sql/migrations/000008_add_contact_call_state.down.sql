@@ -0,0 +1,4 @@
+ALTER TABLE contacts
+DROP COLUMN IF EXISTS opted_out_at,
+DROP COLUMN IF EXISTS last_call_direction,
+DROP COLUMN IF EXISTS last_called_at;
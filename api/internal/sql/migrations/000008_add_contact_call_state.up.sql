@@ -0,0 +1,4 @@
+ALTER TABLE contacts
+ADD COLUMN last_called_at TIMESTAMPTZ NULL,
+ADD COLUMN last_call_direction VARCHAR(20) NULL,
+ADD COLUMN opted_out_at TIMESTAMPTZ NULL;
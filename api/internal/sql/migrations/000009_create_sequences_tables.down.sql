@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS sequence_enrollments;
+DROP TABLE IF EXISTS sequences;
@@ -0,0 +1,31 @@
+CREATE TABLE sequences (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    trigger_type VARCHAR(20) NOT NULL DEFAULT 'missed',
+    steps JSONB NOT NULL DEFAULT '[]',
+    stop_on_callback BOOLEAN NOT NULL DEFAULT true,
+    stop_on_opt_out BOOLEAN NOT NULL DEFAULT true,
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_sequences_user_id ON sequences(user_id);
+
+CREATE TABLE sequence_enrollments (
+    id BIGSERIAL PRIMARY KEY,
+    sequence_id BIGINT NOT NULL REFERENCES sequences(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    phone VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    next_step INTEGER NOT NULL DEFAULT 0,
+    next_run_at TIMESTAMPTZ,
+    stop_reason VARCHAR(30),
+    started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_sequence_enrollments_active ON sequence_enrollments(sequence_id, phone) WHERE status = 'active';
+CREATE INDEX idx_sequence_enrollments_due ON sequence_enrollments(user_id, next_run_at) WHERE status = 'active';
+CREATE INDEX idx_sequence_enrollments_phone ON sequence_enrollments(user_id, phone);
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS campaign_recipients;
+DROP TABLE IF EXISTS campaigns;
+
+DROP INDEX IF EXISTS idx_contacts_tags;
+
+ALTER TABLE contacts
+DROP COLUMN IF EXISTS tags;
@@ -0,0 +1,38 @@
+ALTER TABLE contacts
+ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX idx_contacts_tags ON contacts USING GIN(tags);
+
+CREATE TABLE campaigns (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    template_id BIGINT NOT NULL,
+    segment JSONB NOT NULL DEFAULT '{}',
+    scheduled_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    throttle_per_minute INTEGER NOT NULL DEFAULT 10,
+    status VARCHAR(20) NOT NULL DEFAULT 'scheduled',
+    total_recipients INTEGER NOT NULL DEFAULT 0,
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_campaigns_user_status ON campaigns(user_id, status);
+
+CREATE TABLE campaign_recipients (
+    id BIGSERIAL PRIMARY KEY,
+    campaign_id BIGINT NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE,
+    contact_id BIGINT REFERENCES contacts(id) ON DELETE SET NULL,
+    phone VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+    dispatched_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(campaign_id, phone)
+);
+
+CREATE INDEX idx_campaign_recipients_status ON campaign_recipients(campaign_id, status);
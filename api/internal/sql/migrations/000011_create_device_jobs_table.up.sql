@@ -0,0 +1,21 @@
+CREATE TABLE device_jobs (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    device_id VARCHAR(100),
+    job_type VARCHAR(30) NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL DEFAULT 'queued',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL DEFAULT 5,
+    run_after TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    lease_token VARCHAR(64),
+    leased_by VARCHAR(100),
+    lease_expires_at TIMESTAMPTZ,
+    last_error TEXT,
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_device_jobs_ready ON device_jobs(user_id, status, run_after);
+CREATE INDEX idx_device_jobs_lease ON device_jobs(user_id, lease_expires_at) WHERE status = 'leased';
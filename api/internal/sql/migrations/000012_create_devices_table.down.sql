@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_tokens_device_id;
+
+ALTER TABLE tokens
+DROP COLUMN IF EXISTS device_id;
+
+DROP TABLE IF EXISTS devices;
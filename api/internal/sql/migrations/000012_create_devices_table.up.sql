@@ -0,0 +1,25 @@
+CREATE TABLE devices (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    device_id VARCHAR(100) NOT NULL,
+    name VARCHAR(100),
+    model VARCHAR(100),
+    app_version VARCHAR(30),
+    sim_info JSONB NOT NULL DEFAULT '[]',
+    push_token TEXT,
+    sms_line VARCHAR(30),
+    last_seen_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(user_id, device_id)
+);
+
+-- Only one device may send SMS for a given line of an account.
+CREATE UNIQUE INDEX idx_devices_sms_line ON devices(user_id, sms_line)
+WHERE sms_line IS NOT NULL AND revoked_at IS NULL;
+
+ALTER TABLE tokens
+ADD COLUMN device_id BIGINT REFERENCES devices(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_tokens_device_id ON tokens(device_id);
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS device_alerts;
+DROP TABLE IF EXISTS device_heartbeats;
+
+ALTER TABLE devices
+DROP COLUMN IF EXISTS healthy,
+DROP COLUMN IF EXISTS health,
+DROP COLUMN IF EXISTS last_heartbeat_at;
@@ -0,0 +1,31 @@
+ALTER TABLE devices
+ADD COLUMN last_heartbeat_at TIMESTAMPTZ,
+ADD COLUMN health JSONB,
+ADD COLUMN healthy BOOLEAN NOT NULL DEFAULT true;
+
+CREATE TABLE device_heartbeats (
+    id BIGSERIAL PRIMARY KEY,
+    device_id BIGINT NOT NULL REFERENCES devices(id) ON DELETE CASCADE,
+    battery_optimized BOOLEAN NOT NULL,
+    permissions JSONB NOT NULL DEFAULT '{}',
+    app_version VARCHAR(30),
+    queue_depth INTEGER NOT NULL DEFAULT 0,
+    last_call_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_device_heartbeats_device ON device_heartbeats(device_id, created_at DESC);
+
+CREATE TABLE device_alerts (
+    id BIGSERIAL PRIMARY KEY,
+    device_id BIGINT NOT NULL REFERENCES devices(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL,
+    message TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMPTZ
+);
+
+-- A device has at most one open alert of each kind.
+CREATE UNIQUE INDEX idx_device_alerts_open ON device_alerts(device_id, kind)
+WHERE resolved_at IS NULL;
@@ -0,0 +1,12 @@
+CREATE TABLE login_attempts (
+    phone VARCHAR(20) PRIMARY KEY,
+    failed_count INTEGER NOT NULL DEFAULT 0,
+    lockouts INTEGER NOT NULL DEFAULT 0,
+    locked_until TIMESTAMPTZ,
+    last_failed_at TIMESTAMPTZ,
+    last_ip VARCHAR(45),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_login_attempts_locked_until ON login_attempts(locked_until)
+WHERE locked_until IS NOT NULL;
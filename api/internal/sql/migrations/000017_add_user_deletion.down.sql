@@ -0,0 +1,21 @@
+ALTER TABLE contacts
+DROP CONSTRAINT contacts_user_id_fkey,
+ADD CONSTRAINT contacts_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id);
+
+ALTER TABLE rules
+DROP CONSTRAINT rules_user_id_fkey,
+ADD CONSTRAINT rules_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id);
+
+ALTER TABLE templates
+DROP CONSTRAINT templates_user_id_fkey,
+ADD CONSTRAINT templates_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id);
+
+ALTER TABLE tokens
+DROP CONSTRAINT tokens_user_id_fkey,
+ADD CONSTRAINT tokens_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id);
+
+DROP INDEX IF EXISTS idx_users_purge_after;
+
+ALTER TABLE users
+DROP COLUMN IF EXISTS purge_after,
+DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,22 @@
+ALTER TABLE users
+ADD COLUMN deleted_at TIMESTAMPTZ,
+ADD COLUMN purge_after TIMESTAMPTZ;
+
+CREATE INDEX idx_users_purge_after ON users(purge_after) WHERE purge_after IS NOT NULL;
+
+-- Purging a user removes every row it owns.
+ALTER TABLE tokens
+DROP CONSTRAINT tokens_user_id_fkey,
+ADD CONSTRAINT tokens_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+ALTER TABLE templates
+DROP CONSTRAINT templates_user_id_fkey,
+ADD CONSTRAINT templates_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+ALTER TABLE rules
+DROP CONSTRAINT rules_user_id_fkey,
+ADD CONSTRAINT rules_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+ALTER TABLE contacts
+DROP CONSTRAINT contacts_user_id_fkey,
+ADD CONSTRAINT contacts_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
@@ -0,0 +1,20 @@
+CREATE TABLE lead_endpoints (
+    user_id BIGINT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    token VARCHAR(64) UNIQUE NOT NULL,
+    secret VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE leads (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    contact_id BIGINT REFERENCES contacts(id) ON DELETE SET NULL,
+    phone VARCHAR(20) NOT NULL,
+    name VARCHAR(255),
+    source VARCHAR(100) NOT NULL DEFAULT '',
+    fields JSONB NOT NULL DEFAULT '{}',
+    job_id BIGINT REFERENCES device_jobs(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_leads_user_id ON leads(user_id, created_at DESC);
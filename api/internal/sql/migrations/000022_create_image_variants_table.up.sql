@@ -0,0 +1,13 @@
+CREATE TABLE image_variants (
+    id BIGSERIAL PRIMARY KEY,
+    image_key TEXT NOT NULL,
+    variant VARCHAR(20) NOT NULL,
+    key TEXT NOT NULL,
+    url TEXT NOT NULL,
+    content_type VARCHAR(50) NOT NULL,
+    width INT NOT NULL,
+    height INT NOT NULL,
+    size_bytes INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (image_key, variant)
+);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_landing_pages_image_key;
+DROP INDEX IF EXISTS idx_templates_image_key;
+DROP TABLE IF EXISTS media;
@@ -0,0 +1,35 @@
+-- Every uploaded image is tracked here until its objects are deleted from the
+-- image store. Rows outlive their owner (user_id is cleared) so images of
+-- purged accounts are still cleaned up.
+CREATE TABLE media (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    image_key TEXT UNIQUE NOT NULL,
+    url TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'unattached',
+    unreferenced_since TIMESTAMPTZ,
+    delete_attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ,
+    last_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT media_status_check CHECK (status IN ('unattached', 'attached', 'deleting'))
+);
+
+CREATE INDEX idx_media_user_id ON media(user_id);
+CREATE INDEX idx_media_status ON media(status, next_attempt_at);
+CREATE INDEX idx_templates_image_key ON templates(image_key) WHERE image_key IS NOT NULL;
+CREATE INDEX idx_landing_pages_image_key ON landing_pages(image_key) WHERE image_key IS NOT NULL;
+
+-- Track the images uploaded before this table existed.
+INSERT INTO media (user_id, image_key, url, status)
+SELECT user_id, image_key, COALESCE(image_url, ''), 'attached'
+FROM templates
+WHERE image_key IS NOT NULL
+ON CONFLICT (image_key) DO NOTHING;
+
+INSERT INTO media (user_id, image_key, url, status)
+SELECT user_id, image_key, COALESCE(image_url, ''), 'attached'
+FROM landing_pages
+WHERE image_key IS NOT NULL
+ON CONFLICT (image_key) DO NOTHING;
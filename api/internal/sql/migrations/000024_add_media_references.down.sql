@@ -0,0 +1,2 @@
+ALTER TABLE landing_pages DROP COLUMN IF EXISTS media_id;
+ALTER TABLE templates DROP COLUMN IF EXISTS media_id;
@@ -0,0 +1,12 @@
+ALTER TABLE templates ADD COLUMN media_id BIGINT REFERENCES media(id) ON DELETE SET NULL;
+ALTER TABLE landing_pages ADD COLUMN media_id BIGINT REFERENCES media(id) ON DELETE SET NULL;
+
+UPDATE templates t
+SET media_id = m.id
+FROM media m
+WHERE m.image_key = t.image_key AND m.user_id = t.user_id;
+
+UPDATE landing_pages l
+SET media_id = m.id
+FROM media m
+WHERE m.image_key = l.image_key AND m.user_id = l.user_id;
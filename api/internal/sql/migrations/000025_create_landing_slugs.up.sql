@@ -0,0 +1,11 @@
+CREATE TABLE landing_slugs (
+    slug TEXT PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    is_current BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Each user has one current slug; the others redirect to it.
+CREATE UNIQUE INDEX idx_landing_slugs_current ON landing_slugs(user_id) WHERE is_current;
+CREATE INDEX idx_landing_slugs_user_id ON landing_slugs(user_id);
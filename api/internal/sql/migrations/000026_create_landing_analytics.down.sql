@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS landing_daily_stats;
+DROP TABLE IF EXISTS landing_links;
@@ -0,0 +1,29 @@
+-- A link is the landing URL carried by one message, identified by the short
+-- token appended to it.
+CREATE TABLE landing_links (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token TEXT NOT NULL UNIQUE,
+    source TEXT NOT NULL CHECK (source IN ('job', 'campaign', 'call', 'manual')),
+    ref_id BIGINT,
+    phone TEXT,
+    views INT NOT NULL DEFAULT 0,
+    clicks INT NOT NULL DEFAULT 0,
+    first_visit_at TIMESTAMPTZ,
+    last_visit_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_landing_links_ref ON landing_links(source, ref_id) WHERE ref_id IS NOT NULL;
+CREATE INDEX idx_landing_links_user_visit ON landing_links(user_id, last_visit_at DESC);
+
+-- Page views and button clicks, counted per UTC day
+CREATE TABLE landing_daily_stats (
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    event TEXT NOT NULL CHECK (event IN ('view', 'click')),
+    target TEXT NOT NULL DEFAULT '',
+    attributed BOOLEAN NOT NULL DEFAULT FALSE,
+    count INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, day, event, target, attributed)
+);
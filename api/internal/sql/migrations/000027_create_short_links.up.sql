@@ -0,0 +1,27 @@
+-- A short link redirects /s/<code> to a long URL. Links issued for a message
+-- (ref_id set) are unique per source and message.
+CREATE TABLE short_links (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code VARCHAR(16) UNIQUE NOT NULL,
+    url TEXT NOT NULL,
+    source VARCHAR(20) NOT NULL CHECK (source IN ('manual', 'render', 'job', 'campaign')),
+    ref_id BIGINT,
+    phone VARCHAR(30),
+    clicks INT NOT NULL DEFAULT 0,
+    last_click_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_short_links_ref ON short_links(source, ref_id) WHERE ref_id IS NOT NULL;
+CREATE INDEX idx_short_links_user_id ON short_links(user_id, created_at DESC);
+
+CREATE TABLE short_link_clicks (
+    id BIGSERIAL PRIMARY KEY,
+    short_link_id BIGINT NOT NULL REFERENCES short_links(id) ON DELETE CASCADE,
+    user_agent VARCHAR(512) NOT NULL DEFAULT '',
+    device VARCHAR(20) NOT NULL,
+    clicked_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_short_link_clicks_link ON short_link_clicks(short_link_id, clicked_at DESC);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_landing_pages_gallery_media_ids;
+ALTER TABLE landing_pages DROP COLUMN IF EXISTS gallery_media_ids;
+ALTER TABLE landing_pages DROP COLUMN IF EXISTS sections_version;
+ALTER TABLE landing_pages DROP COLUMN IF EXISTS sections;
@@ -0,0 +1,9 @@
+-- Structured content below the hero: gallery, services, hours, offer and FAQ
+-- sections in display order. sections_version is the schema of the JSON.
+ALTER TABLE landing_pages ADD COLUMN sections JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE landing_pages ADD COLUMN sections_version INT NOT NULL DEFAULT 1;
+
+-- Media used by gallery sections, kept in step with sections so the media
+-- reconciler and library can see the references.
+ALTER TABLE landing_pages ADD COLUMN gallery_media_ids BIGINT[] NOT NULL DEFAULT '{}';
+CREATE INDEX idx_landing_pages_gallery_media_ids ON landing_pages USING GIN (gallery_media_ids);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS landing_revisions;
+DROP TABLE IF EXISTS landing_drafts;
@@ -0,0 +1,49 @@
+-- Unpublished edits of a landing page, one per user. preview_token lets the
+-- owner share the draft before it goes live.
+CREATE TABLE landing_drafts (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT UNIQUE NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    headline TEXT,
+    description TEXT,
+    image_url TEXT,
+    image_key TEXT,
+    media_id BIGINT REFERENCES media(id) ON DELETE SET NULL,
+    whatsapp_url TEXT,
+    facebook_url TEXT,
+    instagram_url TEXT,
+    youtube_url TEXT,
+    email TEXT,
+    website_url TEXT,
+    sections JSONB NOT NULL DEFAULT '[]',
+    sections_version INT NOT NULL DEFAULT 1,
+    gallery_media_ids BIGINT[] NOT NULL DEFAULT '{}',
+    preview_token VARCHAR(64) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_landing_drafts_gallery_media_ids ON landing_drafts USING GIN (gallery_media_ids);
+
+-- A copy of the landing page each time it is published, numbered per user.
+-- Media are not referenced: the library may delete them, and restoring drops
+-- images that are gone.
+CREATE TABLE landing_revisions (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    number INT NOT NULL,
+    headline TEXT,
+    description TEXT,
+    image_url TEXT,
+    image_key TEXT,
+    media_id BIGINT,
+    whatsapp_url TEXT,
+    facebook_url TEXT,
+    instagram_url TEXT,
+    youtube_url TEXT,
+    email TEXT,
+    website_url TEXT,
+    sections JSONB NOT NULL DEFAULT '[]',
+    sections_version INT NOT NULL DEFAULT 1,
+    published_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, number)
+);
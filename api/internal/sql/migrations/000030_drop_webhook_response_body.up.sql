@@ -0,0 +1,2 @@
+-- Response bodies of user-chosen URLs are no longer kept in the delivery log.
+ALTER TABLE webhook_deliveries DROP COLUMN IF EXISTS response_body;
@@ -0,0 +1,3 @@
+-- Tokens issued before token IDs carry no jti and cannot be revoked one by one;
+-- they are rejected when issued before this timestamp.
+ALTER TABLE users ADD COLUMN tokens_valid_after TIMESTAMPTZ;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_landing_revisions_image_key;
+DROP INDEX IF EXISTS idx_landing_revisions_media_id;
+DROP INDEX IF EXISTS idx_landing_revisions_gallery_media_ids;
+ALTER TABLE landing_revisions DROP COLUMN IF EXISTS gallery_media_ids;
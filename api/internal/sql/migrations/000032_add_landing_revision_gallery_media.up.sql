@@ -0,0 +1,15 @@
+-- Media used by a revision, so images kept only by old revisions are not
+-- purged from the library while the revision can still be restored.
+ALTER TABLE landing_revisions ADD COLUMN gallery_media_ids BIGINT[] NOT NULL DEFAULT '{}';
+
+UPDATE landing_revisions r
+SET gallery_media_ids = ARRAY(
+    SELECT (img->>'media_id')::bigint
+    FROM jsonb_array_elements(r.sections) s,
+        jsonb_array_elements(COALESCE(s->'images', '[]'::jsonb)) img
+)
+WHERE r.sections <> '[]'::jsonb;
+
+CREATE INDEX idx_landing_revisions_gallery_media_ids ON landing_revisions USING GIN (gallery_media_ids);
+CREATE INDEX idx_landing_revisions_media_id ON landing_revisions(media_id) WHERE media_id IS NOT NULL;
+CREATE INDEX idx_landing_revisions_image_key ON landing_revisions(image_key) WHERE image_key IS NOT NULL;
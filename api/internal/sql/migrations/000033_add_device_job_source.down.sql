@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_device_jobs_source_ref;
+ALTER TABLE device_jobs DROP COLUMN IF EXISTS ref_id;
+ALTER TABLE device_jobs DROP COLUMN IF EXISTS source;
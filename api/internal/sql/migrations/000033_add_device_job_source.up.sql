@@ -0,0 +1,6 @@
+-- Sequence steps and campaign messages are sent as device jobs; source and
+-- ref_id point back at the enrollment or recipient a job belongs to.
+ALTER TABLE device_jobs ADD COLUMN source VARCHAR(30);
+ALTER TABLE device_jobs ADD COLUMN ref_id BIGINT;
+
+CREATE INDEX idx_device_jobs_source_ref ON device_jobs(source, ref_id) WHERE source IS NOT NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_leads_contact_job;
+DROP TABLE IF EXISTS enquiry_reply_counts;
@@ -0,0 +1,10 @@
+-- Auto-replies sent to landing page enquiries, counted per UTC day to cap
+-- how many texts strangers can trigger on an account's SIM.
+CREATE TABLE enquiry_reply_counts (
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    count INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, day)
+);
+
+CREATE INDEX idx_leads_contact_job ON leads(contact_id, created_at DESC) WHERE job_id IS NOT NULL;
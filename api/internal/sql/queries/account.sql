@@ -0,0 +1,62 @@
+-- name: ListCampaignRecipientsByUserID :many
+SELECT * FROM campaign_recipients
+WHERE campaign_id IN (SELECT id FROM campaigns WHERE user_id = $1)
+ORDER BY created_at;
+
+-- name: ListAllDeviceJobsByUserID :many
+SELECT * FROM device_jobs
+WHERE user_id = $1
+ORDER BY created_at;
+
+-- name: ListSequenceEnrollmentsByUserID :many
+SELECT * FROM sequence_enrollments
+WHERE user_id = $1
+ORDER BY started_at;
+
+-- name: ListAllLeadsByUserID :many
+SELECT * FROM leads
+WHERE user_id = $1
+ORDER BY created_at;
+
+-- name: SoftDeleteUser :exec
+UPDATE users
+SET status = 'deleted',
+    deleted_at = NOW(),
+    purge_after = @purge_after::timestamptz,
+    updated_at = NOW()
+WHERE id = @id;
+
+-- name: ListUsersDueForPurge :many
+SELECT * FROM users
+WHERE status = 'deleted' AND purge_after <= NOW()
+ORDER BY purge_after
+LIMIT $1;
+
+-- name: DeleteLoginAttemptsByPhone :exec
+DELETE FROM login_attempts WHERE phone = $1;
+
+-- name: DeleteOTPCodesByPhone :exec
+DELETE FROM otp_codes WHERE phone = $1;
+
+-- name: DeleteUser :exec
+DELETE FROM users WHERE id = $1;
+
+-- name: ListAllShortLinksByUserID :many
+SELECT * FROM short_links
+WHERE user_id = $1
+ORDER BY created_at;
+
+-- name: ListShortLinkClicksByUserID :many
+SELECT * FROM short_link_clicks
+WHERE short_link_id IN (SELECT id FROM short_links WHERE user_id = $1)
+ORDER BY clicked_at;
+
+-- name: ListLandingSlugsByUserID :many
+SELECT * FROM landing_slugs
+WHERE user_id = $1
+ORDER BY created_at;
+
+-- name: ListAllLandingRevisionsByUserID :many
+SELECT * FROM landing_revisions
+WHERE user_id = $1
+ORDER BY number;
@@ -0,0 +1,135 @@
+-- name: CreateCampaign :one
+INSERT INTO campaigns (user_id, name, template_id, segment, scheduled_at, throttle_per_minute)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetCampaignByID :one
+SELECT * FROM campaigns WHERE id = $1 AND user_id = $2;
+
+-- name: ListCampaignsByUserID :many
+SELECT * FROM campaigns WHERE user_id = $1 ORDER BY created_at DESC;
+
+-- name: ListDueScheduledCampaigns :many
+SELECT * FROM campaigns
+WHERE user_id = $1 AND status = 'scheduled' AND scheduled_at <= NOW()
+ORDER BY scheduled_at;
+
+-- name: ListRunningCampaigns :many
+SELECT * FROM campaigns
+WHERE user_id = $1 AND status = 'running'
+ORDER BY scheduled_at;
+
+-- name: StartCampaign :one
+UPDATE campaigns
+SET status = 'running', started_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND status = 'scheduled' AND scheduled_at <= NOW()
+RETURNING *;
+
+-- name: SetCampaignTotalRecipients :exec
+UPDATE campaigns SET total_recipients = $2, updated_at = NOW() WHERE id = $1;
+
+-- name: CompleteCampaign :exec
+UPDATE campaigns
+SET status = 'completed', completed_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND status = 'running';
+
+-- name: PauseCampaign :one
+UPDATE campaigns
+SET status = 'paused', updated_at = NOW()
+WHERE id = $1 AND user_id = $2 AND status IN ('scheduled', 'running')
+RETURNING *;
+
+-- name: ResumeCampaign :one
+UPDATE campaigns
+SET status = CASE WHEN started_at IS NULL THEN 'scheduled' ELSE 'running' END,
+    updated_at = NOW()
+WHERE id = $1 AND user_id = $2 AND status = 'paused'
+RETURNING *;
+
+-- name: CancelCampaign :one
+UPDATE campaigns
+SET status = 'cancelled', completed_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND user_id = $2 AND status IN ('scheduled', 'running', 'paused')
+RETURNING *;
+
+-- name: ExpandCampaignRecipients :execrows
+INSERT INTO campaign_recipients (campaign_id, contact_id, phone)
+SELECT @campaign_id, c.id, c.phone
+FROM contacts c
+WHERE c.user_id = @user_id
+  AND c.opted_out_at IS NULL
+  AND (cardinality(@tags::text[]) = 0 OR c.tags && @tags::text[])
+  AND (sqlc.narg(called_after)::timestamptz IS NULL OR c.last_called_at >= sqlc.narg(called_after)::timestamptz)
+  AND (sqlc.narg(call_direction)::text IS NULL OR c.last_call_direction = sqlc.narg(call_direction)::text)
+  AND (
+    @contact_mode::text = 'all'
+    OR (@contact_mode::text = 'contacts_only' AND c.name IS NOT NULL)
+    OR (@contact_mode::text = 'non_contacts_only' AND c.name IS NULL)
+  )
+ON CONFLICT (campaign_id, phone) DO NOTHING;
+
+-- name: ListCampaignRecipients :many
+SELECT * FROM campaign_recipients
+WHERE campaign_id = $1
+ORDER BY id
+LIMIT $2;
+
+-- name: CountCampaignRecipientsByStatus :many
+SELECT status, COUNT(*) AS count
+FROM campaign_recipients
+WHERE campaign_id = $1
+GROUP BY status;
+
+-- name: CountRecentCampaignDispatches :one
+SELECT COUNT(*) FROM campaign_recipients
+WHERE campaign_id = @campaign_id AND dispatched_at >= @since::timestamptz;
+
+-- name: CountOpenCampaignRecipients :one
+SELECT COUNT(*) FROM campaign_recipients
+WHERE campaign_id = $1 AND status IN ('pending', 'dispatched');
+
+-- name: ClaimCampaignRecipients :many
+UPDATE campaign_recipients
+SET status = 'dispatched',
+    attempts = attempts + 1,
+    dispatched_at = NOW()
+WHERE id IN (
+    SELECT id FROM campaign_recipients
+    WHERE campaign_id = $1
+      AND (
+        status = 'pending'
+        OR (
+          status = 'dispatched'
+          AND dispatched_at < NOW() - INTERVAL '15 minutes'
+          AND NOT EXISTS (
+            SELECT 1 FROM device_jobs j
+            WHERE j.source = 'campaign' AND j.ref_id = campaign_recipients.id
+          )
+        )
+      )
+    ORDER BY id
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: ReportCampaignRecipient :one
+UPDATE campaign_recipients
+SET status = @status,
+    error = sqlc.narg(error),
+    completed_at = NOW()
+WHERE id = @id
+  AND status = 'dispatched'
+  AND campaign_id IN (SELECT id FROM campaigns WHERE user_id = @user_id)
+RETURNING campaign_id;
+
+-- name: SkipOpenCampaignRecipients :exec
+UPDATE campaign_recipients
+SET status = 'skipped', completed_at = NOW()
+WHERE campaign_id = $1 AND status IN ('pending', 'dispatched');
+
+-- name: DeleteQueuedCampaignJobs :exec
+DELETE FROM device_jobs
+WHERE source = 'campaign'
+  AND status = 'queued'
+  AND ref_id IN (SELECT id FROM campaign_recipients WHERE campaign_id = $1);
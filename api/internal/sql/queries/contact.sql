@@ -5,11 +5,36 @@ SELECT * FROM contacts WHERE user_id = $1 ORDER BY created_at DESC;
 INSERT INTO contacts (user_id, phone, name)
 VALUES ($1, $2, $3)
 ON CONFLICT (user_id, phone) DO UPDATE
-SET name = EXCLUDED.name
-RETURNING *;
+SET name = COALESCE(EXCLUDED.name, contacts.name)
+RETURNING *, (xmax = 0)::boolean AS created;
 
--- name: UpsertContactBatch :exec
+-- name: UpsertContactBatch :many
 INSERT INTO contacts (user_id, phone, name)
-VALUES ($1, $2, $3)
+SELECT @user_id::bigint, c.phone, NULLIF(c.name, '')
+FROM unnest(@phones::text[], @names::text[]) AS c(phone, name)
 ON CONFLICT (user_id, phone) DO UPDATE
-SET name = EXCLUDED.name;
+SET name = EXCLUDED.name
+RETURNING *, (xmax = 0)::boolean AS created;
+
+-- name: GetContactByPhone :one
+SELECT * FROM contacts WHERE user_id = $1 AND phone = $2;
+
+-- name: RecordContactCall :one
+INSERT INTO contacts (user_id, phone, name, last_called_at, last_call_direction)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (user_id, phone) DO UPDATE
+SET name = COALESCE(EXCLUDED.name, contacts.name),
+    last_called_at = EXCLUDED.last_called_at,
+    last_call_direction = EXCLUDED.last_call_direction
+RETURNING *, (xmax = 0)::boolean AS created;
+
+-- name: OptOutContact :one
+INSERT INTO contacts (user_id, phone, opted_out_at)
+VALUES ($1, $2, NOW())
+ON CONFLICT (user_id, phone) DO UPDATE
+SET opted_out_at = COALESCE(contacts.opted_out_at, NOW())
+RETURNING *, (xmax = 0)::boolean AS created;
+
+-- name: SetContactTags :one
+UPDATE contacts SET tags = $3 WHERE id = $1 AND user_id = $2
+RETURNING *;
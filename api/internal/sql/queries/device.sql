@@ -0,0 +1,197 @@
+-- name: CreateDeviceJob :one
+INSERT INTO device_jobs (user_id, device_id, job_type, payload, max_attempts, run_after, source, ref_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: ListDeviceJobs :many
+SELECT * FROM device_jobs
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT $2;
+
+-- name: FailExhaustedDeviceJobs :many
+UPDATE device_jobs
+SET status = 'failed',
+    last_error = 'lease expired after final attempt',
+    lease_token = NULL,
+    lease_expires_at = NULL,
+    completed_at = NOW(),
+    updated_at = NOW()
+WHERE user_id = $1
+  AND status = 'leased'
+  AND lease_expires_at < NOW()
+  AND attempts >= max_attempts
+RETURNING *;
+
+-- name: LeaseDeviceJobs :many
+UPDATE device_jobs
+SET status = 'leased',
+    attempts = attempts + 1,
+    lease_token = @lease_token,
+    leased_by = @device_id::text,
+    lease_expires_at = @lease_expires_at::timestamptz,
+    updated_at = NOW()
+WHERE id IN (
+    SELECT id FROM device_jobs
+    WHERE device_jobs.user_id = @user_id
+      AND (device_jobs.device_id IS NULL OR device_jobs.device_id = @device_id::text)
+      AND (
+        (status = 'queued' AND run_after <= NOW())
+        OR (status = 'leased' AND lease_expires_at < NOW())
+      )
+    ORDER BY run_after, id
+    LIMIT @max_jobs::int
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: AckDeviceJob :one
+UPDATE device_jobs
+SET status = 'done',
+    lease_token = NULL,
+    lease_expires_at = NULL,
+    completed_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1 AND user_id = $2 AND status = 'leased' AND lease_token = $3
+RETURNING *;
+
+-- name: FailDeviceJob :one
+UPDATE device_jobs
+SET status = CASE WHEN @retryable::boolean AND attempts < max_attempts THEN 'queued' ELSE 'failed' END,
+    run_after = NOW() + INTERVAL '30 seconds' * power(2, LEAST(attempts, 10) - 1),
+    last_error = @last_error,
+    lease_token = NULL,
+    lease_expires_at = NULL,
+    completed_at = CASE WHEN @retryable::boolean AND attempts < max_attempts THEN NULL ELSE NOW() END,
+    updated_at = NOW()
+WHERE id = @id AND user_id = @user_id AND status = 'leased' AND lease_token = @lease_token
+RETURNING *;
+
+-- name: UpsertDevice :one
+INSERT INTO devices (user_id, device_id, name, model, app_version, sim_info, push_token, last_seen_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+ON CONFLICT (user_id, device_id) DO UPDATE SET
+    name = COALESCE(EXCLUDED.name, devices.name),
+    model = COALESCE(EXCLUDED.model, devices.model),
+    app_version = COALESCE(EXCLUDED.app_version, devices.app_version),
+    sim_info = EXCLUDED.sim_info,
+    push_token = COALESCE(EXCLUDED.push_token, devices.push_token),
+    last_seen_at = NOW(),
+    revoked_at = NULL,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: ListDevicesByUserID :many
+SELECT * FROM devices
+WHERE (user_id = $1 OR user_id IN (
+    SELECT m.user_id FROM organization_members m
+    JOIN organizations o ON o.id = m.organization_id
+    WHERE o.owner_id = $1
+  ))
+  AND revoked_at IS NULL
+ORDER BY last_seen_at DESC NULLS LAST;
+
+-- name: GetDeviceByID :one
+SELECT * FROM devices
+WHERE id = $1
+  AND (user_id = $2 OR user_id IN (
+    SELECT m.user_id FROM organization_members m
+    JOIN organizations o ON o.id = m.organization_id
+    WHERE o.owner_id = $2
+  ))
+  AND revoked_at IS NULL;
+
+-- name: ListAssignedSMSLines :many
+SELECT sms_line::text FROM devices
+WHERE (user_id = $1 OR user_id IN (
+    SELECT m.user_id FROM organization_members m
+    JOIN organizations o ON o.id = m.organization_id
+    WHERE o.owner_id = $1
+  ))
+  AND sms_line IS NOT NULL AND revoked_at IS NULL
+ORDER BY sms_line;
+
+-- name: ClearDeviceSMSLine :exec
+UPDATE devices
+SET sms_line = NULL, updated_at = NOW()
+WHERE (user_id = $1 OR user_id IN (
+    SELECT m.user_id FROM organization_members m
+    JOIN organizations o ON o.id = m.organization_id
+    WHERE o.owner_id = $1
+  ))
+  AND sms_line = $2;
+
+-- name: SetDeviceSMSLine :one
+UPDATE devices
+SET sms_line = $3, updated_at = NOW()
+WHERE id = $1
+  AND (user_id = $2 OR user_id IN (
+    SELECT m.user_id FROM organization_members m
+    JOIN organizations o ON o.id = m.organization_id
+    WHERE o.owner_id = $2
+  ))
+  AND revoked_at IS NULL
+RETURNING *;
+
+-- name: RevokeDevice :one
+UPDATE devices
+SET revoked_at = NOW(), sms_line = NULL, push_token = NULL, updated_at = NOW()
+WHERE id = $1
+  AND (user_id = $2 OR user_id IN (
+    SELECT m.user_id FROM organization_members m
+    JOIN organizations o ON o.id = m.organization_id
+    WHERE o.owner_id = $2
+  ))
+  AND revoked_at IS NULL
+RETURNING *;
+
+-- name: GetDeviceByDeviceID :one
+SELECT * FROM devices WHERE user_id = $1 AND device_id = $2 AND revoked_at IS NULL;
+
+-- name: CreateDeviceHeartbeat :exec
+INSERT INTO device_heartbeats (device_id, battery_optimized, permissions, app_version, queue_depth, last_call_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: UpdateDeviceHealth :one
+UPDATE devices
+SET health = $2,
+    healthy = $3,
+    app_version = COALESCE($4, app_version),
+    last_heartbeat_at = NOW(),
+    last_seen_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteDeviceHeartbeatsBefore :execrows
+DELETE FROM device_heartbeats WHERE created_at < $1;
+
+-- name: OpenDeviceAlert :exec
+INSERT INTO device_alerts (device_id, user_id, kind, message)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (device_id, kind) WHERE resolved_at IS NULL DO NOTHING;
+
+-- name: ResolveDeviceAlert :exec
+UPDATE device_alerts
+SET resolved_at = NOW()
+WHERE device_id = $1 AND kind = $2 AND resolved_at IS NULL;
+
+-- name: OpenSilentDeviceAlerts :execrows
+INSERT INTO device_alerts (device_id, user_id, kind, message)
+SELECT id, user_id, 'silent', 'No heartbeat received since ' || to_char(last_heartbeat_at AT TIME ZONE 'UTC', 'YYYY-MM-DD HH24:MI') || ' UTC'
+FROM devices
+WHERE revoked_at IS NULL
+  AND last_heartbeat_at < @silent_before::timestamptz
+ON CONFLICT (device_id, kind) WHERE resolved_at IS NULL DO NOTHING;
+
+-- name: ListUnhealthyDevices :many
+SELECT * FROM devices
+WHERE revoked_at IS NULL
+  AND (healthy = false OR last_heartbeat_at < @silent_before::timestamptz)
+ORDER BY last_heartbeat_at NULLS FIRST;
+
+-- name: ListOpenDeviceAlerts :many
+SELECT * FROM device_alerts
+WHERE resolved_at IS NULL
+ORDER BY created_at DESC
+LIMIT $1;
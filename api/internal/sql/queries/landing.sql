@@ -13,9 +13,13 @@ INSERT INTO landing_pages (
   instagram_url,
   youtube_url,
   email,
-  website_url
+  website_url,
+  media_id,
+  sections,
+  sections_version,
+  gallery_media_ids
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
 )
 ON CONFLICT (user_id) DO UPDATE
 SET headline = EXCLUDED.headline,
@@ -28,5 +32,177 @@ SET headline = EXCLUDED.headline,
     youtube_url = EXCLUDED.youtube_url,
     email = EXCLUDED.email,
     website_url = EXCLUDED.website_url,
+    media_id = EXCLUDED.media_id,
+    sections = EXCLUDED.sections,
+    sections_version = EXCLUDED.sections_version,
+    gallery_media_ids = EXCLUDED.gallery_media_ids,
     updated_at = NOW()
 RETURNING *;
+
+-- name: ListLandingsWithImage :many
+SELECT * FROM landing_pages WHERE image_key IS NOT NULL ORDER BY id;
+
+-- name: ReplaceLandingImage :execrows
+UPDATE landing_pages
+SET image_url = @image_url,
+    image_key = @image_key
+WHERE user_id = @user_id AND image_key = @old_image_key;
+
+-- name: GetLandingSlug :one
+SELECT * FROM landing_slugs WHERE slug = $1;
+
+-- name: GetCurrentLandingSlug :one
+SELECT * FROM landing_slugs WHERE user_id = $1 AND is_current;
+
+-- name: ClearCurrentLandingSlug :exec
+UPDATE landing_slugs
+SET is_current = FALSE,
+    updated_at = NOW()
+WHERE user_id = $1 AND is_current;
+
+-- name: SetCurrentLandingSlug :one
+INSERT INTO landing_slugs (slug, user_id, is_current)
+VALUES ($1, $2, TRUE)
+ON CONFLICT (slug) DO UPDATE
+SET is_current = TRUE,
+    updated_at = NOW()
+WHERE landing_slugs.user_id = EXCLUDED.user_id
+RETURNING *;
+
+-- name: CreateLandingLink :one
+INSERT INTO landing_links (user_id, token, source, ref_id, phone)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (source, ref_id) WHERE ref_id IS NOT NULL DO UPDATE
+SET phone = COALESCE(EXCLUDED.phone, landing_links.phone)
+RETURNING *;
+
+-- name: RecordLandingLinkVisit :execrows
+UPDATE landing_links
+SET views = views + CASE WHEN @event::text = 'view' THEN 1 ELSE 0 END,
+    clicks = clicks + CASE WHEN @event::text = 'click' THEN 1 ELSE 0 END,
+    first_visit_at = COALESCE(first_visit_at, NOW()),
+    last_visit_at = NOW()
+WHERE token = @token AND user_id = @user_id;
+
+-- name: IncrementLandingDailyStat :exec
+INSERT INTO landing_daily_stats (user_id, day, event, target, attributed, count)
+VALUES ($1, $2, $3, $4, $5, 1)
+ON CONFLICT (user_id, day, event, target, attributed) DO UPDATE
+SET count = landing_daily_stats.count + 1;
+
+-- name: ListLandingDailyStats :many
+SELECT * FROM landing_daily_stats
+WHERE user_id = @user_id AND day >= @from_day::date AND day <= @to_day::date
+ORDER BY day, event, target;
+
+-- name: ListVisitedLandingLinks :many
+SELECT * FROM landing_links
+WHERE user_id = @user_id AND last_visit_at >= @visited_since::timestamptz
+ORDER BY last_visit_at DESC
+LIMIT @max_links::int;
+
+-- name: GetLandingDraftByUserID :one
+SELECT * FROM landing_drafts WHERE user_id = $1;
+
+-- name: UpsertLandingDraftByUserID :one
+-- An existing draft keeps its preview token.
+INSERT INTO landing_drafts (
+  user_id,
+  headline,
+  description,
+  image_url,
+  image_key,
+  whatsapp_url,
+  facebook_url,
+  instagram_url,
+  youtube_url,
+  email,
+  website_url,
+  media_id,
+  sections,
+  sections_version,
+  gallery_media_ids,
+  preview_token
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
+)
+ON CONFLICT (user_id) DO UPDATE
+SET headline = EXCLUDED.headline,
+    description = EXCLUDED.description,
+    image_url = EXCLUDED.image_url,
+    image_key = EXCLUDED.image_key,
+    whatsapp_url = EXCLUDED.whatsapp_url,
+    facebook_url = EXCLUDED.facebook_url,
+    instagram_url = EXCLUDED.instagram_url,
+    youtube_url = EXCLUDED.youtube_url,
+    email = EXCLUDED.email,
+    website_url = EXCLUDED.website_url,
+    media_id = EXCLUDED.media_id,
+    sections = EXCLUDED.sections,
+    sections_version = EXCLUDED.sections_version,
+    gallery_media_ids = EXCLUDED.gallery_media_ids,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: SetLandingDraftPreviewToken :one
+UPDATE landing_drafts
+SET preview_token = $2,
+    updated_at = NOW()
+WHERE user_id = $1
+RETURNING *;
+
+-- name: DeleteLandingDraft :execrows
+DELETE FROM landing_drafts WHERE user_id = $1;
+
+-- name: CreateLandingRevision :one
+-- Copies the live page as the user's next revision.
+INSERT INTO landing_revisions (
+  user_id,
+  number,
+  headline,
+  description,
+  image_url,
+  image_key,
+  media_id,
+  whatsapp_url,
+  facebook_url,
+  instagram_url,
+  youtube_url,
+  email,
+  website_url,
+  sections,
+  sections_version,
+  gallery_media_ids
+)
+SELECT l.user_id,
+    COALESCE((SELECT MAX(r.number) FROM landing_revisions r WHERE r.user_id = l.user_id), 0) + 1,
+    l.headline,
+    l.description,
+    l.image_url,
+    l.image_key,
+    l.media_id,
+    l.whatsapp_url,
+    l.facebook_url,
+    l.instagram_url,
+    l.youtube_url,
+    l.email,
+    l.website_url,
+    l.sections,
+    l.sections_version,
+    l.gallery_media_ids
+FROM landing_pages l
+WHERE l.user_id = $1
+RETURNING *;
+
+-- name: PruneLandingRevisions :exec
+DELETE FROM landing_revisions
+WHERE user_id = @user_id AND number <= @newest_number::int - @keep::int;
+
+-- name: ListLandingRevisionsByUserID :many
+SELECT * FROM landing_revisions
+WHERE user_id = $1
+ORDER BY number DESC
+LIMIT $2;
+
+-- name: GetLandingRevisionByID :one
+SELECT * FROM landing_revisions WHERE id = $1 AND user_id = $2;
@@ -0,0 +1,47 @@
+-- name: UpsertLeadEndpoint :one
+INSERT INTO lead_endpoints (user_id, token, secret)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id) DO UPDATE
+SET token = EXCLUDED.token, secret = EXCLUDED.secret, created_at = NOW()
+RETURNING *;
+
+-- name: GetLeadEndpointByUserID :one
+SELECT * FROM lead_endpoints WHERE user_id = $1;
+
+-- name: GetLeadEndpointByToken :one
+SELECT * FROM lead_endpoints WHERE token = $1;
+
+-- name: DeleteLeadEndpoint :execrows
+DELETE FROM lead_endpoints WHERE user_id = $1;
+
+-- name: CreateLead :one
+INSERT INTO leads (user_id, contact_id, phone, name, source, fields)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: SetLeadJob :exec
+UPDATE leads SET job_id = $2 WHERE id = $1;
+
+-- name: ListLeadsByUserID :many
+SELECT * FROM leads
+WHERE user_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2;
+
+-- name: HasRecentLeadReply :one
+SELECT EXISTS (
+    SELECT 1 FROM leads
+    WHERE user_id = @user_id
+      AND contact_id = @contact_id
+      AND source = @source
+      AND job_id IS NOT NULL
+      AND created_at >= @since::timestamptz
+);
+
+-- name: ReserveEnquiryReply :one
+INSERT INTO enquiry_reply_counts (user_id, day, count)
+VALUES (@user_id, @day, 1)
+ON CONFLICT (user_id, day) DO UPDATE
+SET count = enquiry_reply_counts.count + 1
+WHERE enquiry_reply_counts.count < @max_replies::int
+RETURNING count;
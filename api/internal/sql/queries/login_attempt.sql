@@ -0,0 +1,28 @@
+-- name: GetLoginAttempt :one
+SELECT * FROM login_attempts WHERE phone = $1;
+
+-- name: RecordLoginFailure :one
+INSERT INTO login_attempts (phone, failed_count, last_failed_at, last_ip)
+VALUES (@phone, 1, NOW(), @last_ip::text)
+ON CONFLICT (phone) DO UPDATE SET
+    failed_count = login_attempts.failed_count + 1,
+    last_failed_at = NOW(),
+    last_ip = EXCLUDED.last_ip,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: LockLoginAccount :exec
+UPDATE login_attempts
+SET locked_until = @locked_until::timestamptz,
+    lockouts = lockouts + 1,
+    failed_count = 0,
+    updated_at = NOW()
+WHERE phone = @phone;
+
+-- name: ResetLoginAttempts :exec
+DELETE FROM login_attempts WHERE phone = $1;
+
+-- name: ListLockedLoginAccounts :many
+SELECT * FROM login_attempts
+WHERE locked_until > NOW()
+ORDER BY locked_until DESC;
@@ -0,0 +1,143 @@
+-- name: CreateImageVariant :one
+INSERT INTO image_variants (image_key, variant, key, url, content_type, width, height, size_bytes)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: ListImageVariants :many
+SELECT * FROM image_variants WHERE image_key = $1 ORDER BY id;
+
+-- name: DeleteImageVariants :exec
+DELETE FROM image_variants WHERE image_key = $1;
+
+-- name: CreateMedia :one
+INSERT INTO media (user_id, image_key, url, status, unreferenced_since)
+VALUES ($1, $2, $3, 'unattached', NOW())
+RETURNING *;
+
+-- name: MarkMediaDeleting :exec
+INSERT INTO media (image_key, status, next_attempt_at)
+VALUES (@image_key, 'deleting', NOW())
+ON CONFLICT (image_key) DO UPDATE
+SET status = 'deleting',
+    next_attempt_at = NOW(),
+    updated_at = NOW();
+
+-- name: MarkUserMediaDeleting :exec
+UPDATE media
+SET status = 'deleting',
+    next_attempt_at = NOW(),
+    updated_at = NOW()
+WHERE user_id = $1 AND status <> 'deleting';
+
+-- name: RenameMedia :exec
+UPDATE media
+SET image_key = @new_image_key,
+    url = @url,
+    updated_at = NOW()
+WHERE image_key = @image_key;
+
+-- name: MarkReferencedMedia :execrows
+UPDATE media
+SET status = 'attached',
+    unreferenced_since = NULL,
+    delete_attempts = 0,
+    next_attempt_at = NULL,
+    last_error = NULL,
+    updated_at = NOW()
+WHERE status <> 'attached'
+  AND (EXISTS (SELECT 1 FROM templates t WHERE t.image_key = media.image_key)
+    OR EXISTS (SELECT 1 FROM landing_pages l WHERE l.image_key = media.image_key)
+    OR EXISTS (SELECT 1 FROM landing_pages l WHERE l.gallery_media_ids @> ARRAY[media.id])
+    OR EXISTS (SELECT 1 FROM landing_drafts d WHERE d.media_id = media.id OR d.gallery_media_ids @> ARRAY[media.id])
+    OR EXISTS (SELECT 1 FROM landing_revisions r WHERE r.media_id = media.id OR r.image_key = media.image_key OR r.gallery_media_ids @> ARRAY[media.id]));
+
+-- name: MarkUnreferencedMedia :execrows
+-- Media that were attached once stay in the library, so unreferenced_since is
+-- left unset and they never expire.
+UPDATE media
+SET status = 'unattached',
+    updated_at = NOW()
+WHERE status = 'attached'
+  AND NOT EXISTS (SELECT 1 FROM templates t WHERE t.image_key = media.image_key)
+  AND NOT EXISTS (SELECT 1 FROM landing_pages l WHERE l.image_key = media.image_key)
+  AND NOT EXISTS (SELECT 1 FROM landing_pages l WHERE l.gallery_media_ids @> ARRAY[media.id])
+  AND NOT EXISTS (SELECT 1 FROM landing_drafts d WHERE d.media_id = media.id OR d.gallery_media_ids @> ARRAY[media.id])
+  AND NOT EXISTS (SELECT 1 FROM landing_revisions r WHERE r.media_id = media.id OR r.image_key = media.image_key OR r.gallery_media_ids @> ARRAY[media.id]);
+
+-- name: ExpireUnattachedMedia :execrows
+UPDATE media
+SET status = 'deleting',
+    next_attempt_at = NOW(),
+    updated_at = NOW()
+WHERE status = 'unattached' AND unreferenced_since < @unreferenced_before::timestamptz;
+
+-- name: ClaimDeletingMedia :many
+UPDATE media
+SET delete_attempts = delete_attempts + 1,
+    next_attempt_at = @locked_until::timestamptz,
+    updated_at = NOW()
+WHERE id IN (
+    SELECT m.id FROM media m
+    WHERE m.status = 'deleting'
+      AND m.next_attempt_at <= NOW()
+      AND NOT EXISTS (SELECT 1 FROM templates t WHERE t.image_key = m.image_key)
+      AND NOT EXISTS (SELECT 1 FROM landing_pages l WHERE l.image_key = m.image_key)
+      AND NOT EXISTS (SELECT 1 FROM landing_pages l WHERE l.gallery_media_ids @> ARRAY[m.id])
+      AND NOT EXISTS (SELECT 1 FROM landing_drafts d WHERE d.media_id = m.id OR d.gallery_media_ids @> ARRAY[m.id])
+      AND NOT EXISTS (SELECT 1 FROM landing_revisions r WHERE r.media_id = m.id OR r.image_key = m.image_key OR r.gallery_media_ids @> ARRAY[m.id])
+    ORDER BY m.next_attempt_at, m.id
+    LIMIT @max_media::int
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: DeleteMedia :exec
+DELETE FROM media WHERE id = $1;
+
+-- name: FailMediaDeletion :exec
+UPDATE media
+SET next_attempt_at = NOW() + INTERVAL '1 minute' * power(2, LEAST(delete_attempts, 10) - 1),
+    last_error = @last_error::text,
+    updated_at = NOW()
+WHERE id = @id;
+
+-- name: ListMediaItemsByUserID :many
+SELECT m.id, m.image_key, m.url, m.created_at,
+    COALESCE(o.content_type, '')::text AS content_type,
+    COALESCE(o.width, 0)::int AS width,
+    COALESCE(o.height, 0)::int AS height,
+    COALESCE(o.size_bytes, 0)::int AS size_bytes,
+    COALESCE(t.url, '')::text AS thumbnail_url,
+    ((SELECT COUNT(*) FROM templates tp WHERE tp.image_key = m.image_key)
+        + (SELECT COUNT(*) FROM landing_pages lp WHERE lp.image_key = m.image_key)
+        + (SELECT COUNT(*) FROM landing_pages lg WHERE lg.gallery_media_ids @> ARRAY[m.id])
+        + (SELECT COUNT(*) FROM landing_drafts ld WHERE ld.media_id = m.id OR ld.gallery_media_ids @> ARRAY[m.id])
+        + (SELECT COUNT(*) FROM landing_revisions lr WHERE lr.media_id = m.id OR lr.image_key = m.image_key OR lr.gallery_media_ids @> ARRAY[m.id]))::int AS usage_count
+FROM media m
+LEFT JOIN image_variants o ON o.image_key = m.image_key AND o.variant = 'original'
+LEFT JOIN image_variants t ON t.image_key = m.image_key AND t.variant = 'thumbnail'
+WHERE m.user_id = $1 AND m.status <> 'deleting'
+ORDER BY m.created_at DESC, m.id DESC;
+
+-- name: GetMediaItem :one
+SELECT m.id, m.image_key, m.url, m.created_at,
+    COALESCE(o.content_type, '')::text AS content_type,
+    COALESCE(o.width, 0)::int AS width,
+    COALESCE(o.height, 0)::int AS height,
+    COALESCE(o.size_bytes, 0)::int AS size_bytes,
+    COALESCE(t.url, '')::text AS thumbnail_url,
+    ((SELECT COUNT(*) FROM templates tp WHERE tp.image_key = m.image_key)
+        + (SELECT COUNT(*) FROM landing_pages lp WHERE lp.image_key = m.image_key)
+        + (SELECT COUNT(*) FROM landing_pages lg WHERE lg.gallery_media_ids @> ARRAY[m.id])
+        + (SELECT COUNT(*) FROM landing_drafts ld WHERE ld.media_id = m.id OR ld.gallery_media_ids @> ARRAY[m.id])
+        + (SELECT COUNT(*) FROM landing_revisions lr WHERE lr.media_id = m.id OR lr.image_key = m.image_key OR lr.gallery_media_ids @> ARRAY[m.id]))::int AS usage_count
+FROM media m
+LEFT JOIN image_variants o ON o.image_key = m.image_key AND o.variant = 'original'
+LEFT JOIN image_variants t ON t.image_key = m.image_key AND t.variant = 'thumbnail'
+WHERE m.id = $1 AND m.user_id = $2 AND m.status <> 'deleting';
+
+-- name: GetMediaByID :one
+SELECT * FROM media WHERE id = $1 AND user_id = $2 AND status <> 'deleting';
+
+-- name: GetMediaByImageKey :one
+SELECT * FROM media WHERE image_key = $1 AND user_id = $2 AND status <> 'deleting';
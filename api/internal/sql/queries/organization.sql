@@ -0,0 +1,72 @@
+-- name: CreateOrganization :one
+INSERT INTO organizations (owner_id, name)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetOrganizationByID :one
+SELECT * FROM organizations WHERE id = $1;
+
+-- name: GetOrganizationByOwnerID :one
+SELECT * FROM organizations WHERE owner_id = $1;
+
+-- name: UpdateOrganizationName :one
+UPDATE organizations
+SET name = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: AddOrganizationMember :exec
+INSERT INTO organization_members (organization_id, user_id, role)
+VALUES ($1, $2, $3)
+ON CONFLICT (organization_id, user_id) DO UPDATE SET role = EXCLUDED.role, updated_at = NOW();
+
+-- name: GetOrganizationMember :one
+SELECT * FROM organization_members
+WHERE organization_id = $1 AND user_id = $2;
+
+-- name: ListOrganizationMembers :many
+SELECT * FROM organization_members
+WHERE organization_id = $1
+ORDER BY created_at;
+
+-- name: ListOrganizationMembershipsByUserID :many
+SELECT * FROM organization_members
+WHERE user_id = $1
+ORDER BY created_at;
+
+-- name: UpdateOrganizationMemberRole :execrows
+UPDATE organization_members
+SET role = $3, updated_at = NOW()
+WHERE organization_id = $1 AND user_id = $2 AND role <> 'owner';
+
+-- name: DeleteOrganizationMember :execrows
+DELETE FROM organization_members
+WHERE organization_id = $1 AND user_id = $2 AND role <> 'owner';
+
+-- name: CreateOrganizationInvitation :one
+INSERT INTO organization_invitations (organization_id, phone, role, invited_by, expires_at)
+VALUES (@organization_id, @phone, @role, @invited_by, @expires_at::timestamptz)
+RETURNING *;
+
+-- name: GetOrganizationInvitationByID :one
+SELECT * FROM organization_invitations WHERE id = $1;
+
+-- name: ListOrganizationInvitations :many
+SELECT * FROM organization_invitations
+WHERE organization_id = $1 AND status = 'pending'
+ORDER BY created_at DESC;
+
+-- name: ListPendingInvitationsByPhone :many
+SELECT * FROM organization_invitations
+WHERE phone = $1 AND status = 'pending' AND expires_at > NOW()
+ORDER BY created_at DESC;
+
+-- name: RespondOrganizationInvitation :execrows
+UPDATE organization_invitations
+SET status = $2, responded_at = NOW()
+WHERE id = $1 AND status = 'pending';
+
+-- name: ExpireOrganizationInvitations :exec
+UPDATE organization_invitations
+SET status = 'expired'
+WHERE organization_id = $1 AND phone = $2 AND status = 'pending' AND expires_at <= NOW();
@@ -0,0 +1,31 @@
+-- name: CreateOTPCode :one
+INSERT INTO otp_codes (phone, purpose, code_hash, max_attempts, expires_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetActiveOTPCode :one
+SELECT * FROM otp_codes
+WHERE phone = $1 AND purpose = $2 AND consumed_at IS NULL
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: CountRecentOTPCodes :one
+SELECT COUNT(*) FROM otp_codes
+WHERE phone = @phone AND purpose = @purpose AND created_at >= @since::timestamptz;
+
+-- name: UseOTPAttempt :one
+-- Counts a guess in the same statement that checks the cap, so concurrent
+-- guesses cannot all pass it.
+UPDATE otp_codes SET attempts = attempts + 1
+WHERE id = $1 AND attempts < max_attempts
+RETURNING attempts;
+
+-- name: ConsumeOTPCode :execrows
+UPDATE otp_codes SET consumed_at = NOW() WHERE id = $1 AND consumed_at IS NULL;
+
+-- name: InvalidateOTPCodes :exec
+UPDATE otp_codes SET consumed_at = NOW()
+WHERE phone = $1 AND purpose = $2 AND consumed_at IS NULL;
+
+-- name: DeleteExpiredOTPCodes :exec
+DELETE FROM otp_codes WHERE expires_at < $1;
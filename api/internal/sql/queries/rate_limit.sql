@@ -0,0 +1,13 @@
+-- name: HitRateLimitBucket :one
+INSERT INTO rate_limit_buckets (key, window_start, count, expires_at)
+VALUES (@key, @window_start::timestamptz, 1, @expires_at::timestamptz)
+ON CONFLICT (key, window_start) DO UPDATE SET count = rate_limit_buckets.count + 1
+WHERE rate_limit_buckets.count < @max_count::int
+RETURNING count;
+
+-- name: GetRateLimitBucketCount :one
+SELECT count FROM rate_limit_buckets
+WHERE key = @key AND window_start = @window_start::timestamptz;
+
+-- name: DeleteExpiredRateLimitBuckets :exec
+DELETE FROM rate_limit_buckets WHERE expires_at < NOW();
@@ -0,0 +1,94 @@
+-- name: ListSequencesByUserID :many
+SELECT * FROM sequences WHERE user_id = $1 ORDER BY created_at DESC;
+
+-- name: ListEnabledSequencesByTrigger :many
+SELECT * FROM sequences
+WHERE user_id = $1 AND enabled = true AND (trigger_type = $2 OR trigger_type = 'all')
+ORDER BY id;
+
+-- name: GetSequenceByID :one
+SELECT * FROM sequences WHERE id = $1 AND user_id = $2;
+
+-- name: CreateSequence :one
+INSERT INTO sequences (user_id, name, trigger_type, steps, stop_on_callback, stop_on_opt_out, enabled)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: UpdateSequence :one
+UPDATE sequences
+SET name = $3,
+    trigger_type = $4,
+    steps = $5,
+    stop_on_callback = $6,
+    stop_on_opt_out = $7,
+    enabled = $8,
+    updated_at = NOW()
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: DeleteSequence :exec
+DELETE FROM sequences WHERE id = $1 AND user_id = $2;
+
+-- name: CreateSequenceEnrollment :one
+-- A phone that called back while enrolled is not enrolled in that sequence again.
+INSERT INTO sequence_enrollments (sequence_id, user_id, phone, next_run_at)
+SELECT @sequence_id::bigint, @user_id::bigint, @phone::text, @next_run_at::timestamptz
+WHERE NOT EXISTS (
+    SELECT 1 FROM sequence_enrollments
+    WHERE user_id = @user_id AND phone = @phone AND sequence_id = @sequence_id AND stop_reason = 'called_back'
+)
+ON CONFLICT (sequence_id, phone) WHERE status = 'active' DO NOTHING
+RETURNING *;
+
+-- name: ListSequenceEnrollments :many
+SELECT * FROM sequence_enrollments
+WHERE sequence_id = $1 AND user_id = $2
+ORDER BY started_at DESC
+LIMIT $3;
+
+-- name: ClaimDueSequenceEnrollments :many
+UPDATE sequence_enrollments
+SET next_run_at = NOW() + INTERVAL '5 minutes',
+    updated_at = NOW()
+WHERE id IN (
+    SELECT id FROM sequence_enrollments
+    WHERE user_id = $1 AND status = 'active' AND next_run_at <= NOW()
+    ORDER BY next_run_at
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: AdvanceSequenceEnrollment :execrows
+UPDATE sequence_enrollments
+SET next_step = $2,
+    next_run_at = $3,
+    status = $4,
+    updated_at = NOW()
+WHERE id = $1 AND status = 'active';
+
+-- name: StopSequenceEnrollment :exec
+UPDATE sequence_enrollments
+SET status = 'stopped',
+    stop_reason = $2,
+    next_run_at = NULL,
+    updated_at = NOW()
+WHERE id = $1;
+
+-- name: StopSequenceEnrollmentsOnCallback :execrows
+UPDATE sequence_enrollments
+SET status = 'stopped',
+    stop_reason = 'called_back',
+    next_run_at = NULL,
+    updated_at = NOW()
+WHERE user_id = $1 AND phone = $2 AND status = 'active'
+  AND sequence_id IN (SELECT id FROM sequences WHERE stop_on_callback = true);
+
+-- name: StopSequenceEnrollmentsOnOptOut :execrows
+UPDATE sequence_enrollments
+SET status = 'stopped',
+    stop_reason = 'opted_out',
+    next_run_at = NULL,
+    updated_at = NOW()
+WHERE user_id = $1 AND phone = $2 AND status = 'active'
+  AND sequence_id IN (SELECT id FROM sequences WHERE stop_on_opt_out = true);
@@ -0,0 +1,36 @@
+-- name: CreateShortLink :one
+INSERT INTO short_links (user_id, code, url, source, ref_id, phone)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (source, ref_id) WHERE ref_id IS NOT NULL DO UPDATE
+SET url = EXCLUDED.url
+RETURNING *;
+
+-- name: GetShortLinkByCode :one
+SELECT * FROM short_links WHERE code = $1;
+
+-- name: GetShortLinkByID :one
+SELECT * FROM short_links WHERE id = $1 AND user_id = $2;
+
+-- name: GetShortLinkByRef :one
+SELECT * FROM short_links WHERE source = $1 AND ref_id = $2 AND user_id = $3;
+
+-- name: ListShortLinksByUserID :many
+SELECT * FROM short_links
+WHERE user_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2;
+
+-- name: CreateShortLinkClick :exec
+INSERT INTO short_link_clicks (short_link_id, user_agent, device)
+VALUES ($1, $2, $3);
+
+-- name: IncrementShortLinkClicks :exec
+UPDATE short_links
+SET clicks = clicks + 1, last_click_at = NOW()
+WHERE id = $1;
+
+-- name: ListShortLinkClicks :many
+SELECT * FROM short_link_clicks
+WHERE short_link_id = $1
+ORDER BY clicked_at DESC, id DESC
+LIMIT $2;
@@ -5,8 +5,8 @@ SELECT * FROM templates WHERE user_id = $1 ORDER BY created_at DESC;
 SELECT * FROM templates WHERE id = $1 AND user_id = $2;
 
 -- name: CreateTemplate :one
-INSERT INTO templates (user_id, name, body, type, channel, image_url, image_key, language, is_default)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+INSERT INTO templates (user_id, name, body, type, channel, image_url, image_key, language, is_default, media_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 RETURNING *;
 
 -- name: UpdateTemplate :one
@@ -19,9 +19,19 @@ SET name = $3,
     image_key = $8,
     language = $9,
     is_default = $10,
+    media_id = $11,
     updated_at = NOW()
 WHERE id = $1 AND user_id = $2
 RETURNING *;
 
 -- name: DeleteTemplate :exec
 DELETE FROM templates WHERE id = $1 AND user_id = $2;
+
+-- name: ListTemplatesWithImage :many
+SELECT * FROM templates WHERE image_key IS NOT NULL ORDER BY id;
+
+-- name: ReplaceTemplateImage :execrows
+UPDATE templates
+SET image_url = @image_url,
+    image_key = @image_key
+WHERE id = @id AND image_key = @old_image_key;
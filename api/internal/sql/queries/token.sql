@@ -20,3 +20,13 @@ UPDATE tokens SET last_used_at = NOW() WHERE id = $1;
 
 -- name: DeleteExpiredTokens :exec
 DELETE FROM tokens WHERE expires_at < $1;
+
+-- name: CreateDeviceToken :exec
+INSERT INTO tokens (user_id, token, expires_at, token_type, device_id)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: RevokeDeviceTokens :exec
+UPDATE tokens SET is_revoked = true WHERE device_id = $1;
+
+-- name: RevokeOtherUserTokens :exec
+UPDATE tokens SET is_revoked = true WHERE user_id = $1 AND token <> $2;
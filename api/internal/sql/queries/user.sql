@@ -33,3 +33,12 @@ UPDATE users SET status = $2, updated_at = NOW() WHERE id = $1;
 
 -- name: ListAllUsers :many
 SELECT * FROM users ORDER BY created_at DESC;
+
+-- name: MarkUserPhoneVerified :exec
+UPDATE users SET phone_verified = true, updated_at = NOW() WHERE phone = $1;
+
+-- name: UpdateUserPassword :exec
+UPDATE users SET password_hash = $2, updated_at = NOW() WHERE id = $1;
+
+-- name: InvalidateUserTokens :exec
+UPDATE users SET tokens_valid_after = NOW(), updated_at = NOW() WHERE id = $1;
@@ -0,0 +1,80 @@
+-- name: CreateWebhook :one
+INSERT INTO webhooks (user_id, url, events, secret)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetWebhookByID :one
+SELECT * FROM webhooks WHERE id = $1 AND user_id = $2;
+
+-- name: GetWebhookForDelivery :one
+SELECT * FROM webhooks WHERE id = $1;
+
+-- name: ListWebhooksByUserID :many
+SELECT * FROM webhooks
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: ListActiveWebhooksForEvent :many
+SELECT * FROM webhooks
+WHERE user_id = @user_id AND active = TRUE AND @event_type::text = ANY(events);
+
+-- name: UpdateWebhook :one
+UPDATE webhooks
+SET url = $3, events = $4, active = $5, updated_at = NOW()
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: DeleteWebhook :execrows
+DELETE FROM webhooks WHERE id = $1 AND user_id = $2;
+
+-- name: CreateWebhookDelivery :one
+INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, payload)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetWebhookDeliveryByID :one
+SELECT * FROM webhook_deliveries WHERE id = $1 AND webhook_id = $2;
+
+-- name: ListWebhookDeliveries :many
+SELECT * FROM webhook_deliveries
+WHERE webhook_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2;
+
+-- name: ClaimWebhookDeliveries :many
+UPDATE webhook_deliveries
+SET status = 'delivering',
+    attempts = attempts + 1,
+    locked_until = @locked_until::timestamptz,
+    updated_at = NOW()
+WHERE id IN (
+    SELECT id FROM webhook_deliveries
+    WHERE (status = 'pending' AND next_attempt_at <= NOW())
+       OR (status = 'delivering' AND locked_until < NOW())
+    ORDER BY next_attempt_at, id
+    LIMIT @max_deliveries::int
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: CompleteWebhookDelivery :exec
+UPDATE webhook_deliveries
+SET status = 'delivered',
+    locked_until = NULL,
+    response_status = @response_status::int,
+    last_error = NULL,
+    duration_ms = @duration_ms::int,
+    delivered_at = NOW(),
+    updated_at = NOW()
+WHERE id = @id;
+
+-- name: FailWebhookDelivery :exec
+UPDATE webhook_deliveries
+SET status = CASE WHEN @retryable::boolean AND attempts < max_attempts THEN 'pending' ELSE 'failed' END,
+    next_attempt_at = NOW() + INTERVAL '30 seconds' * power(2, LEAST(attempts, 10) - 1),
+    locked_until = NULL,
+    response_status = sqlc.narg(response_status),
+    last_error = @last_error::text,
+    duration_ms = @duration_ms::int,
+    updated_at = NOW()
+WHERE id = @id;
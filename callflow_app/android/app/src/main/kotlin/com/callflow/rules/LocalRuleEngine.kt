@@ -44,6 +44,11 @@ class LocalRuleEngine {
     private var planType: String = "none"
     private var planExpiresAt: Long = 0
 
+    // SMS line assignment: the line this device is the designated sender for,
+    // and every line designated to some device of the account
+    private var smsLine: String = ""
+    private val assignedLines = mutableSetOf<String>()
+
     // Templates indexed by their ID
     private val templates = mutableMapOf<Long, TemplateData>()
 
@@ -62,6 +67,17 @@ class LocalRuleEngine {
                 planType = json.optString("plan", "none")
                 planExpiresAt = json.optLong("plan_expires_at", 0)
 
+                val assignment = json.optJSONObject("sms_assignment")
+                smsLine = assignment?.optString("sms_line", "")?.trim() ?: ""
+                assignedLines.clear()
+                val lines = assignment?.optJSONArray("assigned_lines")
+                if (lines != null) {
+                    for (i in 0 until lines.length()) {
+                        val line = lines.optString(i, "").trim()
+                        if (line.isNotEmpty()) assignedLines.add(line)
+                    }
+                }
+
                 // Load templates
                 val templatesArray = json.optJSONArray("templates")
                 if (templatesArray != null) {
@@ -181,6 +197,12 @@ class LocalRuleEngine {
 
         val smsConfig = ruleConfig.optJSONObject("sms")
         if (smsConfig != null && smsConfig.optBoolean("enabled", false)) {
+            if (!isSmsSender()) {
+                return@write RuleEvaluation(
+                    shouldProcess = false,
+                    reason = "SMS line assigned to another device"
+                )
+            }
             if (planType == "sms") {
                 smsSimSlot = ruleConfig.optInt("sms_sim_slot", 0)
                 val templateId = getTemplateIdForDirection(smsConfig, direction)
@@ -214,6 +236,14 @@ class LocalRuleEngine {
         )
     }
 
+    // Once lines are designated, only their designated devices send SMS, so
+    // phones sharing an account do not message the same caller twice.
+    // Must be called with the lock held.
+    private fun isSmsSender(): Boolean {
+        if (assignedLines.isEmpty()) return true
+        return smsLine.isNotEmpty() && assignedLines.contains(smsLine)
+    }
+
     private fun todayKey(): String {
         return SimpleDateFormat("yyyy-MM-dd", Locale.getDefault()).format(java.util.Date())
     }
@@ -1,3 +1,6 @@
 const String apiBaseUrl = 'https://adflow.up.railway.app/api/v1';
 const String landingBaseUrl = 'https://adflowapp.vercel.app';
 const String appendWebsiteUrlToSmsPrefKey = 'append_website_url_to_sms';
+const String landingUrlPrefKey = 'landing_url';
+const String deviceIdPrefKey = 'device_id';
+const String smsAssignmentPrefKey = 'sms_assignment';
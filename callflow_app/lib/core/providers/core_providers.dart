@@ -223,6 +223,21 @@ class SyncService {
         ));
       }
 
+      // Cache the slug-based landing URL appended to messages
+      final landingUrl = data['landing_url'] as String?;
+      if (landingUrl != null && landingUrl.isNotEmpty) {
+        final prefs = await SharedPreferences.getInstance();
+        await prefs.setString(landingUrlPrefKey, landingUrl);
+      }
+
+      // Cache which SMS lines this device may send for. The server leaves it
+      // out when the lookup fails; the last known assignment is kept then.
+      final smsAssignment = data['device'] as Map<String, dynamic>?;
+      if (smsAssignment != null) {
+        final prefs = await SharedPreferences.getInstance();
+        await prefs.setString(smsAssignmentPrefKey, jsonEncode(smsAssignment));
+      }
+
       // Update server templates
       final templatesData = data['templates'] as List<dynamic>?;
       if (templatesData != null) {
@@ -290,8 +305,9 @@ class SyncService {
       final rule = await _db.getRule();
       final user = await _db.getUser();
       final templates = await _db.getTemplates();
-      final landingUrl = user == null ? '' : '$landingBaseUrl/${user.id}';
+      final landingUrl = await _readLandingUrl(user);
       final appendWebsiteUrlToSms = await _readAppendWebsiteUrlSetting();
+      final smsAssignment = await _readSmsAssignment();
 
       if (rule == null) return;
 
@@ -302,6 +318,7 @@ class SyncService {
         'plan_expires_at': user?.planExpiresAt?.millisecondsSinceEpoch ?? 0,
         'landing_url': landingUrl,
         'append_website_url_to_sms': appendWebsiteUrlToSms,
+        'sms_assignment': smsAssignment,
         'templates': templates
             .map((t) => {
                   'id': t.serverId ?? t.id,
@@ -319,6 +336,17 @@ class SyncService {
     await _pushRuleConfigToNative();
   }
 
+  /// Prefers the slug-based URL from the last sync; older backends only
+  /// serve landing pages by user ID.
+  Future<String> _readLandingUrl(User? user) async {
+    try {
+      final prefs = await SharedPreferences.getInstance();
+      final cached = prefs.getString(landingUrlPrefKey) ?? '';
+      if (cached.isNotEmpty) return cached;
+    } catch (_) {}
+    return user == null ? '' : '$landingBaseUrl/${user.id}';
+  }
+
   Future<bool> _readAppendWebsiteUrlSetting() async {
     try {
       final prefs = await SharedPreferences.getInstance();
@@ -328,6 +356,17 @@ class SyncService {
     }
   }
 
+  Future<Map<String, dynamic>?> _readSmsAssignment() async {
+    try {
+      final prefs = await SharedPreferences.getInstance();
+      final raw = prefs.getString(smsAssignmentPrefKey);
+      if (raw == null || raw.isEmpty) return null;
+      return jsonDecode(raw) as Map<String, dynamic>;
+    } catch (_) {
+      return null;
+    }
+  }
+
   Future<void> pushRuleConfig(String configJson) async {
     try {
       await _api.put('/rules', data: {'config': jsonDecode(configJson)});
@@ -1,5 +1,9 @@
+import 'dart:math';
+
 import 'package:drift/drift.dart';
 import 'package:flutter_riverpod/flutter_riverpod.dart';
+import 'package:shared_preferences/shared_preferences.dart';
+import '../../../core/constants.dart';
 import '../../../core/database/app_database.dart';
 import '../../../core/network/api_client.dart';
 import '../../../core/network/auth_interceptor.dart';
@@ -34,6 +38,7 @@ class AuthService {
         'business_name': businessName,
         'city': city,
         'address': address,
+        'device': await _deviceRegistration(),
       },
     );
 
@@ -44,13 +49,34 @@ class AuthService {
   Future<void> login(String phone, String password) async {
     final response = await _api.post(
       '/auth/login',
-      data: {'phone': phone, 'password': password},
+      data: {
+        'phone': phone,
+        'password': password,
+        'device': await _deviceRegistration(),
+      },
     );
 
     final data = response.data['data'] as Map<String, dynamic>;
     await _saveAuthResponse(data);
   }
 
+  /// Registers this phone as a device of the account, so its token is bound
+  /// to it and it receives its SMS line assignment. The ID is generated once
+  /// and kept across logins.
+  Future<Map<String, dynamic>> _deviceRegistration() async {
+    final prefs = await SharedPreferences.getInstance();
+    var deviceId = prefs.getString(deviceIdPrefKey);
+    if (deviceId == null || deviceId.isEmpty) {
+      final random = Random.secure();
+      deviceId = List.generate(
+        16,
+        (_) => random.nextInt(256).toRadixString(16).padLeft(2, '0'),
+      ).join();
+      await prefs.setString(deviceIdPrefKey, deviceId);
+    }
+    return {'device_id': deviceId};
+  }
+
   Future<void> _saveAuthResponse(Map<String, dynamic> data) async {
     await AuthInterceptor.saveToken(data['access_token'] as String);
 
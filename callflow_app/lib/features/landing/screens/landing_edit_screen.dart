@@ -7,6 +7,7 @@ import 'package:flutter_riverpod/flutter_riverpod.dart';
 import 'package:go_router/go_router.dart';
 import 'package:image_picker/image_picker.dart';
 import 'package:path/path.dart' as p;
+import 'package:shared_preferences/shared_preferences.dart';
 
 import '../../../core/constants.dart';
 import '../../../core/database/app_database.dart';
@@ -106,13 +107,24 @@ class _LandingEditScreenState extends ConsumerState<LandingEditScreen> {
     _instagramController.text = landing['instagram_url'] as String? ?? '';
     _youtubeController.text = landing['youtube_url'] as String? ?? '';
     _emailController.text = landing['email'] as String? ?? '';
-    _setWebsiteUrlFromUserId(_extractInt(landing['user_id']));
+    final landingUrl = payload['landing_url'] as String? ?? '';
+    if (landingUrl.isNotEmpty) {
+      _websiteController.text = landingUrl;
+    } else {
+      _setWebsiteUrlFromUserId(_extractInt(landing['user_id']));
+    }
     _imageUrl = landing['image_url'] as String?;
     _locationController.text = payload['location_url'] as String? ?? '';
   }
 
   Future<void> _loadWebsiteUrlFromCache() async {
     try {
+      final prefs = await SharedPreferences.getInstance();
+      final cached = prefs.getString(landingUrlPrefKey) ?? '';
+      if (cached.isNotEmpty) {
+        if (mounted) _websiteController.text = cached;
+        return;
+      }
       final user = await ref.read(databaseProvider).getUser();
       _setWebsiteUrlFromUserId(user?.id);
     } catch (_) {}
@@ -306,6 +318,17 @@ class _LandingEditScreenState extends ConsumerState<LandingEditScreen> {
     await api.post('/landing', data: payload);
   }
 
+  // Saving stages the changes in the draft; publishing makes them live and
+  // records a revision.
+  Future<void> _publishLanding(ApiClient api) async {
+    try {
+      await api.post('/landing/publish');
+    } on DioException catch (e) {
+      // Deployments without drafts already published on save.
+      if (e.response?.statusCode != 404) rethrow;
+    }
+  }
+
   Future<void> _save() async {
     if (!_formKey.currentState!.validate()) return;
     setState(() => _saving = true);
@@ -340,6 +363,7 @@ class _LandingEditScreenState extends ConsumerState<LandingEditScreen> {
       };
 
       await _upsertLanding(api, payload);
+      await _publishLanding(api);
 
       if (!mounted) return;
       if (Navigator.of(context).canPop()) {
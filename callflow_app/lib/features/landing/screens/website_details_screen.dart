@@ -89,8 +89,10 @@ class _WebsiteDetailsScreenState extends ConsumerState<WebsiteDetailsScreen> {
         ? Map<String, dynamic>.from(payload['landing'] as Map)
         : <String, dynamic>{};
 
-    final userId = _extractInt(landing['user_id']);
-    final mappedWebsiteUrl = _buildWebsiteUrl(userId);
+    final landingUrl = payload['landing_url'] as String? ?? '';
+    final mappedWebsiteUrl = landingUrl.isNotEmpty
+        ? landingUrl
+        : _buildWebsiteUrl(_extractInt(landing['user_id']));
 
     if (!mounted) return;
     setState(() {
@@ -112,8 +114,12 @@ class _WebsiteDetailsScreenState extends ConsumerState<WebsiteDetailsScreen> {
 
   Future<void> _loadWebsiteUrlFromCache() async {
     try {
-      final user = await ref.read(databaseProvider).getUser();
-      final cachedWebsiteUrl = _buildWebsiteUrl(user?.id);
+      final prefs = await SharedPreferences.getInstance();
+      var cachedWebsiteUrl = prefs.getString(landingUrlPrefKey) ?? '';
+      if (cachedWebsiteUrl.isEmpty) {
+        final user = await ref.read(databaseProvider).getUser();
+        cachedWebsiteUrl = _buildWebsiteUrl(user?.id);
+      }
       if (cachedWebsiteUrl.isNotEmpty && mounted) {
         setState(() => _websiteUrl = cachedWebsiteUrl);
       }
@@ -22,6 +22,9 @@ class _RulesScreenState extends ConsumerState<RulesScreen> {
   int? _smsIncomingTemplateId;
   int? _smsOutgoingTemplateId;
   int? _smsMissedTemplateId;
+  int? _smsLeadTemplateId;
+  int? _smsEnquiryTemplateId;
+  bool _smsEnquiryAutoReply = false;
 
   // Unique per day
   bool _uniquePerDay = true;
@@ -78,6 +81,15 @@ class _RulesScreenState extends ConsumerState<RulesScreen> {
               _parseTemplateId(sms['missed_template_id']),
               smsTemplates,
             );
+            _smsLeadTemplateId = _normalizeTemplateId(
+              _parseTemplateId(sms['lead_template_id']),
+              smsTemplates,
+            );
+            _smsEnquiryTemplateId = _normalizeTemplateId(
+              _parseTemplateId(sms['enquiry_template_id']),
+              smsTemplates,
+            );
+            _smsEnquiryAutoReply = sms['enquiry_auto_reply'] as bool? ?? false;
           }
 
           _uniquePerDay = config['unique_per_day'] as bool? ?? true;
@@ -146,6 +158,11 @@ class _RulesScreenState extends ConsumerState<RulesScreen> {
           'outgoing_template_id': _smsOutgoingTemplateId,
         if (_smsMissedTemplateId != null)
           'missed_template_id': _smsMissedTemplateId,
+        if (_smsLeadTemplateId != null)
+          'lead_template_id': _smsLeadTemplateId,
+        if (_smsEnquiryTemplateId != null)
+          'enquiry_template_id': _smsEnquiryTemplateId,
+        'enquiry_auto_reply': _smsEnquiryAutoReply,
       },
       'excluded_numbers': _excludedNumbers,
       if (_workingHoursEnabled)
@@ -274,6 +291,36 @@ class _RulesScreenState extends ConsumerState<RulesScreen> {
                             onChanged: (v) =>
                                 setState(() => _smsMissedTemplateId = v),
                           ),
+                          const SizedBox(height: 12),
+                          _TemplateDropdown(
+                            label: 'Inbound Lead',
+                            icon: Icons.person_add_alt,
+                            callType: 'lead',
+                            value: _smsLeadTemplateId,
+                            templates: templates,
+                            onChanged: (v) =>
+                                setState(() => _smsLeadTemplateId = v),
+                          ),
+                          const SizedBox(height: 12),
+                          SwitchListTile(
+                            contentPadding: EdgeInsets.zero,
+                            title: const Text('Reply to Landing Enquiries'),
+                            subtitle: const Text(
+                                'Text visitors who send an enquiry, once per number a day'),
+                            value: _smsEnquiryAutoReply,
+                            onChanged: (v) =>
+                                setState(() => _smsEnquiryAutoReply = v),
+                          ),
+                          if (_smsEnquiryAutoReply)
+                            _TemplateDropdown(
+                              label: 'Landing Enquiry',
+                              icon: Icons.contact_mail_outlined,
+                              callType: 'enquiry',
+                              value: _smsEnquiryTemplateId,
+                              templates: templates,
+                              onChanged: (v) =>
+                                  setState(() => _smsEnquiryTemplateId = v),
+                            ),
                         ],
                       ),
                     ),
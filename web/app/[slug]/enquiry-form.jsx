@@ -0,0 +1,66 @@
+'use client';
+
+import { useState } from 'react';
+import { useI18n } from '../i18n-context';
+
+// EnquiryForm sends the visitor's details to the page owner as a lead. The
+// website field is a honeypot kept out of sight of people.
+export default function EnquiryForm({ enquiryUrl = '' }) {
+  const { t } = useI18n();
+  const [status, setStatus] = useState('idle');
+
+  if (!enquiryUrl) return null;
+
+  async function handleSubmit(event) {
+    event.preventDefault();
+    const form = new FormData(event.currentTarget);
+    setStatus('sending');
+    try {
+      const res = await fetch(enquiryUrl, {
+        method: 'POST',
+        headers: { 'Content-Type': 'application/json' },
+        body: JSON.stringify({
+          name: form.get('name'),
+          phone: form.get('phone'),
+          message: form.get('message'),
+          website: form.get('website'),
+        }),
+      });
+      if (res.status === 429) {
+        setStatus('limited');
+      } else {
+        setStatus(res.ok ? 'sent' : 'error');
+      }
+    } catch {
+      setStatus('error');
+    }
+  }
+
+  return (
+    <section className="section">
+      <h2 className="landing-section-title">{t('landing.enquiry.title')}</h2>
+      {status === 'sent' ? (
+        <p className="enquiry-status">{t('landing.enquiry.sent')}</p>
+      ) : (
+        <form className="enquiry" onSubmit={handleSubmit}>
+          <input name="name" required maxLength={255} placeholder={t('landing.enquiry.name')} autoComplete="name" />
+          <input
+            name="phone"
+            type="tel"
+            required
+            maxLength={20}
+            placeholder={t('landing.enquiry.phone')}
+            autoComplete="tel"
+          />
+          <textarea name="message" rows={3} maxLength={1000} placeholder={t('landing.enquiry.message')} />
+          <input name="website" className="enquiry-trap" tabIndex={-1} autoComplete="off" aria-hidden="true" />
+          <button type="submit" className="action" disabled={status === 'sending'}>
+            {status === 'sending' ? t('landing.enquiry.sending') : t('landing.enquiry.submit')}
+          </button>
+          {status === 'error' ? <p className="enquiry-status">{t('landing.enquiry.error')}</p> : null}
+          {status === 'limited' ? <p className="enquiry-status">{t('landing.enquiry.limited')}</p> : null}
+        </form>
+      )}
+    </section>
+  );
+}
@@ -0,0 +1,137 @@
+'use client';
+
+import { useEffect } from 'react';
+import { useI18n } from '../i18n-context';
+import EnquiryForm from './enquiry-form';
+import LandingSections from './landing-sections';
+
+function buildMapUrl(user) {
+  if (!user) return '';
+  if (user.location_url) return user.location_url;
+  const address = [user.address, user.city].filter(Boolean).join(', ');
+  if (!address) return '';
+  return `https://www.google.com/maps/search/?api=1&query=${encodeURIComponent(address)}`;
+}
+
+function descriptionToPoints(description) {
+  if (!description || typeof description !== 'string') return [];
+  return description
+    .split(/\r?\n/)
+    .map((line) => line.trim())
+    .map((line) => line.replace(/^[-*\u2022]\s+/, '').replace(/^\d+[.)]\s+/, ''))
+    .filter(Boolean);
+}
+
+// trackEvent reports a view or click; keepalive lets a click outlive the
+// navigation it triggers. Failures never affect the page.
+function trackEvent(eventsUrl, token, type, target) {
+  if (!eventsUrl) return;
+  const body = { type };
+  if (target) body.target = target;
+  if (token) body.token = token;
+  fetch(eventsUrl, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify(body),
+    keepalive: true,
+  }).catch(() => {});
+}
+
+export default function LandingContent({
+  user = {},
+  landing = {},
+  sections = [],
+  eventsUrl = '',
+  enquiryUrl = '',
+  token = '',
+  preview = false,
+}) {
+  const { t } = useI18n();
+
+  useEffect(() => {
+    trackEvent(eventsUrl, token, 'view');
+  }, [eventsUrl, token]);
+
+  const headline =
+    landing.headline || user.business_name || user.name || t('landing.fallbackTitle');
+  const descriptionPoints = descriptionToPoints(landing.description || '');
+  const imageUrl = landing.image_url || '';
+  const mapUrl = buildMapUrl(user);
+
+  const actions = [
+    user.phone ? { target: 'call', label: t('landing.actions.call'), href: `tel:${user.phone}` } : null,
+    landing.whatsapp_url
+      ? { target: 'whatsapp', label: t('landing.actions.whatsapp'), href: landing.whatsapp_url }
+      : null,
+    landing.facebook_url
+      ? { target: 'facebook', label: t('landing.actions.facebook'), href: landing.facebook_url }
+      : null,
+    landing.instagram_url
+      ? { target: 'instagram', label: t('landing.actions.instagram'), href: landing.instagram_url }
+      : null,
+    landing.youtube_url
+      ? { target: 'youtube', label: t('landing.actions.youtube'), href: landing.youtube_url }
+      : null,
+    landing.email ? { target: 'email', label: t('landing.actions.email'), href: `mailto:${landing.email}` } : null,
+    landing.website_url
+      ? { target: 'website', label: t('landing.actions.website'), href: landing.website_url }
+      : null,
+    mapUrl ? { target: 'map', label: t('landing.actions.maps'), href: mapUrl } : null,
+  ].filter(Boolean);
+
+  return (
+    <main className="container">
+      {preview ? <p className="preview-banner">{t('landing.preview')}</p> : null}
+      <section className="hero">
+        {imageUrl ? (
+          <img src={imageUrl} alt={headline} />
+        ) : (
+          <div
+            style={{
+              width: '100%',
+              height: '160px',
+              borderRadius: '16px',
+              background: 'linear-gradient(135deg, #f1d6e3, #e7e0f4)',
+              display: 'flex',
+              alignItems: 'center',
+              justifyContent: 'center',
+              color: '#6b5d70',
+              fontWeight: 600,
+            }}
+          >
+            {t('landing.noImage')}
+          </div>
+        )}
+        <div>
+          <p className="label">{t('landing.partnerLabel')}</p>
+          <h1>{headline}</h1>
+          {descriptionPoints.length ? (
+            <ul className="hero-points">
+              {descriptionPoints.map((point, index) => (
+                <li key={`${point}-${index}`}>{point}</li>
+              ))}
+            </ul>
+          ) : null}
+          {actions.length ? (
+            <div className="actions">
+              {actions.map((action) => (
+                <a
+                  key={action.target}
+                  className="action"
+                  href={action.href}
+                  target="_blank"
+                  rel="noreferrer"
+                  onClick={() => trackEvent(eventsUrl, token, 'click', action.target)}
+                >
+                  {action.label}
+                </a>
+              ))}
+            </div>
+          ) : null}
+        </div>
+      </section>
+      <LandingSections sections={sections} />
+      <EnquiryForm enquiryUrl={enquiryUrl} />
+    </main>
+  );
+}
@@ -0,0 +1,108 @@
+'use client';
+
+import { useI18n } from '../i18n-context';
+
+const DAY_ORDER = ['mon', 'tue', 'wed', 'thu', 'fri', 'sat', 'sun'];
+
+function GallerySection({ section }) {
+  return (
+    <div className="gallery">
+      {section.images.map((image) => (
+        <figure key={image.media_id}>
+          <img src={image.thumbnail_url || image.url} alt={image.caption || ''} loading="lazy" />
+          {image.caption ? <figcaption>{image.caption}</figcaption> : null}
+        </figure>
+      ))}
+    </div>
+  );
+}
+
+function ServicesSection({ section }) {
+  return (
+    <div className="site-grid">
+      {section.services.map((item, index) => (
+        <div key={`${item.name}-${index}`} className="site-card service-item">
+          <div>
+            <h3>{item.name}</h3>
+            {item.description ? <p>{item.description}</p> : null}
+          </div>
+          {item.price ? <strong>{item.price}</strong> : null}
+        </div>
+      ))}
+    </div>
+  );
+}
+
+function HoursSection({ section }) {
+  const { t } = useI18n();
+  const hours = [...section.hours].sort((a, b) => DAY_ORDER.indexOf(a.day) - DAY_ORDER.indexOf(b.day));
+  return (
+    <table className="hours">
+      <tbody>
+        {hours.map((h) => (
+          <tr key={h.day}>
+            <th scope="row">{t(`landing.days.${h.day}`)}</th>
+            <td>{h.closed ? t('landing.sections.closed') : `${h.open} – ${h.close}`}</td>
+          </tr>
+        ))}
+      </tbody>
+    </table>
+  );
+}
+
+function OfferSection({ section }) {
+  const { t } = useI18n();
+  const { offer } = section;
+  return (
+    <div className="site-card">
+      <h3>{offer.title}</h3>
+      {offer.description ? <p>{offer.description}</p> : null}
+      {offer.code ? (
+        <p className="site-url">
+          {t('landing.sections.code')}: {offer.code}
+        </p>
+      ) : null}
+      {offer.expires_at ? (
+        <p className="label">
+          {t('landing.sections.validUntil')} {new Date(offer.expires_at).toLocaleDateString()}
+        </p>
+      ) : null}
+    </div>
+  );
+}
+
+function FAQSection({ section }) {
+  return (
+    <div className="faq">
+      {section.faqs.map((item, index) => (
+        <details key={`${item.question}-${index}`}>
+          <summary>{item.question}</summary>
+          <p>{item.answer}</p>
+        </details>
+      ))}
+    </div>
+  );
+}
+
+const RENDERERS = {
+  gallery: GallerySection,
+  services: ServicesSection,
+  hours: HoursSection,
+  offer: OfferSection,
+  faq: FAQSection,
+};
+
+// Sections of unknown types, from a newer API, are skipped.
+export default function LandingSections({ sections = [] }) {
+  const { t } = useI18n();
+  return sections.map((section) => {
+    const Renderer = RENDERERS[section.type];
+    if (!Renderer) return null;
+    return (
+      <section key={section.id} className="section">
+        <h2 className="landing-section-title">{section.title || t(`landing.sections.${section.type}`)}</h2>
+        <Renderer section={section} />
+      </section>
+    );
+  });
+}
@@ -0,0 +1,68 @@
+import { notFound, permanentRedirect } from 'next/navigation';
+import LandingContent from './landing-content';
+
+const API_BASE = process.env.NEXT_PUBLIC_API_BASE || 'https://adflow.up.railway.app/api/v1';
+
+async function fetchLanding(slug, preview) {
+  const path = preview
+    ? `${encodeURIComponent(slug)}/preview/${encodeURIComponent(preview)}`
+    : encodeURIComponent(slug);
+  const res = await fetch(`${API_BASE}/public/landing/${path}`, {
+    cache: 'no-store',
+    redirect: 'manual',
+  });
+  // Former slugs and old numeric links redirect to the current slug.
+  if (res.status === 301 || res.status === 308) {
+    const location = res.headers.get('location') || '';
+    const current = location.split('/').filter(Boolean).pop();
+    return current ? { redirectTo: decodeURIComponent(current) } : null;
+  }
+  if (!res.ok) {
+    return null;
+  }
+  const body = await res.json();
+  if (!body || !body.success || !body.data) {
+    return null;
+  }
+  return body.data;
+}
+
+// Draft previews are shared by link only and must stay out of search results.
+export function generateMetadata({ searchParams }) {
+  return searchParams?.preview ? { robots: { index: false, follow: false } } : {};
+}
+
+export default async function LandingPage({ params, searchParams }) {
+  const preview = typeof searchParams?.preview === 'string' ? searchParams.preview : '';
+  const data = await fetchLanding(params.slug, preview);
+  if (!data) {
+    notFound();
+  }
+  // The attribution token of the message link and the preview token survive
+  // the redirect.
+  const token = typeof searchParams?.t === 'string' ? searchParams.t : '';
+  if (data.redirectTo) {
+    const query = new URLSearchParams();
+    if (token) query.set('t', token);
+    if (preview) query.set('preview', preview);
+    const search = query.toString();
+    permanentRedirect(`/${encodeURIComponent(data.redirectTo)}${search ? `?${search}` : ''}`);
+  }
+
+  const user = data.user || {};
+  const landing = data.landing || {};
+  const landingApi = `${API_BASE}/public/landing/${encodeURIComponent(data.slug || params.slug)}`;
+  const sections = Array.isArray(data.sections) ? data.sections : [];
+  // A preview neither counts as a visit nor accepts enquiries.
+  return (
+    <LandingContent
+      user={user}
+      landing={landing}
+      sections={sections}
+      eventsUrl={preview ? '' : `${landingApi}/events`}
+      enquiryUrl={preview ? '' : `${landingApi}/enquiry`}
+      token={token}
+      preview={Boolean(preview)}
+    />
+  );
+}
@@ -55,8 +55,10 @@ export const TRANSLATIONS = {
       partnerLabel: 'AdFlow Partner',
       noImage: 'No image',
       fallbackTitle: 'Welcome',
+      preview: 'Preview of unpublished changes. Customers still see the live page.',
       actions: {
         call: 'Call',
+        whatsapp: 'WhatsApp',
         facebook: 'Facebook',
         instagram: 'Instagram',
         youtube: 'YouTube',
@@ -64,6 +66,36 @@ export const TRANSLATIONS = {
         website: 'Website',
         maps: 'Maps',
       },
+      sections: {
+        gallery: 'Gallery',
+        services: 'Services',
+        hours: 'Opening hours',
+        offer: 'Offer',
+        faq: 'Questions',
+        closed: 'Closed',
+        code: 'Code',
+        validUntil: 'Valid until',
+      },
+      days: {
+        mon: 'Monday',
+        tue: 'Tuesday',
+        wed: 'Wednesday',
+        thu: 'Thursday',
+        fri: 'Friday',
+        sat: 'Saturday',
+        sun: 'Sunday',
+      },
+      enquiry: {
+        title: 'Send an enquiry',
+        name: 'Your name',
+        phone: 'Phone number',
+        message: 'Message (optional)',
+        submit: 'Send',
+        sending: 'Sending…',
+        sent: 'Thanks! We will get back to you soon.',
+        error: 'Could not send your enquiry. Please check your number and try again.',
+        limited: 'Too many enquiries. Please try again later.',
+      },
     },
     notFound: {
       label: 'Not Found',
@@ -124,8 +156,10 @@ export const TRANSLATIONS = {
       partnerLabel: 'AdFlow पार्टनर',
       noImage: 'प्रतिमा नाही',
       fallbackTitle: 'स्वागत आहे',
+      preview: 'प्रकाशित न केलेल्या बदलांचे पूर्वावलोकन. ग्राहकांना अजूनही सध्याचे पेज दिसते.',
       actions: {
         call: 'कॉल',
+        whatsapp: 'व्हॉट्सॲप',
         facebook: 'फेसबुक',
         instagram: 'इंस्टाग्राम',
         youtube: 'यूट्यूब',
@@ -133,6 +167,36 @@ export const TRANSLATIONS = {
         website: 'वेबसाइट',
         maps: 'नकाशा',
       },
+      sections: {
+        gallery: 'फोटो',
+        services: 'सेवा',
+        hours: 'वेळ',
+        offer: 'ऑफर',
+        faq: 'प्रश्न',
+        closed: 'बंद',
+        code: 'कोड',
+        validUntil: 'पर्यंत वैध',
+      },
+      days: {
+        mon: 'सोमवार',
+        tue: 'मंगळवार',
+        wed: 'बुधवार',
+        thu: 'गुरुवार',
+        fri: 'शुक्रवार',
+        sat: 'शनिवार',
+        sun: 'रविवार',
+      },
+      enquiry: {
+        title: 'चौकशी पाठवा',
+        name: 'तुमचे नाव',
+        phone: 'फोन नंबर',
+        message: 'संदेश (ऐच्छिक)',
+        submit: 'पाठवा',
+        sending: 'पाठवत आहे…',
+        sent: 'धन्यवाद! आम्ही लवकरच तुमच्याशी संपर्क साधू.',
+        error: 'चौकशी पाठवता आली नाही. कृपया नंबर तपासून पुन्हा प्रयत्न करा.',
+        limited: 'खूप चौकशा झाल्या. कृपया नंतर पुन्हा प्रयत्न करा.',
+      },
     },
     notFound: {
       label: 'आढळले नाही',